@@ -0,0 +1,69 @@
+package caches
+
+import "fmt"
+
+// Stats holds hit, miss and eviction counters for a cache
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns the fraction of lookups that were hits
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// String returns a string representation of the counters
+func (s Stats) String() string {
+	return fmt.Sprintf("hits=%d misses=%d evictions=%d hit rate=%.2f",
+		s.Hits, s.Misses, s.Evictions, s.HitRate())
+}
+
+// config holds the settings collected from Options
+type config[K comparable, V any] struct {
+	cost    func(key K, value V) int
+	onEvict func(key K, value V)
+}
+
+// Option configures a cache created by NewLRUCache or NewLFUCache
+type Option[K comparable, V any] func(*config[K, V])
+
+// WithCost makes the capacity a budget over the given cost function
+// instead of a count of entries. Costs below 1 are treated as 1.
+func WithCost[K comparable, V any](cost func(key K, value V) int) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.cost = cost
+	}
+}
+
+// WithOnEvict registers a callback that runs whenever an entry is evicted
+// to make room. It is not called for explicit Delete or Clear.
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V)) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.onEvict = onEvict
+	}
+}
+
+func newConfig[K comparable, V any](opts []Option[K, V]) config[K, V] {
+	var cfg config[K, V]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// costOf returns the cost of an entry under the configured cost function
+func (c config[K, V]) costOf(key K, value V) int {
+	if c.cost == nil {
+		return 1
+	}
+	if cost := c.cost(key, value); cost > 1 {
+		return cost
+	}
+	return 1
+}
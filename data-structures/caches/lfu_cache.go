@@ -0,0 +1,258 @@
+package caches
+
+import (
+	"fmt"
+	"strings"
+
+	hashtables "go-programming/data-structures/hash-tables"
+	linkedlists "go-programming/data-structures/linked-lists"
+)
+
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	cost   int
+	node   *linkedlists.DoublyNode[*lfuEntry[K, V]]
+	bucket *linkedlists.DoublyNode[*lfuBucket[K, V]]
+}
+
+// lfuBucket holds every entry with the same access frequency,
+// most recently used first
+type lfuBucket[K comparable, V any] struct {
+	frequency int
+	entries   *linkedlists.DoublyLinkedList[*lfuEntry[K, V]]
+}
+
+// LFUCache represents a least-frequently-used cache. Entries are grouped
+// into frequency buckets kept in a doubly linked list sorted by frequency,
+// so finding the victim and bumping a frequency are O(1). Ties are broken
+// by evicting the least recently used entry of the lowest frequency.
+type LFUCache[K comparable, V any] struct {
+	items    *hashtables.HashTable[K, *lfuEntry[K, V]]
+	buckets  *linkedlists.DoublyLinkedList[*lfuBucket[K, V]]
+	size     int
+	capacity int
+	cost     int
+	cfg      config[K, V]
+	stats    Stats
+}
+
+// NewLFUCache creates a new LFU cache. By default capacity is the maximum
+// number of entries; with WithCost it is the maximum total cost.
+func NewLFUCache[K comparable, V any](capacity int, opts ...Option[K, V]) *LFUCache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LFUCache[K, V]{
+		items:    hashtables.NewHashTable[K, *lfuEntry[K, V]](16),
+		buckets:  linkedlists.NewDoublyLinkedList[*lfuBucket[K, V]](),
+		capacity: capacity,
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get returns the value for key and increments its frequency
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	entry, found := c.items.Get(key)
+	if !found {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.touch(entry)
+	return entry.value, true
+}
+
+// Peek returns the value for key without affecting frequency or counters
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	if entry, found := c.items.Get(key); found {
+		return entry.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Frequency returns how often key has been used, or 0 if it is not cached
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	if entry, found := c.items.Get(key); found {
+		return entry.bucket.Data.frequency
+	}
+	return 0
+}
+
+// touch moves an entry into the bucket for the next frequency
+func (c *LFUCache[K, V]) touch(entry *lfuEntry[K, V]) {
+	current := entry.bucket
+	next := current.Next()
+	if next == nil || next.Data.frequency != current.Data.frequency+1 {
+		next = c.buckets.InsertAfter(c.newBucket(current.Data.frequency+1), current)
+	}
+
+	current.Data.entries.Remove(entry.node)
+	c.place(entry, next)
+	if current.Data.entries.IsEmpty() {
+		c.buckets.Remove(current)
+	}
+}
+
+func (c *LFUCache[K, V]) newBucket(frequency int) *lfuBucket[K, V] {
+	return &lfuBucket[K, V]{
+		frequency: frequency,
+		entries:   linkedlists.NewDoublyLinkedList[*lfuEntry[K, V]](),
+	}
+}
+
+// place puts an entry at the front of a frequency bucket
+func (c *LFUCache[K, V]) place(entry *lfuEntry[K, V], bucket *linkedlists.DoublyNode[*lfuBucket[K, V]]) {
+	entry.bucket = bucket
+	entry.node = bucket.Data.entries.PushFront(entry)
+}
+
+// Put inserts or updates a value, evicting the least frequently used
+// entries until it fits. Updating counts as a use and never evicts the
+// updated entry itself. An entry that costs more than the whole capacity
+// is not cached.
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	cost := c.cfg.costOf(key, value)
+	entry, found := c.items.Get(key)
+	if cost > c.capacity {
+		if found {
+			c.remove(entry)
+		}
+		return
+	}
+
+	if found {
+		c.cost += cost - entry.cost
+		entry.value = value
+		entry.cost = cost
+		c.touch(entry)
+	} else {
+		entry = &lfuEntry[K, V]{key: key, value: value, cost: cost}
+		c.insert(entry)
+	}
+	for c.cost > c.capacity {
+		c.evict(entry)
+	}
+}
+
+// insert adds a new entry with frequency 1
+func (c *LFUCache[K, V]) insert(entry *lfuEntry[K, V]) {
+	first := c.buckets.Front()
+	if first == nil || first.Data.frequency != 1 {
+		first = c.buckets.PushFront(c.newBucket(1))
+	}
+	c.place(entry, first)
+	c.items.Put(entry.key, entry)
+	c.size++
+	c.cost += entry.cost
+}
+
+// evict removes the least recently used entry of the lowest frequency,
+// skipping the entry that is being written
+func (c *LFUCache[K, V]) evict(skip *lfuEntry[K, V]) {
+	for bucket := c.buckets.Front(); bucket != nil; bucket = bucket.Next() {
+		for node := bucket.Data.entries.Back(); node != nil; node = node.Prev() {
+			if entry := node.Data; entry != skip {
+				c.remove(entry)
+				c.stats.Evictions++
+				if c.cfg.onEvict != nil {
+					c.cfg.onEvict(entry.key, entry.value)
+				}
+				return
+			}
+		}
+	}
+}
+
+// remove unlinks an entry from its bucket and the index
+func (c *LFUCache[K, V]) remove(entry *lfuEntry[K, V]) {
+	bucket := entry.bucket
+	bucket.Data.entries.Remove(entry.node)
+	if bucket.Data.entries.IsEmpty() {
+		c.buckets.Remove(bucket)
+	}
+	c.items.Delete(entry.key)
+	c.size--
+	c.cost -= entry.cost
+}
+
+// Delete removes key from the cache
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	entry, found := c.items.Get(key)
+	if !found {
+		return false
+	}
+	c.remove(entry)
+	return true
+}
+
+// Contains checks if key is cached without affecting frequency or counters
+func (c *LFUCache[K, V]) Contains(key K) bool {
+	return c.items.Contains(key)
+}
+
+// Size returns the number of cached entries
+func (c *LFUCache[K, V]) Size() int {
+	return c.size
+}
+
+// Cost returns the total cost of the cached entries
+func (c *LFUCache[K, V]) Cost() int {
+	return c.cost
+}
+
+// Capacity returns the maximum total cost of the cache
+func (c *LFUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Keys returns the cached keys in eviction order: lowest frequency first,
+// least recently used first within a frequency
+func (c *LFUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, c.size)
+	for bucket := range c.buckets.All() {
+		for entry := range bucket.entries.Backward() {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// Stats returns the hit, miss and eviction counters
+func (c *LFUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Clear removes all entries without calling the eviction callback
+func (c *LFUCache[K, V]) Clear() {
+	c.items.Clear()
+	c.buckets.Clear()
+	c.size = 0
+	c.cost = 0
+}
+
+// String returns the cached entries grouped by frequency, in eviction order
+func (c *LFUCache[K, V]) String() string {
+	var result strings.Builder
+	result.WriteString("LFUCache: [")
+	for node := c.buckets.Front(); node != nil; node = node.Next() {
+		result.WriteString(fmt.Sprintf("%d: {", node.Data.frequency))
+		first := true
+		for entry := range node.Data.entries.Backward() {
+			if !first {
+				result.WriteString(", ")
+			}
+			result.WriteString(fmt.Sprintf("%v: %v", entry.key, entry.value))
+			first = false
+		}
+		result.WriteString("}")
+		if node.Next() != nil {
+			result.WriteString(", ")
+		}
+	}
+	result.WriteString("]")
+	return result.String()
+}
@@ -0,0 +1,103 @@
+package caches
+
+import (
+	"testing"
+)
+
+func TestLFUCache(t *testing.T) {
+	var evicted []string
+	cache := NewLFUCache(3, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+	if cache.Frequency("a") != 3 || cache.Frequency("b") != 2 || cache.Frequency("c") != 1 {
+		t.Errorf("Unexpected frequencies: %s", cache)
+	}
+
+	// c is the least frequently used
+	cache.Put("d", 4)
+	if cache.Contains("c") {
+		t.Error("Expected c to be evicted")
+	}
+
+	// Ties at the lowest frequency evict the least recently used
+	cache.Get("d")
+	cache.Put("e", 5)
+	if !equalKeys(evicted, []string{"c", "b"}) {
+		t.Errorf("Expected evictions [c b], got %v", evicted)
+	}
+	if got := cache.Keys(); !equalKeys(got, []string{"e", "d", "a"}) {
+		t.Errorf("Expected eviction order [e d a], got %v", got)
+	}
+	if got := cache.String(); got != "LFUCache: [1: {e: 5}, 2: {d: 4}, 3: {a: 1}]" {
+		t.Errorf("Unexpected string: %s", got)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 4 || stats.Misses != 0 || stats.Evictions != 2 {
+		t.Errorf("Unexpected stats: %s", stats)
+	}
+	cache.Get("missing")
+	if cache.Stats().HitRate() != 0.8 {
+		t.Errorf("Expected hit rate 0.8, got %f", cache.Stats().HitRate())
+	}
+
+	// Updating counts as a use
+	cache.Put("e", 50)
+	if val, _ := cache.Peek("e"); val != 50 || cache.Frequency("e") != 2 {
+		t.Errorf("Expected e=50 at frequency 2, got %d at %d", val, cache.Frequency("e"))
+	}
+
+	if !cache.Delete("a") || cache.Size() != 2 {
+		t.Errorf("Expected a to be deleted, size %d", cache.Size())
+	}
+	cache.Clear()
+	if cache.Size() != 0 || len(cache.Keys()) != 0 || cache.String() != "LFUCache: []" {
+		t.Errorf("Expected empty cache, got %s", cache)
+	}
+}
+
+func TestLFUCacheCost(t *testing.T) {
+	cache := NewLFUCache(10, WithCost(func(key string, value []byte) int {
+		return len(value)
+	}))
+	cache.Put("hot", make([]byte, 4))
+	cache.Get("hot")
+	cache.Put("cold", make([]byte, 4))
+
+	// The written entry is never its own victim, even at the lowest frequency
+	cache.Put("new", make([]byte, 6))
+	if cache.Contains("cold") || !cache.Contains("new") || !cache.Contains("hot") {
+		t.Errorf("Expected cold to be evicted, got %v", cache.Keys())
+	}
+	if cache.Cost() != 10 {
+		t.Errorf("Expected cost 10, got %d", cache.Cost())
+	}
+
+	// Growing the hot entry evicts the colder one instead
+	cache.Put("hot", make([]byte, 8))
+	if !cache.Contains("hot") || cache.Contains("new") || cache.Cost() != 8 {
+		t.Errorf("Expected only hot to remain, got %v cost %d", cache.Keys(), cache.Cost())
+	}
+
+	cache.Put("huge", make([]byte, 11))
+	if cache.Contains("huge") || cache.Stats().Evictions != 2 {
+		t.Errorf("Expected oversized entry to be dropped, stats %s", cache.Stats())
+	}
+}
+
+func BenchmarkLFUCache(b *testing.B) {
+	cache := NewLFUCache[int, int](1024)
+	for i := 0; i < b.N; i++ {
+		key := (i * 7) % 2048
+		if _, found := cache.Get(key); !found {
+			cache.Put(key, i)
+		}
+	}
+}
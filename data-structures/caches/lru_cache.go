@@ -0,0 +1,171 @@
+package caches
+
+import (
+	"fmt"
+	"strings"
+
+	hashtables "go-programming/data-structures/hash-tables"
+	linkedlists "go-programming/data-structures/linked-lists"
+)
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+	cost  int
+}
+
+// LRUCache represents a least-recently-used cache. A hash table maps keys
+// to nodes of a doubly linked list ordered from most to least recently
+// used, so Get, Put and eviction are all O(1).
+type LRUCache[K comparable, V any] struct {
+	items    *hashtables.HashTable[K, *linkedlists.DoublyNode[*lruEntry[K, V]]]
+	order    *linkedlists.DoublyLinkedList[*lruEntry[K, V]]
+	capacity int
+	cost     int
+	cfg      config[K, V]
+	stats    Stats
+}
+
+// NewLRUCache creates a new LRU cache. By default capacity is the maximum
+// number of entries; with WithCost it is the maximum total cost.
+func NewLRUCache[K comparable, V any](capacity int, opts ...Option[K, V]) *LRUCache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRUCache[K, V]{
+		items:    hashtables.NewHashTable[K, *linkedlists.DoublyNode[*lruEntry[K, V]]](16),
+		order:    linkedlists.NewDoublyLinkedList[*lruEntry[K, V]](),
+		capacity: capacity,
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get returns the value for key and marks it as most recently used
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	node, found := c.items.Get(key)
+	if !found {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.order.MoveToFront(node)
+	return node.Data.value, true
+}
+
+// Peek returns the value for key without affecting recency or counters
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	if node, found := c.items.Get(key); found {
+		return node.Data.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put inserts or updates a value and marks it as most recently used,
+// evicting least recently used entries until it fits. An entry that
+// costs more than the whole capacity is not cached.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	cost := c.cfg.costOf(key, value)
+	if cost > c.capacity {
+		c.Delete(key)
+		return
+	}
+
+	if node, found := c.items.Get(key); found {
+		c.cost += cost - node.Data.cost
+		node.Data.value = value
+		node.Data.cost = cost
+		c.order.MoveToFront(node)
+	} else {
+		c.items.Put(key, c.order.PushFront(&lruEntry[K, V]{key: key, value: value, cost: cost}))
+		c.cost += cost
+	}
+
+	// The written entry is at the front, so it is never its own victim
+	for c.cost > c.capacity {
+		c.evict()
+	}
+}
+
+// evict removes the least recently used entry
+func (c *LRUCache[K, V]) evict() {
+	entry, ok := c.order.PopBack()
+	if !ok {
+		return
+	}
+	c.items.Delete(entry.key)
+	c.cost -= entry.cost
+	c.stats.Evictions++
+	if c.cfg.onEvict != nil {
+		c.cfg.onEvict(entry.key, entry.value)
+	}
+}
+
+// Delete removes key from the cache
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	node, found := c.items.Get(key)
+	if !found {
+		return false
+	}
+	c.order.Remove(node)
+	c.items.Delete(key)
+	c.cost -= node.Data.cost
+	return true
+}
+
+// Contains checks if key is cached without affecting recency or counters
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	return c.items.Contains(key)
+}
+
+// Size returns the number of cached entries
+func (c *LRUCache[K, V]) Size() int {
+	return c.order.Size()
+}
+
+// Cost returns the total cost of the cached entries
+func (c *LRUCache[K, V]) Cost() int {
+	return c.cost
+}
+
+// Capacity returns the maximum total cost of the cache
+func (c *LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Keys returns the cached keys from most to least recently used
+func (c *LRUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, c.order.Size())
+	for entry := range c.order.All() {
+		keys = append(keys, entry.key)
+	}
+	return keys
+}
+
+// Stats returns the hit, miss and eviction counters
+func (c *LRUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Clear removes all entries without calling the eviction callback
+func (c *LRUCache[K, V]) Clear() {
+	c.items.Clear()
+	c.order.Clear()
+	c.cost = 0
+}
+
+// String returns the cached entries from most to least recently used
+func (c *LRUCache[K, V]) String() string {
+	var result strings.Builder
+	result.WriteString("LRUCache: [")
+	for node := c.order.Front(); node != nil; node = node.Next() {
+		result.WriteString(fmt.Sprintf("%v: %v", node.Data.key, node.Data.value))
+		if node.Next() != nil {
+			result.WriteString(", ")
+		}
+	}
+	result.WriteString("]")
+	return result.String()
+}
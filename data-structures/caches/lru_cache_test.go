@@ -0,0 +1,148 @@
+package caches
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLRUCache(t *testing.T) {
+	var evicted []string
+	cache := NewLRUCache(3, WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	if got := cache.Keys(); !equalKeys(got, []string{"c", "b", "a"}) {
+		t.Errorf("Expected [c b a], got %v", got)
+	}
+
+	// Get refreshes recency, so b becomes the victim
+	if val, found := cache.Get("a"); !found || val != 1 {
+		t.Errorf("Expected (1, true), got (%d, %v)", val, found)
+	}
+	cache.Put("d", 4)
+	if cache.Contains("b") {
+		t.Error("Expected b to be evicted")
+	}
+	if !equalKeys(evicted, []string{"b"}) {
+		t.Errorf("Expected eviction callback for b, got %v", evicted)
+	}
+
+	// Updating an existing key does not evict
+	cache.Put("c", 30)
+	if cache.Size() != 3 || len(evicted) != 1 {
+		t.Errorf("Expected update without eviction, size %d", cache.Size())
+	}
+	if got := cache.String(); got != "LRUCache: [c: 30, d: 4, a: 1]" {
+		t.Errorf("Unexpected string: %s", got)
+	}
+
+	// Peek does not refresh recency or count as a hit
+	cache.Peek("a")
+	cache.Get("missing")
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("Unexpected stats: %s", stats)
+	}
+	cache.Put("e", 5)
+	if cache.Contains("a") {
+		t.Error("Expected a to be evicted after Peek")
+	}
+
+	// Explicit deletes do not call the eviction callback
+	if !cache.Delete("c") || cache.Delete("c") {
+		t.Error("Expected exactly one successful delete")
+	}
+	cache.Clear()
+	if cache.Size() != 0 || cache.Cost() != 0 || len(evicted) != 2 {
+		t.Errorf("Expected empty cache and 2 evictions, got %d evictions", len(evicted))
+	}
+}
+
+func TestLRUCacheCost(t *testing.T) {
+	cache := NewLRUCache(10, WithCost(func(key string, value string) int {
+		return len(value)
+	}))
+
+	cache.Put("a", "aaaa")
+	cache.Put("b", "bbbb")
+	cache.Put("c", "cc")
+	if cache.Cost() != 10 || cache.Size() != 3 {
+		t.Errorf("Expected cost 10 over 3 entries, got %d over %d", cache.Cost(), cache.Size())
+	}
+
+	// Growing an entry evicts from the cold end until it fits
+	cache.Put("c", "cccccc")
+	if cache.Contains("a") || !cache.Contains("b") || cache.Cost() != 10 {
+		t.Errorf("Expected a to be evicted, cost %d, keys %v", cache.Cost(), cache.Keys())
+	}
+
+	// An entry larger than the capacity is dropped
+	cache.Put("b", "bbbbbbbbbbbb")
+	if cache.Contains("b") || cache.Cost() != 6 {
+		t.Errorf("Expected oversized b to be dropped, cost %d", cache.Cost())
+	}
+}
+
+func TestLRUCacheAgainstReference(t *testing.T) {
+	const capacity = 8
+	cache := NewLRUCache[int, int](capacity)
+	var recency []int // most recent first
+	touch := func(key int) {
+		for i, k := range recency {
+			if k == key {
+				recency = append(recency[:i], recency[i+1:]...)
+				break
+			}
+		}
+		recency = append([]int{key}, recency...)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(20)
+		if rng.Intn(2) == 0 {
+			cache.Put(key, i)
+			touch(key)
+			if len(recency) > capacity {
+				recency = recency[:capacity]
+			}
+		} else if _, found := cache.Get(key); found {
+			touch(key)
+		}
+
+		keys := cache.Keys()
+		if len(keys) != len(recency) {
+			t.Fatalf("Expected %d keys, got %d", len(recency), len(keys))
+		}
+		for j := range keys {
+			if keys[j] != recency[j] {
+				t.Fatalf("Expected order %v, got %v", recency, keys)
+			}
+		}
+	}
+}
+
+func BenchmarkLRUCache(b *testing.B) {
+	cache := NewLRUCache[int, int](1024)
+	for i := 0; i < b.N; i++ {
+		key := i % 2048
+		if _, found := cache.Get(key); !found {
+			cache.Put(key, i)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package hashring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLoadFactor lets a member take 25% more than its fair share
+const DefaultLoadFactor = 1.25
+
+// BoundedRing represents a consistent hash ring with bounded loads
+// (Mirrokni, Thorup and Zadimoghaddam). Every member may hold at most
+// loadFactor times its fair share of the assigned keys; a key whose owner
+// is full walks clockwise to the next member with room. This caps hot
+// spots while keeping most of the stability of a plain ring.
+type BoundedRing struct {
+	mu          sync.Mutex
+	ring        *Ring
+	loadFactor  float64
+	loads       map[string]int
+	assignments map[string]string
+}
+
+// NewBoundedRing creates an empty bounded-load ring. A loadFactor <= 1
+// uses DefaultLoadFactor.
+func NewBoundedRing(virtualNodes int, loadFactor float64) *BoundedRing {
+	if loadFactor <= 1 {
+		loadFactor = DefaultLoadFactor
+	}
+	return &BoundedRing{
+		ring:        NewRing(virtualNodes, nil),
+		loadFactor:  loadFactor,
+		loads:       make(map[string]int),
+		assignments: make(map[string]string),
+	}
+}
+
+// Add adds a member with weight 1
+func (b *BoundedRing) Add(member string) {
+	b.AddWeighted(member, 1)
+}
+
+// AddWeighted adds a member whose capacity is proportional to weight, or
+// changes its weight. Existing assignments are kept. A weight <= 0
+// removes the member like Remove and returns its orphaned keys.
+func (b *BoundedRing) AddWeighted(member string, weight int) []string {
+	if weight <= 0 {
+		return b.Remove(member)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ring.AddWeighted(member, weight)
+	return nil
+}
+
+// Remove removes a member and returns the keys that were assigned to it
+// in sorted order. The caller should Assign them again.
+func (b *BoundedRing) Remove(member string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.ring.Remove(member) {
+		return nil
+	}
+
+	var orphans []string
+	for key, owner := range b.assignments {
+		if owner == member {
+			orphans = append(orphans, key)
+			delete(b.assignments, key)
+		}
+	}
+	delete(b.loads, member)
+	sort.Strings(orphans)
+	return orphans
+}
+
+// capacity returns how many keys member may hold once one more is
+// assigned: loadFactor times its weighted share of the total
+func (b *BoundedRing) capacity(member string, totalWeight int) int {
+	share := float64(len(b.assignments)+1) * float64(b.ring.Weight(member)) / float64(totalWeight)
+	return int(math.Ceil(b.loadFactor * share))
+}
+
+// Assign places key on the first member clockwise from it that is below
+// its capacity and records the assignment. Assigning a key twice returns
+// its existing member. It reports false if the ring is empty.
+func (b *BoundedRing) Assign(key string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if member, found := b.assignments[key]; found {
+		return member, true
+	}
+
+	totalWeight := 0
+	for _, member := range b.ring.Members() {
+		totalWeight += b.ring.Weight(member)
+	}
+
+	// Every member appears in the walk, and the capacities sum to more
+	// than the total load, so some member always has room
+	for _, member := range b.ring.LocateN(key, b.ring.Size()) {
+		if b.loads[member] < b.capacity(member, totalWeight) {
+			b.assignments[key] = member
+			b.loads[member]++
+			return member, true
+		}
+	}
+	return "", false
+}
+
+// Locate returns the member key is assigned to, without assigning it
+func (b *BoundedRing) Locate(key string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	member, found := b.assignments[key]
+	return member, found
+}
+
+// Release removes the assignment of key, freeing capacity on its member
+func (b *BoundedRing) Release(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	member, found := b.assignments[key]
+	if !found {
+		return false
+	}
+	delete(b.assignments, key)
+	b.loads[member]--
+	return true
+}
+
+// Load returns the number of keys assigned to member
+func (b *BoundedRing) Load(member string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.loads[member]
+}
+
+// Assigned returns the total number of assigned keys
+func (b *BoundedRing) Assigned() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.assignments)
+}
+
+// Members returns the members in sorted order
+func (b *BoundedRing) Members() []string {
+	return b.ring.Members()
+}
+
+// String returns each member with its load in sorted order
+func (b *BoundedRing) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result strings.Builder
+	result.WriteString("BoundedRing: [")
+	for i, member := range b.ring.Members() {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%s: %d", member, b.loads[member]))
+	}
+	result.WriteString("]")
+	return result.String()
+}
@@ -0,0 +1,207 @@
+package hashring
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	hashtables "go-programming/data-structures/hash-tables"
+)
+
+// DefaultVirtualNodes is the number of ring points per unit of weight
+// used when none is given
+const DefaultVirtualNodes = 160
+
+// DefaultHasher places keys and members on the ring. Its key is fixed so
+// every process builds the same ring from the same members.
+var DefaultHasher hashtables.Hasher[string] = hashtables.NewSeededSipHasher[string](0)
+
+// point is one virtual node: a position on the ring owned by a member
+type point struct {
+	hash   uint64
+	member string
+}
+
+// Ring represents a consistent hash ring. Each member owns many virtual
+// nodes spread around a 64-bit circle and a key belongs to the first
+// virtual node clockwise from its hash, so adding or removing a member
+// only moves the keys on the arcs it gains or loses. Ring is safe for
+// concurrent use.
+type Ring struct {
+	mu           sync.RWMutex
+	points       []point // sorted by hash
+	weights      map[string]int
+	virtualNodes int
+	hasher       hashtables.Hasher[string]
+}
+
+// NewRing creates an empty ring with the given number of virtual nodes per
+// unit of weight. A nil hasher uses DefaultHasher.
+func NewRing(virtualNodes int, hasher hashtables.Hasher[string]) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultVirtualNodes
+	}
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	return &Ring{
+		weights:      make(map[string]int),
+		virtualNodes: virtualNodes,
+		hasher:       hasher,
+	}
+}
+
+// Add adds a member with weight 1
+func (r *Ring) Add(member string) {
+	r.AddWeighted(member, 1)
+}
+
+// AddWeighted adds a member, or changes its weight. A member with weight w
+// owns w times as many virtual nodes, and so about w times as many keys,
+// as a member with weight 1. A weight <= 0 removes the member.
+func (r *Ring) AddWeighted(member string, weight int) {
+	if weight <= 0 {
+		r.Remove(member)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removePoints(member)
+	r.weights[member] = weight
+	for i := 0; i < weight*r.virtualNodes; i++ {
+		r.points = append(r.points, point{hash: r.hasher.Hash(member + "#" + strconv.Itoa(i)), member: member})
+	}
+	// Ties are broken by member so the layout does not depend on insertion order
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].member < r.points[j].member
+	})
+}
+
+// Remove removes a member and its virtual nodes
+func (r *Ring) Remove(member string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.weights[member]; !found {
+		return false
+	}
+	r.removePoints(member)
+	delete(r.weights, member)
+	return true
+}
+
+// removePoints drops the virtual nodes of member, keeping the order
+func (r *Ring) removePoints(member string) {
+	if _, found := r.weights[member]; !found {
+		return
+	}
+	kept := r.points[:0]
+	for _, p := range r.points {
+		if p.member != member {
+			kept = append(kept, p)
+		}
+	}
+	r.points = kept
+}
+
+// search returns the index of the first virtual node clockwise from hash
+func (r *Ring) search(hash uint64) int {
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= hash
+	})
+	if i == len(r.points) {
+		return 0 // wrap around
+	}
+	return i
+}
+
+// Locate returns the member that owns key. It reports false if the ring is empty.
+func (r *Ring) Locate(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.points[r.search(r.hasher.Hash(key))].member, true
+}
+
+// LocateN returns up to n distinct members for key, in the order they
+// appear clockwise from it. The first is the owner; the rest are natural
+// choices for replicas.
+func (r *Ring) LocateN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n = min(n, len(r.weights))
+	if n <= 0 {
+		return nil
+	}
+	members := make([]string, 0, n)
+	start := r.search(r.hasher.Hash(key))
+	for i := 0; i < len(r.points) && len(members) < n; i++ {
+		member := r.points[(start+i)%len(r.points)].member
+		if !contains(members, member) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func contains(members []string, member string) bool {
+	for _, m := range members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
+
+// Members returns the members in sorted order
+func (r *Ring) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	members := make([]string, 0, len(r.weights))
+	for member := range r.weights {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// Weight returns the weight of member, or 0 if it is not on the ring
+func (r *Ring) Weight(member string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.weights[member]
+}
+
+// Size returns the number of members
+func (r *Ring) Size() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.weights)
+}
+
+// IsEmpty checks if the ring has no members
+func (r *Ring) IsEmpty() bool {
+	return r.Size() == 0
+}
+
+// String returns the members and their weights in sorted order
+func (r *Ring) String() string {
+	var result strings.Builder
+	result.WriteString("Ring: [")
+	for i, member := range r.Members() {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%s(%d)", member, r.Weight(member)))
+	}
+	result.WriteString("]")
+	return result.String()
+}
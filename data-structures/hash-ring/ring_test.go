@@ -0,0 +1,236 @@
+package hashring
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func keys(n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = fmt.Sprintf("key-%d", i)
+	}
+	return result
+}
+
+// assign maps every key to its owner on the ring
+func assign(r *Ring, keys []string) map[string]string {
+	owners := make(map[string]string, len(keys))
+	for _, key := range keys {
+		owners[key], _ = r.Locate(key)
+	}
+	return owners
+}
+
+func newRingWith(members int) *Ring {
+	r := NewRing(0, nil)
+	for i := 0; i < members; i++ {
+		r.Add(fmt.Sprintf("worker-%d", i))
+	}
+	return r
+}
+
+func TestRing(t *testing.T) {
+	r := NewRing(10, nil)
+	if _, ok := r.Locate("x"); ok || !r.IsEmpty() || r.LocateN("x", 3) != nil {
+		t.Error("Expected an empty ring to locate nothing")
+	}
+
+	r.Add("a")
+	r.AddWeighted("b", 2)
+	r.Add("c")
+	if r.Size() != 3 || r.String() != "Ring: [a(1), b(2), c(1)]" {
+		t.Errorf("Unexpected ring %s", r)
+	}
+
+	// LocateN starts at the owner and returns distinct members
+	owner, _ := r.Locate("some-key")
+	replicas := r.LocateN("some-key", 5)
+	if len(replicas) != 3 || replicas[0] != owner || replicas[1] == replicas[2] {
+		t.Errorf("Expected 3 distinct replicas led by %s, got %v", owner, replicas)
+	}
+
+	// Rings built in a different order place keys identically
+	other := NewRing(10, nil)
+	other.Add("c")
+	other.AddWeighted("b", 2)
+	other.Add("a")
+	for _, key := range keys(1000) {
+		a, _ := r.Locate(key)
+		b, _ := other.Locate(key)
+		if a != b {
+			t.Fatalf("Expected %s on the same member, got %s and %s", key, a, b)
+		}
+	}
+
+	if !r.Remove("b") || r.Remove("b") || r.Weight("b") != 0 {
+		t.Error("Expected to remove b exactly once")
+	}
+	for _, key := range keys(100) {
+		if owner, _ := r.Locate(key); owner == "b" {
+			t.Fatalf("Expected no keys on removed member, got %s", key)
+		}
+	}
+}
+
+func TestRingBalanceAndWeights(t *testing.T) {
+	r := newRingWith(10)
+	r.AddWeighted("big", 3)
+
+	counts := map[string]int{}
+	for _, owner := range assign(r, keys(130000)) {
+		counts[owner]++
+	}
+
+	// Total weight 13: each worker expects 10000 keys, big expects 30000
+	for member, count := range counts {
+		expected := 10000.0 * float64(r.Weight(member))
+		if math.Abs(float64(count)-expected)/expected > 0.2 {
+			t.Errorf("%s got %d keys; want within 20%% of %.0f", member, count, expected)
+		}
+	}
+}
+
+func TestRingKeyMovementSimulation(t *testing.T) {
+	const n = 100000
+	ks := keys(n)
+	r := newRingWith(10)
+	before := assign(r, ks)
+
+	// Joining: only about 1/11 of the keys move, all onto the new member
+	r.Add("worker-10")
+	after := assign(r, ks)
+	moved := 0
+	for _, key := range ks {
+		if before[key] != after[key] {
+			moved++
+			if after[key] != "worker-10" {
+				t.Fatalf("Key %s moved between existing members", key)
+			}
+		}
+	}
+	fraction := float64(moved) / n
+	t.Logf("join: %.2f%% of keys moved (ideal %.2f%%)", 100*fraction, 100.0/11)
+	if fraction > 1.5/11 {
+		t.Errorf("Expected about 1/11 of keys to move on join, got %.4f", fraction)
+	}
+
+	// Leaving: exactly the keys of the departing member move
+	r.Remove("worker-3")
+	final := assign(r, ks)
+	for _, key := range ks {
+		if (after[key] == "worker-3") != (after[key] != final[key]) {
+			t.Fatalf("Key %s moved although its member stayed", key)
+		}
+	}
+
+	// Compare with naive modulo sharding, where almost every key moves
+	modMoved := 0
+	for i := range ks {
+		if i%10 != i%11 {
+			modMoved++
+		}
+	}
+	t.Logf("modulo sharding would move %.2f%% of keys", 100*float64(modMoved)/n)
+}
+
+func TestBoundedRing(t *testing.T) {
+	const loadFactor = 1.25
+	b := NewBoundedRing(0, loadFactor)
+	if _, ok := b.Assign("x"); ok {
+		t.Error("Expected an empty ring to assign nothing")
+	}
+	for i := 0; i < 8; i++ {
+		b.Add(fmt.Sprintf("worker-%d", i))
+	}
+
+	ks := keys(10000)
+	for _, key := range ks {
+		if _, ok := b.Assign(key); !ok {
+			t.Fatalf("Expected to assign %s", key)
+		}
+	}
+	if b.Assigned() != len(ks) {
+		t.Errorf("Expected %d assignments, got %d", len(ks), b.Assigned())
+	}
+
+	// No member exceeds loadFactor times the average
+	limit := int(math.Ceil(loadFactor * float64(len(ks)) / 8))
+	for _, member := range b.Members() {
+		if load := b.Load(member); load > limit {
+			t.Errorf("%s holds %d keys; limit %d", member, load, limit)
+		}
+	}
+
+	// Assignments are sticky, and most keys still land on their ring owner
+	first, _ := b.Locate("key-1")
+	if again, _ := b.Assign("key-1"); again != first {
+		t.Errorf("Expected key-1 to stay on %s, got %s", first, again)
+	}
+	onOwner := 0
+	for _, key := range ks {
+		assigned, _ := b.Locate(key)
+		if owner, _ := b.ring.Locate(key); owner == assigned {
+			onOwner++
+		}
+	}
+	if onOwner < len(ks)*3/4 {
+		t.Errorf("Expected most keys on their ring owner, got %d of %d", onOwner, len(ks))
+	}
+
+	// Removing a member hands back its keys for reassignment
+	orphans := b.Remove("worker-0")
+	if len(orphans) == 0 || b.Load("worker-0") != 0 {
+		t.Errorf("Expected worker-0's keys back, got %d", len(orphans))
+	}
+	for _, key := range orphans {
+		if member, _ := b.Assign(key); member == "worker-0" {
+			t.Fatal("Expected orphans to move off the removed member")
+		}
+	}
+
+	if !b.Release("key-1") || b.Release("key-1") {
+		t.Error("Expected to release key-1 exactly once")
+	}
+}
+
+func TestBoundedRingZeroWeightRemoves(t *testing.T) {
+	b := NewBoundedRing(10, 0)
+	b.Add("a")
+	b.Add("b")
+	ks := keys(100)
+	for _, key := range ks {
+		b.Assign(key)
+	}
+	load := b.Load("a")
+
+	// A weight of 0 removes a like Remove does, keys and all
+	orphans := b.AddWeighted("a", 0)
+	if len(orphans) != load || load == 0 {
+		t.Errorf("Expected %d orphaned keys, got %d", load, len(orphans))
+	}
+	if len(b.Members()) != 1 || b.Load("a") != 0 || b.Assigned() != len(ks)-load {
+		t.Errorf("Expected only b with its keys left, got %s", b)
+	}
+	for _, key := range orphans {
+		if member, found := b.Locate(key); found {
+			t.Fatalf("Expected %s to be unassigned, got %s", key, member)
+		}
+		if member, _ := b.Assign(key); member != "b" {
+			t.Fatalf("Expected %s to move to b, got %s", key, member)
+		}
+	}
+	if b.AddWeighted("missing", -1) != nil {
+		t.Error("Expected no orphans for a member that was never added")
+	}
+}
+
+func BenchmarkRingLocate(b *testing.B) {
+	r := newRingWith(50)
+	ks := keys(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Locate(ks[i&1023])
+	}
+}
@@ -0,0 +1,277 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+)
+
+// DefaultShardCount is the number of shards used when none is given
+const DefaultShardCount = 16
+
+// concurrentShard is one independently locked part of a ConcurrentHashTable
+type concurrentShard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	table *HashTable[K, V]
+}
+
+// ConcurrentHashTable represents a hash table that is safe for concurrent
+// use. Keys are split across shards, each a HashTable guarded by its own
+// lock, so goroutines working on different shards do not contend.
+type ConcurrentHashTable[K comparable, V any] struct {
+	shards []*concurrentShard[K, V]
+	hasher Hasher[K]
+}
+
+// NewConcurrentHashTable creates a new concurrent hash table with the given
+// number of shards. The options configure every shard's HashTable.
+func NewConcurrentHashTable[K comparable, V any](shards int, opts ...Option) *ConcurrentHashTable[K, V] {
+	return NewConcurrentHashTableWithHasher[K, V](shards, nil, opts...)
+}
+
+// NewConcurrentHashTableWithHasher creates a new concurrent hash table that uses the given hasher
+func NewConcurrentHashTableWithHasher[K comparable, V any](shards int, hasher Hasher[K], opts ...Option) *ConcurrentHashTable[K, V] {
+	if shards <= 0 {
+		shards = DefaultShardCount
+	}
+	if hasher == nil {
+		hasher = newDefaultHasher[K](newConfig(opts))
+	}
+
+	ct := &ConcurrentHashTable[K, V]{
+		shards: make([]*concurrentShard[K, V], shards),
+		hasher: hasher,
+	}
+	for i := range ct.shards {
+		ct.shards[i] = &concurrentShard[K, V]{
+			table: NewHashTableWithHasher[K, V](0, hasher, opts...),
+		}
+	}
+	return ct
+}
+
+// shard returns the shard responsible for key. The hash is remixed so
+// that shard selection does not correlate with the shard's bucket index.
+func (ct *ConcurrentHashTable[K, V]) shard(key K) *concurrentShard[K, V] {
+	return ct.shards[mix64(ct.hasher.Hash(key))%uint64(len(ct.shards))]
+}
+
+// read runs fn under the shard's read lock. A shard that is rehashing
+// incrementally moves buckets on every access, so it needs the write lock.
+func (s *concurrentShard[K, V]) read(fn func(table *HashTable[K, V])) {
+	s.mu.RLock()
+	if !s.table.rehashing() {
+		fn(s.table)
+		s.mu.RUnlock()
+		return
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.table)
+}
+
+// write runs fn under the shard's write lock
+func (s *concurrentShard[K, V]) write(fn func(table *HashTable[K, V])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.table)
+}
+
+// Put inserts or updates a key-value pair
+func (ct *ConcurrentHashTable[K, V]) Put(key K, value V) {
+	ct.shard(key).write(func(table *HashTable[K, V]) {
+		table.Put(key, value)
+	})
+}
+
+// Get retrieves the value associated with the given key
+func (ct *ConcurrentHashTable[K, V]) Get(key K) (value V, found bool) {
+	ct.shard(key).read(func(table *HashTable[K, V]) {
+		value, found = table.Get(key)
+	})
+	return value, found
+}
+
+// Delete removes the key-value pair with the given key
+func (ct *ConcurrentHashTable[K, V]) Delete(key K) (deleted bool) {
+	ct.shard(key).write(func(table *HashTable[K, V]) {
+		deleted = table.Delete(key)
+	})
+	return deleted
+}
+
+// Contains checks if the table contains the given key
+func (ct *ConcurrentHashTable[K, V]) Contains(key K) bool {
+	_, found := ct.Get(key)
+	return found
+}
+
+// GetOrPut returns the existing value for key if present. Otherwise it
+// stores value and returns it. loaded reports whether the value was
+// already present.
+func (ct *ConcurrentHashTable[K, V]) GetOrPut(key K, value V) (actual V, loaded bool) {
+	ct.shard(key).write(func(table *HashTable[K, V]) {
+		if existing, found := table.Get(key); found {
+			actual, loaded = existing, true
+			return
+		}
+		table.Put(key, value)
+		actual = value
+	})
+	return actual, loaded
+}
+
+// Compute atomically replaces the value for key with the result of fn.
+// fn receives the current value and whether it exists; if it returns
+// keep == false the key is deleted. Compute returns the new value and
+// whether the key is now present. fn runs under the shard lock and must
+// not call back into the table.
+func (ct *ConcurrentHashTable[K, V]) Compute(key K, fn func(old V, found bool) (value V, keep bool)) (V, bool) {
+	var result V
+	var present bool
+	ct.shard(key).write(func(table *HashTable[K, V]) {
+		old, found := table.Get(key)
+		value, keep := fn(old, found)
+		if !keep {
+			table.Delete(key)
+			return
+		}
+		table.Put(key, value)
+		result, present = value, true
+	})
+	return result, present
+}
+
+// CompareAndSwap stores new for key if the current value equals old.
+// Like sync.Map, it panics if V is not a comparable type.
+func (ct *ConcurrentHashTable[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	ct.shard(key).write(func(table *HashTable[K, V]) {
+		current, found := table.Get(key)
+		if !found || any(current) != any(old) {
+			return
+		}
+		table.Put(key, new)
+		swapped = true
+	})
+	return swapped
+}
+
+// Size returns the number of key-value pairs. Shards are counted one at a
+// time, so the result may be stale under concurrent writes.
+func (ct *ConcurrentHashTable[K, V]) Size() int {
+	total := 0
+	for _, s := range ct.shards {
+		s.mu.RLock()
+		total += s.table.Size()
+		s.mu.RUnlock()
+	}
+	return total
+}
+
+// Count returns the number of key-value pairs.
+//
+// Deprecated: Use Size, which every Map in this package provides.
+func (ct *ConcurrentHashTable[K, V]) Count() int {
+	return ct.Size()
+}
+
+// IsEmpty checks if the table is empty
+func (ct *ConcurrentHashTable[K, V]) IsEmpty() bool {
+	return ct.Size() == 0
+}
+
+// ShardCount returns the number of shards
+func (ct *ConcurrentHashTable[K, V]) ShardCount() int {
+	return len(ct.shards)
+}
+
+// Snapshot returns a copy of every key-value pair taken while all shards
+// are locked, so it reflects a single point in time
+func (ct *ConcurrentHashTable[K, V]) Snapshot() []Entry[K, V] {
+	// Locks are always taken in shard order, so concurrent snapshots
+	// cannot deadlock; single-key operations only ever hold one lock
+	for _, s := range ct.shards {
+		s.mu.RLock()
+	}
+	defer func() {
+		for _, s := range ct.shards {
+			s.mu.RUnlock()
+		}
+	}()
+
+	total := 0
+	for _, s := range ct.shards {
+		total += s.table.Size()
+	}
+	entries := make([]Entry[K, V], 0, total)
+	for _, s := range ct.shards {
+		for key, value := range s.table.All() {
+			entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// All returns an iterator over a consistent snapshot of the table.
+// The snapshot is taken when iteration starts; later writes are not seen
+// and the loop body may safely modify the table.
+func (ct *ConcurrentHashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range ct.Snapshot() {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys from a consistent snapshot
+func (ct *ConcurrentHashTable[K, V]) Keys() []K {
+	snapshot := ct.Snapshot()
+	keys := make([]K, len(snapshot))
+	for i, entry := range snapshot {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Values returns all values from a consistent snapshot
+func (ct *ConcurrentHashTable[K, V]) Values() []V {
+	snapshot := ct.Snapshot()
+	values := make([]V, len(snapshot))
+	for i, entry := range snapshot {
+		values[i] = entry.Value
+	}
+	return values
+}
+
+// Clear removes all entries, one shard at a time
+func (ct *ConcurrentHashTable[K, V]) Clear() {
+	for _, s := range ct.shards {
+		s.write(func(table *HashTable[K, V]) {
+			table.Clear()
+		})
+	}
+}
+
+// String returns a string representation of a consistent snapshot
+func (ct *ConcurrentHashTable[K, V]) String() string {
+	snapshot := ct.Snapshot()
+	if len(snapshot) == 0 {
+		return "ConcurrentHashTable: {}"
+	}
+
+	var result strings.Builder
+	result.WriteString("ConcurrentHashTable: {")
+	for i, entry := range snapshot {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%v: %v", entry.Key, entry.Value))
+	}
+	result.WriteString("}")
+	return result.String()
+}
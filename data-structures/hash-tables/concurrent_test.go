@@ -0,0 +1,215 @@
+package hashtables
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentHashTable(t *testing.T) {
+	ct := NewConcurrentHashTable[string, int](4)
+	ct.Put("a", 1)
+	ct.Put("b", 2)
+	if val, found := ct.Get("a"); !found || val != 1 {
+		t.Errorf("Expected (1, true), got (%d, %v)", val, found)
+	}
+	if ct.Size() != 2 {
+		t.Errorf("Expected count 2, got %d", ct.Size())
+	}
+
+	// GetOrPut
+	if actual, loaded := ct.GetOrPut("a", 10); !loaded || actual != 1 {
+		t.Errorf("Expected existing (1, true), got (%d, %v)", actual, loaded)
+	}
+	if actual, loaded := ct.GetOrPut("c", 3); loaded || actual != 3 {
+		t.Errorf("Expected stored (3, false), got (%d, %v)", actual, loaded)
+	}
+
+	// Compute updates, inserts and deletes
+	if val, ok := ct.Compute("a", func(old int, found bool) (int, bool) { return old + 1, true }); !ok || val != 2 {
+		t.Errorf("Expected compute to give (2, true), got (%d, %v)", val, ok)
+	}
+	ct.Compute("d", func(old int, found bool) (int, bool) {
+		if found {
+			t.Error("Expected d to be absent")
+		}
+		return 4, true
+	})
+	if _, ok := ct.Compute("b", func(int, bool) (int, bool) { return 0, false }); ok || ct.Contains("b") {
+		t.Error("Expected compute to delete b")
+	}
+
+	// CompareAndSwap
+	if ct.CompareAndSwap("a", 1, 5) {
+		t.Error("Expected CAS with stale old value to fail")
+	}
+	if !ct.CompareAndSwap("a", 2, 5) {
+		t.Error("Expected CAS with current old value to succeed")
+	}
+	if ct.CompareAndSwap("missing", 0, 1) {
+		t.Error("Expected CAS on missing key to fail")
+	}
+
+	if !ct.Delete("d") || ct.Delete("d") {
+		t.Error("Expected exactly one successful delete of d")
+	}
+	keys := ct.Keys()
+	if len(keys) != 2 || len(ct.Values()) != 2 {
+		t.Errorf("Expected 2 keys, got %v", keys)
+	}
+
+	ct.Clear()
+	if !ct.IsEmpty() || ct.String() != "ConcurrentHashTable: {}" {
+		t.Errorf("Expected empty table after clear, got %s", ct)
+	}
+}
+
+func TestConcurrentHashTableSnapshotIsolation(t *testing.T) {
+	ct := NewConcurrentHashTable[int, int](8)
+	for i := 0; i < 100; i++ {
+		ct.Put(i, i)
+	}
+
+	// Mutating inside the loop must neither deadlock nor affect the iteration
+	seen := 0
+	for key := range ct.All() {
+		ct.Delete(key)
+		ct.Put(key+1000, key)
+		seen++
+	}
+	if seen != 100 {
+		t.Errorf("Expected to iterate the 100 snapshotted entries, got %d", seen)
+	}
+	if ct.Size() != 100 || ct.Contains(5) || !ct.Contains(1005) {
+		t.Error("Expected every key to have been moved")
+	}
+}
+
+func TestConcurrentHashTableStress(t *testing.T) {
+	const goroutines = 16
+	const iterations = 2000
+
+	// Incremental rehashing exercises the read path's lock upgrade
+	ct := NewConcurrentHashTable[int, int](4, WithIncrementalRehash(2))
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				key := i % 64
+				switch i % 5 {
+				case 0:
+					ct.Put(1000+g*iterations+i, i)
+				case 1:
+					ct.Get(1000 + g*iterations + i - 1)
+				case 2:
+					ct.Compute(key, func(old int, _ bool) (int, bool) { return old + 1, true })
+				case 3:
+					ct.GetOrPut(-key-1, g)
+				case 4:
+					for {
+						old, _ := ct.GetOrPut(-1000, 0)
+						if ct.CompareAndSwap(-1000, old, old+1) {
+							break
+						}
+					}
+				}
+			}
+		}(g)
+	}
+
+	// Snapshots race with the writers
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			for range ct.All() {
+			}
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	// Compute and CompareAndSwap must not lose increments
+	sum := 0
+	for key := 0; key < 64; key++ {
+		val, _ := ct.Get(key)
+		sum += val
+	}
+	if want := goroutines * iterations / 5; sum != want {
+		t.Errorf("Expected %d computed increments, got %d", want, sum)
+	}
+	if val, _ := ct.Get(-1000); val != goroutines*iterations/5 {
+		t.Errorf("Expected %d CAS increments, got %d", goroutines*iterations/5, val)
+	}
+	if ct.Size() != 64+64+1+goroutines*iterations/5 {
+		t.Errorf("Unexpected count %d", ct.Size())
+	}
+}
+
+func TestConcurrentHashTableGetOrPutSingleWinner(t *testing.T) {
+	ct := NewConcurrentHashTable[string, int](0)
+	var winners atomic.Int32
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			if _, loaded := ct.GetOrPut("leader", g); !loaded {
+				winners.Add(1)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if winners.Load() != 1 {
+		t.Errorf("Expected exactly one GetOrPut to store, got %d", winners.Load())
+	}
+	if ct.ShardCount() != DefaultShardCount {
+		t.Errorf("Expected %d shards by default, got %d", DefaultShardCount, ct.ShardCount())
+	}
+}
+
+// Benchmarks compare against sync.Map with 90% reads, 10% writes
+func BenchmarkConcurrentHashTable(b *testing.B) {
+	for _, shards := range []int{1, 16, 64} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			ct := NewConcurrentHashTable[int, int](shards)
+			for i := 0; i < 1024; i++ {
+				ct.Put(i, i)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%10 == 0 {
+						ct.Put(i&1023, i)
+					} else {
+						ct.Get(i & 1023)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkSyncMap(b *testing.B) {
+	var m sync.Map
+	for i := 0; i < 1024; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				m.Store(i&1023, i)
+			} else {
+				m.Load(i & 1023)
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+const (
+	// cuckooMaxKicks bounds the eviction chain of a single insert
+	cuckooMaxKicks = 32
+	// cuckooStashSize is the number of entries that may overflow the two tables
+	cuckooStashSize = 4
+	// cuckooSecondSeed derives the second table's hash from the first
+	cuckooSecondSeed = 0x9e3779b97f4a7c15
+)
+
+type cuckooSlot[K comparable, V any] struct {
+	key      K
+	value    V
+	occupied bool
+}
+
+// CuckooHashTable represents a cuckoo hash table: every key lives in one
+// of exactly two slots, one per table, so lookups probe at most two slots
+// plus a small stash. Inserting into an occupied slot kicks the resident
+// out to its other slot; entries that cannot be placed go to the stash,
+// and a full stash triggers a rehash into larger tables.
+type CuckooHashTable[K comparable, V any] struct {
+	tables [2][]cuckooSlot[K, V]
+	stash  []cuckooSlot[K, V]
+	count  int
+	hasher Hasher[K]
+
+	minSize       int
+	maxLoadFactor float64
+	minLoadFactor float64
+}
+
+// NewCuckooHashTable creates a new cuckoo hash table with the specified
+// total number of slots, rounded up so each table is a power of two.
+// Load factor options are honoured; incremental rehashing is not supported.
+func NewCuckooHashTable[K comparable, V any](size int, opts ...Option) *CuckooHashTable[K, V] {
+	return NewCuckooHashTableWithHasher[K, V](size, nil, opts...)
+}
+
+// NewCuckooHashTableWithHasher creates a new cuckoo hash table that uses the given hasher
+func NewCuckooHashTableWithHasher[K comparable, V any](size int, hasher Hasher[K], opts ...Option) *CuckooHashTable[K, V] {
+	half := nextPowerOfTwo((size + 1) / 2)
+	cfg := newOpenAddressingConfig(opts)
+	if hasher == nil {
+		hasher = newDefaultHasher[K](cfg)
+	}
+	ct := &CuckooHashTable[K, V]{
+		hasher:        hasher,
+		minSize:       half,
+		maxLoadFactor: cfg.maxLoadFactor,
+		minLoadFactor: cfg.minLoadFactor,
+	}
+	ct.allocate(half)
+	return ct
+}
+
+func (ct *CuckooHashTable[K, V]) allocate(half int) {
+	ct.tables[0] = make([]cuckooSlot[K, V], half)
+	ct.tables[1] = make([]cuckooSlot[K, V], half)
+	ct.stash = ct.stash[:0]
+}
+
+// slotIndex returns the slot of key in table t
+func (ct *CuckooHashTable[K, V]) slotIndex(t int, h uint64) int {
+	if t == 1 {
+		h ^= cuckooSecondSeed
+	}
+	return int(mix64(h) & uint64(len(ct.tables[t])-1))
+}
+
+// locate returns the table (0, 1, or 2 for the stash) and index holding key
+func (ct *CuckooHashTable[K, V]) locate(key K) (int, int, bool) {
+	h := ct.hasher.Hash(key)
+	for t := 0; t < 2; t++ {
+		i := ct.slotIndex(t, h)
+		if s := ct.tables[t][i]; s.occupied && s.key == key {
+			return t, i, true
+		}
+	}
+	for i, s := range ct.stash {
+		if s.key == key {
+			return 2, i, true
+		}
+	}
+	return 0, 0, false
+}
+
+// slot returns a pointer to the slot found by locate
+func (ct *CuckooHashTable[K, V]) slot(t, i int) *cuckooSlot[K, V] {
+	if t == 2 {
+		return &ct.stash[i]
+	}
+	return &ct.tables[t][i]
+}
+
+// Put inserts or updates a key-value pair in the hash table
+func (ct *CuckooHashTable[K, V]) Put(key K, value V) {
+	if t, i, found := ct.locate(key); found {
+		ct.slot(t, i).value = value
+		return
+	}
+
+	if float64(ct.count+1) > ct.maxLoadFactor*float64(ct.capacity()) {
+		ct.resize(len(ct.tables[0]) * 2)
+	}
+	ct.insert(cuckooSlot[K, V]{key: key, value: value, occupied: true})
+	ct.count++
+}
+
+// insert places an entry, kicking residents to their other slot as needed
+func (ct *CuckooHashTable[K, V]) insert(entry cuckooSlot[K, V]) {
+	for {
+		t := 0
+		for kick := 0; kick < cuckooMaxKicks; kick++ {
+			i := ct.slotIndex(t, ct.hasher.Hash(entry.key))
+			s := &ct.tables[t][i]
+			if !s.occupied {
+				*s = entry
+				return
+			}
+			*s, entry = entry, *s
+			t = 1 - t
+		}
+
+		// The eviction chain is too long: park the homeless entry in the
+		// stash, or rebuild with larger tables once the stash is full. If
+		// the tables are already sparse the keys must share a hash code,
+		// so growing would not help and the stash takes the overflow.
+		if len(ct.stash) < cuckooStashSize || ct.count*8 < ct.capacity() {
+			ct.stash = append(ct.stash, entry)
+			return
+		}
+		ct.resize(len(ct.tables[0]) * 2)
+	}
+}
+
+// resize rehashes every entry into tables of the given size
+func (ct *CuckooHashTable[K, V]) resize(half int) {
+	old := ct.tables
+	oldStash := append([]cuckooSlot[K, V](nil), ct.stash...)
+	ct.allocate(half)
+
+	for _, table := range old {
+		for _, s := range table {
+			if s.occupied {
+				ct.insert(s)
+			}
+		}
+	}
+	for _, s := range oldStash {
+		ct.insert(s)
+	}
+}
+
+// Get retrieves the value associated with the given key
+func (ct *CuckooHashTable[K, V]) Get(key K) (V, bool) {
+	if t, i, found := ct.locate(key); found {
+		return ct.slot(t, i).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the key-value pair with the given key
+func (ct *CuckooHashTable[K, V]) Delete(key K) bool {
+	t, i, found := ct.locate(key)
+	if !found {
+		return false
+	}
+
+	if t == 2 {
+		ct.stash = append(ct.stash[:i], ct.stash[i+1:]...)
+	} else {
+		ct.tables[t][i] = cuckooSlot[K, V]{}
+		// A freed slot may let a stashed entry move back into the tables
+		ct.drainStash()
+	}
+	ct.count--
+
+	half := len(ct.tables[0])
+	if ct.minLoadFactor > 0 && half/2 >= ct.minSize && ct.LoadFactor() < ct.minLoadFactor {
+		ct.resize(half / 2)
+	}
+	return true
+}
+
+// drainStash moves stashed entries into any free slot they hash to
+func (ct *CuckooHashTable[K, V]) drainStash() {
+	kept := ct.stash[:0]
+	for _, entry := range ct.stash {
+		placed := false
+		h := ct.hasher.Hash(entry.key)
+		for t := 0; t < 2 && !placed; t++ {
+			if s := &ct.tables[t][ct.slotIndex(t, h)]; !s.occupied {
+				*s = entry
+				placed = true
+			}
+		}
+		if !placed {
+			kept = append(kept, entry)
+		}
+	}
+	ct.stash = kept
+}
+
+// capacity returns the number of slots across both tables
+func (ct *CuckooHashTable[K, V]) capacity() int {
+	return 2 * len(ct.tables[0])
+}
+
+// Contains checks if the hash table contains the given key
+func (ct *CuckooHashTable[K, V]) Contains(key K) bool {
+	_, _, found := ct.locate(key)
+	return found
+}
+
+// Size returns the number of key-value pairs in the hash table
+func (ct *CuckooHashTable[K, V]) Size() int {
+	return ct.count
+}
+
+// Count returns the number of key-value pairs.
+//
+// Deprecated: Use Size, which every Map in this package provides.
+func (ct *CuckooHashTable[K, V]) Count() int {
+	return ct.Size()
+}
+
+// IsEmpty checks if the hash table is empty
+func (ct *CuckooHashTable[K, V]) IsEmpty() bool {
+	return ct.count == 0
+}
+
+// StashLen returns the number of entries currently held in the stash
+func (ct *CuckooHashTable[K, V]) StashLen() int {
+	return len(ct.stash)
+}
+
+// All returns an iterator over all key-value pairs: first table, second table, then stash
+func (ct *CuckooHashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, table := range ct.tables {
+			for _, s := range table {
+				if s.occupied && !yield(s.key, s.value) {
+					return
+				}
+			}
+		}
+		for _, s := range ct.stash {
+			if !yield(s.key, s.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys in the hash table
+func (ct *CuckooHashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, ct.count)
+	for key := range ct.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values in the hash table
+func (ct *CuckooHashTable[K, V]) Values() []V {
+	values := make([]V, 0, ct.count)
+	for _, value := range ct.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all entries from the hash table
+func (ct *CuckooHashTable[K, V]) Clear() {
+	ct.allocate(ct.minSize)
+	ct.count = 0
+}
+
+// LoadFactor returns the load factor of the hash table over both tables
+func (ct *CuckooHashTable[K, V]) LoadFactor() float64 {
+	return float64(ct.count) / float64(ct.capacity())
+}
+
+// String returns a string representation of the hash table
+func (ct *CuckooHashTable[K, V]) String() string {
+	if ct.IsEmpty() {
+		return "CuckooHashTable: {}"
+	}
+
+	var result strings.Builder
+	result.WriteString("CuckooHashTable: {\n")
+	for t, table := range ct.tables {
+		for i, s := range table {
+			if s.occupied {
+				result.WriteString(fmt.Sprintf("  [T%d %d]: (%v: %v)\n", t+1, i, s.key, s.value))
+			}
+		}
+	}
+	for i, s := range ct.stash {
+		result.WriteString(fmt.Sprintf("  [stash %d]: (%v: %v)\n", i, s.key, s.value))
+	}
+	result.WriteString("}")
+	return result.String()
+}
@@ -2,112 +2,170 @@ package hashtables
 
 import (
 	"fmt"
+	"iter"
+	"sort"
 	"strings"
 )
 
 // Entry represents a key-value pair in the hash table
-type Entry struct {
-	Key   string
-	Value int
-	Next  *Entry // For chaining collision resolution
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+	Next  *Entry[K, V] // For chaining collision resolution
 }
 
-// HashTable represents a hash table with separate chaining
-type HashTable struct {
-	buckets []*Entry
+// HashTable represents a hash table with separate chaining.
+// The table grows and shrinks according to its load factor policy;
+// see Option for the available settings.
+type HashTable[K comparable, V any] struct {
+	buckets []*Entry[K, V]
 	size    int
 	count   int
+	hasher  Hasher[K]
+
+	// Incremental rehash state: while rehashIndex >= 0, entries are
+	// migrated from buckets into next a few buckets at a time.
+	next        []*Entry[K, V]
+	rehashIndex int
+
+	minSize       int
+	maxLoadFactor float64
+	minLoadFactor float64
+	rehashStep    int
 }
 
-// NewHashTable creates a new hash table with the specified size
-func NewHashTable(size int) *HashTable {
+// NewHashTable creates a new hash table with the specified size.
+// Keys are hashed with SipHash-2-4 under a random per-table seed;
+// use WithSeed for reproducible bucket layouts.
+func NewHashTable[K comparable, V any](size int, opts ...Option) *HashTable[K, V] {
+	return NewHashTableWithHasher[K, V](size, nil, opts...)
+}
+
+// NewHashTableWithHasher creates a new hash table that uses the given hasher
+func NewHashTableWithHasher[K comparable, V any](size int, hasher Hasher[K], opts ...Option) *HashTable[K, V] {
 	if size <= 0 {
 		size = 16 // Default size
 	}
-	return &HashTable{
-		buckets: make([]*Entry, size),
-		size:    size,
-		count:   0,
+	cfg := newConfig(opts)
+	if hasher == nil {
+		hasher = newDefaultHasher[K](cfg)
+	}
+	return &HashTable[K, V]{
+		buckets:       make([]*Entry[K, V], size),
+		size:          size,
+		count:         0,
+		hasher:        hasher,
+		rehashIndex:   -1,
+		minSize:       size,
+		maxLoadFactor: cfg.maxLoadFactor,
+		minLoadFactor: cfg.minLoadFactor,
+		rehashStep:    cfg.rehashStep,
 	}
 }
 
-// hash computes the hash value for a given key
-func (ht *HashTable) hash(key string) int {
-	hash := 0
-	for _, char := range key {
-		hash = (hash*31 + int(char)) % ht.size
+// index computes the bucket index for a given key in a table of n buckets
+func (ht *HashTable[K, V]) index(key K, n int) int {
+	return int(ht.hasher.Hash(key) % uint64(n))
+}
+
+// find returns the entry holding key, looking in both tables during a rehash
+func (ht *HashTable[K, V]) find(key K) *Entry[K, V] {
+	for current := ht.buckets[ht.index(key, len(ht.buckets))]; current != nil; current = current.Next {
+		if current.Key == key {
+			return current
+		}
 	}
-	if hash < 0 {
-		hash += ht.size
+	if ht.rehashing() {
+		for current := ht.next[ht.index(key, len(ht.next))]; current != nil; current = current.Next {
+			if current.Key == key {
+				return current
+			}
+		}
 	}
-	return hash
+	return nil
 }
 
 // Put inserts or updates a key-value pair in the hash table
-func (ht *HashTable) Put(key string, value int) {
-	index := ht.hash(key)
+func (ht *HashTable[K, V]) Put(key K, value V) {
+	ht.rehashSteps()
+
+	if entry := ht.find(key); entry != nil {
+		// Update existing key
+		entry.Value = value
+		return
+	}
+
+	// New keys always go into the table being migrated to
+	table := ht.buckets
+	if ht.rehashing() {
+		table = ht.next
+	}
+	appendEntry(table, ht.index(key, len(table)), &Entry[K, V]{Key: key, Value: value})
+	ht.count++
+	ht.maybeGrow()
+}
 
-	if ht.buckets[index] == nil {
-		ht.buckets[index] = &Entry{Key: key, Value: value}
-		ht.count++
+// appendEntry adds an entry at the end of the chain in the given bucket
+func appendEntry[K comparable, V any](table []*Entry[K, V], index int, entry *Entry[K, V]) {
+	if table[index] == nil {
+		table[index] = entry
 		return
 	}
 
 	// Handle collision using chaining
-	current := ht.buckets[index]
-	for current != nil {
-		if current.Key == key {
-			// Update existing key
-			current.Value = value
-			return
-		}
-		if current.Next == nil {
-			break
-		}
+	current := table[index]
+	for current.Next != nil {
 		current = current.Next
 	}
-
-	// Add new entry at the end of the chain
-	current.Next = &Entry{Key: key, Value: value}
-	ht.count++
+	current.Next = entry
 }
 
 // Get retrieves the value associated with the given key
-func (ht *HashTable) Get(key string) (int, bool) {
-	index := ht.hash(key)
-	current := ht.buckets[index]
+func (ht *HashTable[K, V]) Get(key K) (V, bool) {
+	ht.rehashSteps()
 
-	for current != nil {
-		if current.Key == key {
-			return current.Value, true
-		}
-		current = current.Next
+	if entry := ht.find(key); entry != nil {
+		return entry.Value, true
 	}
 
-	return 0, false
+	var zero V
+	return zero, false
 }
 
 // Delete removes the key-value pair with the given key
-func (ht *HashTable) Delete(key string) bool {
-	index := ht.hash(key)
+func (ht *HashTable[K, V]) Delete(key K) bool {
+	ht.rehashSteps()
+
+	removed := removeEntry(ht.buckets, ht.index(key, len(ht.buckets)), key)
+	if !removed && ht.rehashing() {
+		removed = removeEntry(ht.next, ht.index(key, len(ht.next)), key)
+	}
+	if !removed {
+		return false
+	}
+
+	ht.count--
+	ht.maybeShrink()
+	return true
+}
 
-	if ht.buckets[index] == nil {
+// removeEntry unlinks the entry with the given key from a bucket chain
+func removeEntry[K comparable, V any](table []*Entry[K, V], index int, key K) bool {
+	if table[index] == nil {
 		return false
 	}
 
 	// If the first entry matches
-	if ht.buckets[index].Key == key {
-		ht.buckets[index] = ht.buckets[index].Next
-		ht.count--
+	if table[index].Key == key {
+		table[index] = table[index].Next
 		return true
 	}
 
 	// Search in the chain
-	current := ht.buckets[index]
+	current := table[index]
 	for current.Next != nil {
 		if current.Next.Key == key {
 			current.Next = current.Next.Next
-			ht.count--
 			return true
 		}
 		current = current.Next
@@ -117,77 +175,99 @@ func (ht *HashTable) Delete(key string) bool {
 }
 
 // Contains checks if the hash table contains the given key
-func (ht *HashTable) Contains(key string) bool {
+func (ht *HashTable[K, V]) Contains(key K) bool {
 	_, found := ht.Get(key)
 	return found
 }
 
 // Size returns the number of key-value pairs in the hash table
-func (ht *HashTable) Count() int {
+func (ht *HashTable[K, V]) Size() int {
 	return ht.count
 }
 
+// Count returns the number of key-value pairs.
+//
+// Deprecated: Use Size, which every Map in this package provides.
+func (ht *HashTable[K, V]) Count() int {
+	return ht.Size()
+}
+
 // IsEmpty checks if the hash table is empty
-func (ht *HashTable) IsEmpty() bool {
+func (ht *HashTable[K, V]) IsEmpty() bool {
 	return ht.count == 0
 }
 
-// Keys returns all keys in the hash table
-func (ht *HashTable) Keys() []string {
-	keys := make([]string, 0, ht.count)
-
-	for _, entry := range ht.buckets {
-		current := entry
-		for current != nil {
-			keys = append(keys, current.Key)
-			current = current.Next
+// All returns an iterator over all key-value pairs in bucket order
+func (ht *HashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, table := range [][]*Entry[K, V]{ht.buckets, ht.next} {
+			for _, entry := range table {
+				for current := entry; current != nil; current = current.Next {
+					if !yield(current.Key, current.Value) {
+						return
+					}
+				}
+			}
 		}
 	}
+}
 
+// Keys returns all keys in the hash table
+func (ht *HashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, ht.count)
+	for key := range ht.All() {
+		keys = append(keys, key)
+	}
 	return keys
 }
 
 // Values returns all values in the hash table
-func (ht *HashTable) Values() []int {
-	values := make([]int, 0, ht.count)
-
-	for _, entry := range ht.buckets {
-		current := entry
-		for current != nil {
-			values = append(values, current.Value)
-			current = current.Next
-		}
+func (ht *HashTable[K, V]) Values() []V {
+	values := make([]V, 0, ht.count)
+	for _, value := range ht.All() {
+		values = append(values, value)
 	}
-
 	return values
 }
 
 // Clear removes all entries from the hash table
-func (ht *HashTable) Clear() {
-	ht.buckets = make([]*Entry, ht.size)
+func (ht *HashTable[K, V]) Clear() {
+	ht.size = ht.minSize
+	ht.buckets = make([]*Entry[K, V], ht.size)
+	ht.next = nil
+	ht.rehashIndex = -1
 	ht.count = 0
 }
 
-// LoadFactor returns the load factor of the hash table
-func (ht *HashTable) LoadFactor() float64 {
-	return float64(ht.count) / float64(ht.size)
+// LoadFactor returns the load factor of the hash table.
+// During an incremental rehash it is measured against the new table.
+func (ht *HashTable[K, V]) LoadFactor() float64 {
+	return float64(ht.count) / float64(ht.BucketCount())
 }
 
 // String returns a string representation of the hash table
-func (ht *HashTable) String() string {
+func (ht *HashTable[K, V]) String() string {
 	if ht.IsEmpty() {
 		return "HashTable: {}"
 	}
 
 	var result strings.Builder
 	result.WriteString("HashTable: {\n")
+	writeBuckets(&result, ht.buckets, "")
+	if ht.rehashing() {
+		writeBuckets(&result, ht.next, "new ")
+	}
+	result.WriteString("}")
+	return result.String()
+}
 
-	for i, entry := range ht.buckets {
+func writeBuckets[K comparable, V any](result *strings.Builder, table []*Entry[K, V], label string) {
+	for i, entry := range table {
 		if entry != nil {
-			result.WriteString(fmt.Sprintf("  [%d]: ", i))
+			result.WriteString(fmt.Sprintf("  [%s%d]: ", label, i))
 			current := entry
 			for current != nil {
-				result.WriteString(fmt.Sprintf("(%s: %d)", current.Key, current.Value))
+				result.WriteString(fmt.Sprintf("(%v: %v)", current.Key, current.Value))
 				if current.Next != nil {
 					result.WriteString(" -> ")
 				}
@@ -196,36 +276,33 @@ func (ht *HashTable) String() string {
 			result.WriteString("\n")
 		}
 	}
-
-	result.WriteString("}")
-	return result.String()
 }
 
 // SimpleHashMap represents a simple hash map using Go's built-in map
-type SimpleHashMap struct {
-	data map[string]int
+type SimpleHashMap[K comparable, V any] struct {
+	data map[K]V
 }
 
 // NewSimpleHashMap creates a new simple hash map
-func NewSimpleHashMap() *SimpleHashMap {
-	return &SimpleHashMap{
-		data: make(map[string]int),
+func NewSimpleHashMap[K comparable, V any]() *SimpleHashMap[K, V] {
+	return &SimpleHashMap[K, V]{
+		data: make(map[K]V),
 	}
 }
 
 // Put inserts or updates a key-value pair
-func (shm *SimpleHashMap) Put(key string, value int) {
+func (shm *SimpleHashMap[K, V]) Put(key K, value V) {
 	shm.data[key] = value
 }
 
 // Get retrieves the value associated with the given key
-func (shm *SimpleHashMap) Get(key string) (int, bool) {
+func (shm *SimpleHashMap[K, V]) Get(key K) (V, bool) {
 	value, found := shm.data[key]
 	return value, found
 }
 
 // Delete removes the key-value pair with the given key
-func (shm *SimpleHashMap) Delete(key string) bool {
+func (shm *SimpleHashMap[K, V]) Delete(key K) bool {
 	if _, found := shm.data[key]; found {
 		delete(shm.data, key)
 		return true
@@ -234,21 +311,62 @@ func (shm *SimpleHashMap) Delete(key string) bool {
 }
 
 // Contains checks if the map contains the given key
-func (shm *SimpleHashMap) Contains(key string) bool {
+func (shm *SimpleHashMap[K, V]) Contains(key K) bool {
 	_, found := shm.data[key]
 	return found
 }
 
 // Size returns the number of key-value pairs
-func (shm *SimpleHashMap) Size() int {
+func (shm *SimpleHashMap[K, V]) Size() int {
 	return len(shm.data)
 }
 
+// IsEmpty checks if the map is empty
+func (shm *SimpleHashMap[K, V]) IsEmpty() bool {
+	return len(shm.data) == 0
+}
+
+// All returns an iterator over all key-value pairs in unspecified order
+func (shm *SimpleHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range shm.data {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
 // Keys returns all keys in the map
-func (shm *SimpleHashMap) Keys() []string {
-	keys := make([]string, 0, len(shm.data))
+func (shm *SimpleHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(shm.data))
 	for key := range shm.data {
 		keys = append(keys, key)
 	}
 	return keys
 }
+
+// Values returns all values in the map
+func (shm *SimpleHashMap[K, V]) Values() []V {
+	values := make([]V, 0, len(shm.data))
+	for _, value := range shm.data {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all key-value pairs
+func (shm *SimpleHashMap[K, V]) Clear() {
+	clear(shm.data)
+}
+
+// String returns a string representation of the map. Entries are sorted
+// by their formatted key, so the output is deterministic.
+func (shm *SimpleHashMap[K, V]) String() string {
+	entries := make([]string, 0, len(shm.data))
+	for key, value := range shm.data {
+		entries = append(entries, fmt.Sprintf("%v: %v", key, value))
+	}
+	sort.Strings(entries)
+	return "SimpleHashMap: {" + strings.Join(entries, ", ") + "}"
+}
@@ -0,0 +1,181 @@
+package hashtables
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestHashTable(t *testing.T) {
+	ht := NewHashTable[string, int](8)
+	if !ht.IsEmpty() {
+		t.Error("Expected hash table to be empty")
+	}
+
+	// Test put and get
+	ht.Put("one", 1)
+	ht.Put("two", 2)
+	ht.Put("three", 3)
+	if ht.Size() != 3 || ht.Count() != ht.Size() {
+		t.Errorf("Expected size 3, got %d", ht.Size())
+	}
+	if val, found := ht.Get("two"); !found || val != 2 {
+		t.Errorf("Expected (2, true), got (%d, %v)", val, found)
+	}
+
+	// Test update
+	ht.Put("two", 22)
+	if val, _ := ht.Get("two"); val != 22 {
+		t.Errorf("Expected 22 after update, got %d", val)
+	}
+	if ht.Size() != 3 {
+		t.Errorf("Expected size 3 after update, got %d", ht.Size())
+	}
+
+	// Test keys and values
+	keys := ht.Keys()
+	sort.Strings(keys)
+	expected := []string{"one", "three", "two"}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("Expected key %s at index %d, got %s", key, i, keys[i])
+		}
+	}
+	sum := 0
+	for _, val := range ht.Values() {
+		sum += val
+	}
+	if sum != 26 {
+		t.Errorf("Expected values to sum to 26, got %d", sum)
+	}
+
+	// Test delete
+	if !ht.Delete("one") {
+		t.Error("Expected to delete key one")
+	}
+	if ht.Delete("missing") {
+		t.Error("Expected delete of missing key to fail")
+	}
+	if ht.Contains("one") {
+		t.Error("Expected key one to be gone")
+	}
+
+	// Test clear
+	ht.Clear()
+	if !ht.IsEmpty() {
+		t.Error("Expected hash table to be empty after clear")
+	}
+	if ht.String() != "HashTable: {}" {
+		t.Errorf("Unexpected string for empty table: %s", ht.String())
+	}
+}
+
+func TestHashTableCollisions(t *testing.T) {
+	// A single bucket without growth forces every entry into the same chain
+	ht := NewHashTable[string, int](1, WithMaxLoadFactor(0))
+	for i, key := range []string{"a", "b", "c", "d"} {
+		ht.Put(key, i)
+	}
+	if ht.LoadFactor() != 4 {
+		t.Errorf("Expected load factor 4, got %f", ht.LoadFactor())
+	}
+	if !ht.Delete("c") || !ht.Delete("a") {
+		t.Error("Expected to delete chained keys")
+	}
+	if val, found := ht.Get("d"); !found || val != 3 {
+		t.Errorf("Expected (3, true), got (%d, %v)", val, found)
+	}
+	if ht.String() != "HashTable: {\n  [0]: (b: 1) -> (d: 3)\n}" {
+		t.Errorf("Unexpected string: %q", ht.String())
+	}
+}
+
+func TestHashTableGenericKeys(t *testing.T) {
+	type point struct{ X, Y int }
+
+	ht := NewHashTable[point, string](4)
+	ht.Put(point{1, 2}, "a")
+	ht.Put(point{2, 1}, "b")
+	if val, found := ht.Get(point{1, 2}); !found || val != "a" {
+		t.Errorf("Expected (a, true), got (%s, %v)", val, found)
+	}
+
+	// Custom hasher
+	byX := NewHashTableWithHasher[point, string](4, HashFunc[point](func(p point) uint64 { return uint64(p.X) }))
+	byX.Put(point{1, 2}, "a")
+	byX.Put(point{1, 3}, "b")
+	if byX.Size() != 2 {
+		t.Errorf("Expected count 2, got %d", byX.Size())
+	}
+	if val, _ := byX.Get(point{1, 3}); val != "b" {
+		t.Errorf("Expected b, got %s", val)
+	}
+}
+
+func TestHashTableAll(t *testing.T) {
+	ht := NewHashTable[int, int](4)
+	for i := 0; i < 10; i++ {
+		ht.Put(i, i*i)
+	}
+
+	seen := 0
+	for key, value := range ht.All() {
+		if value != key*key {
+			t.Errorf("Expected %d for key %d, got %d", key*key, key, value)
+		}
+		seen++
+	}
+	if seen != 10 {
+		t.Errorf("Expected 10 entries, got %d", seen)
+	}
+
+	// Early termination
+	seen = 0
+	for range ht.All() {
+		seen++
+		if seen == 3 {
+			break
+		}
+	}
+	if seen != 3 {
+		t.Errorf("Expected iteration to stop at 3, got %d", seen)
+	}
+}
+
+func TestSimpleHashMap(t *testing.T) {
+	shm := NewSimpleHashMap[string, []int]()
+	shm.Put("evens", []int{2, 4})
+	shm.Put("odds", []int{1, 3})
+	if shm.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", shm.Size())
+	}
+	if val, found := shm.Get("evens"); !found || len(val) != 2 {
+		t.Errorf("Expected evens to be found, got %v", val)
+	}
+	if !shm.Delete("odds") || shm.Contains("odds") {
+		t.Error("Expected odds to be deleted")
+	}
+	count := 0
+	for range shm.All() {
+		count++
+	}
+	if count != 1 || len(shm.Keys()) != 1 || len(shm.Values()) != 1 {
+		t.Errorf("Expected one entry, got %d", count)
+	}
+
+	// String is sorted, so it does not depend on map iteration order
+	shm.Put("all", []int{1, 2})
+	if got := shm.String(); got != "SimpleHashMap: {all: [1 2], evens: [2 4]}" {
+		t.Errorf("Unexpected string %s", got)
+	}
+	shm.Clear()
+	if !shm.IsEmpty() {
+		t.Error("Expected map to be empty after clear")
+	}
+}
+
+func BenchmarkHashTablePut(b *testing.B) {
+	ht := NewHashTable[int, int](1024)
+	for i := 0; i < b.N; i++ {
+		ht.Put(i%4096, i)
+	}
+}
@@ -0,0 +1,212 @@
+package hashtables
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// Hasher computes hash codes for keys
+type Hasher[K comparable] interface {
+	Hash(key K) uint64
+}
+
+// HashFunc computes a hash code for a key
+type HashFunc[K comparable] func(key K) uint64
+
+// Hash calls f(key), so any HashFunc can be used as a Hasher
+func (f HashFunc[K]) Hash(key K) uint64 {
+	return f(key)
+}
+
+// PolynomialHash returns the classic "hash*31 + c" rolling hash over the
+// key's bytes. It is unseeded, so collisions are easy to craft; it is kept
+// as a baseline for comparing hash quality.
+func PolynomialHash[K comparable]() HashFunc[K] {
+	return func(key K) uint64 {
+		var buf [32]byte
+		var hash uint64
+		for _, b := range appendKey(buf[:0], key) {
+			hash = hash*31 + uint64(b)
+		}
+		return hash
+	}
+}
+
+// FNV-1a 64-bit parameters
+const (
+	fnvOffsetBasis = 14695981039346656037
+	fnvPrime       = 1099511628211
+)
+
+// FNV1aHasher hashes keys with 64-bit FNV-1a. The seed is folded into the
+// offset basis; FNV-1a is fast but not collision resistant, so prefer
+// SipHasher for keys that may come from untrusted input.
+type FNV1aHasher[K comparable] struct {
+	seed uint64
+}
+
+// NewFNV1aHasher creates an FNV-1a hasher with the given seed
+func NewFNV1aHasher[K comparable](seed uint64) FNV1aHasher[K] {
+	return FNV1aHasher[K]{seed: seed}
+}
+
+// Hash returns the FNV-1a hash of the key
+func (h FNV1aHasher[K]) Hash(key K) uint64 {
+	var buf [32]byte
+	return fnv1a(h.seed, appendKey(buf[:0], key))
+}
+
+func fnv1a(seed uint64, data []byte) uint64 {
+	hash := uint64(fnvOffsetBasis) ^ seed
+	for _, b := range data {
+		hash ^= uint64(b)
+		hash *= fnvPrime
+	}
+	return hash
+}
+
+// SipHasher hashes keys with SipHash-2-4, a keyed hash designed to resist
+// hash-flooding: without the 128-bit key an attacker cannot predict which
+// keys collide.
+type SipHasher[K comparable] struct {
+	k0, k1 uint64
+}
+
+// NewSipHasher creates a SipHash-2-4 hasher with the given 128-bit key
+func NewSipHasher[K comparable](k0, k1 uint64) SipHasher[K] {
+	return SipHasher[K]{k0: k0, k1: k1}
+}
+
+// NewSeededSipHasher creates a SipHash-2-4 hasher whose key is derived from a 64-bit seed
+func NewSeededSipHasher[K comparable](seed uint64) SipHasher[K] {
+	return NewSipHasher[K](seed, mix64(seed^fnvOffsetBasis))
+}
+
+// Hash returns the SipHash-2-4 hash of the key
+func (h SipHasher[K]) Hash(key K) uint64 {
+	var buf [32]byte
+	return SipHash24(h.k0, h.k1, appendKey(buf[:0], key))
+}
+
+// SipHash24 computes SipHash-2-4 of data under the key (k0, k1)
+func SipHash24(k0, k1 uint64, data []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	// Compression: two rounds per 8-byte word
+	length := len(data)
+	for ; len(data) >= 8; data = data[8:] {
+		m := binary.LittleEndian.Uint64(data)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+
+	// The final word holds the remaining bytes and the message length
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(length)
+	m := binary.LittleEndian.Uint64(last[:])
+	v3 ^= m
+	round()
+	round()
+	v0 ^= m
+
+	// Finalization: four rounds
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// randomSeed returns a fresh seed for a table that was not given one
+func randomSeed() uint64 {
+	return rand.Uint64()
+}
+
+// newDefaultHasher returns the hasher tables use when none is supplied:
+// SipHash-2-4 keyed from the table's seed
+func newDefaultHasher[K comparable](cfg config) Hasher[K] {
+	return NewSeededSipHasher[K](cfg.seed)
+}
+
+// comparableSeed seeds maphash.Comparable for keys appendKey cannot
+// encode directly
+var comparableSeed = maphash.MakeSeed()
+
+// appendKey appends a byte encoding of key to buf. Equal keys always
+// produce equal encodings. Strings and numeric types are encoded
+// directly; any other comparable type is encoded as its
+// maphash.Comparable hash, which follows ==: pointers hash by address,
+// and structs holding -0 and +0 hash the same. Those hashes are seeded
+// per process, so only directly encoded keys hash the same across runs.
+func appendKey[K comparable](buf []byte, key K) []byte {
+	switch k := any(key).(type) {
+	case string:
+		return append(buf, k...)
+	case int:
+		return binary.LittleEndian.AppendUint64(buf, uint64(k))
+	case int8:
+		return append(buf, byte(k))
+	case int16:
+		return binary.LittleEndian.AppendUint16(buf, uint16(k))
+	case int32:
+		return binary.LittleEndian.AppendUint32(buf, uint32(k))
+	case int64:
+		return binary.LittleEndian.AppendUint64(buf, uint64(k))
+	case uint:
+		return binary.LittleEndian.AppendUint64(buf, uint64(k))
+	case uint8:
+		return append(buf, k)
+	case uint16:
+		return binary.LittleEndian.AppendUint16(buf, k)
+	case uint32:
+		return binary.LittleEndian.AppendUint32(buf, k)
+	case uint64:
+		return binary.LittleEndian.AppendUint64(buf, k)
+	case uintptr:
+		return binary.LittleEndian.AppendUint64(buf, uint64(k))
+	case float32:
+		if k == 0 {
+			k = 0 // -0 == +0, so they must encode the same
+		}
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(k))
+	case float64:
+		if k == 0 {
+			k = 0
+		}
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(k))
+	case bool:
+		if k {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	default:
+		return binary.LittleEndian.AppendUint64(buf, maphash.Comparable(comparableSeed, key))
+	}
+}
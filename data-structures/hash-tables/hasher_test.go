@@ -0,0 +1,185 @@
+package hashtables
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSipHash24Vectors(t *testing.T) {
+	// Reference vectors from the SipHash paper: key 00..0f, message 00..(n-1)
+	k0, k1 := uint64(0x0706050403020100), uint64(0x0f0e0d0c0b0a0908)
+	message := make([]byte, 15)
+	for i := range message {
+		message[i] = byte(i)
+	}
+
+	tests := []struct {
+		length   int
+		expected uint64
+	}{
+		{0, 0x726fdb47dd0e0e31},
+		{1, 0x74f839c593dc67fd},
+		{8, 0x93f5f5799a932462},
+		{15, 0xa129ca6149be45e5},
+	}
+	for _, tt := range tests {
+		if got := SipHash24(k0, k1, message[:tt.length]); got != tt.expected {
+			t.Errorf("SipHash24(len %d) = %#x; want %#x", tt.length, got, tt.expected)
+		}
+	}
+}
+
+func TestFNV1aHasher(t *testing.T) {
+	h := NewFNV1aHasher[string](0)
+	if got := h.Hash("a"); got != 0xaf63dc4c8601ec8c {
+		t.Errorf("FNV-1a(a) = %#x; want 0xaf63dc4c8601ec8c", got)
+	}
+	if NewFNV1aHasher[string](1).Hash("a") == h.Hash("a") {
+		t.Error("Expected different seeds to give different hashes")
+	}
+}
+
+func TestHashersAgreeOnEqualKeys(t *testing.T) {
+	type point struct{ X, Y int }
+
+	sip := NewSeededSipHasher[float64](42)
+	if sip.Hash(0.0) != sip.Hash(math.Copysign(0, -1)) {
+		t.Error("Expected +0 and -0 to hash the same")
+	}
+	structs := NewSeededSipHasher[point](42)
+	if structs.Hash(point{1, 2}) != structs.Hash(point{1, 2}) {
+		t.Error("Expected equal structs to hash the same")
+	}
+	if structs.Hash(point{1, 2}) == structs.Hash(point{2, 1}) {
+		t.Error("Expected different structs to hash differently")
+	}
+}
+
+func TestHashTableCompositeKeys(t *testing.T) {
+	// Pointer keys are equal by address, not by what they point to
+	type node struct{ name string }
+	a, b := &node{"a"}, &node{"a"}
+	pointers := NewHashTable[*node, int](8)
+	pointers.Put(a, 1)
+	pointers.Put(b, 2)
+	a.name = "changed"
+	if v, found := pointers.Get(a); !found || v != 1 {
+		t.Errorf("Expected (1, true) after the pointee changed, got (%d, %v)", v, found)
+	}
+	if pointers.Size() != 2 {
+		t.Errorf("Expected distinct pointers to be distinct keys, got %d", pointers.Size())
+	}
+
+	// -0 == +0, so a struct holding either finds the same entry
+	type sample struct {
+		label string
+		x     float64
+	}
+	floats := NewHashTable[sample, string](8)
+	floats.Put(sample{"origin", 0}, "zero")
+	if v, found := floats.Get(sample{"origin", math.Copysign(0, -1)}); !found || v != "zero" {
+		t.Errorf("Expected (zero, true) for -0, got (%s, %v)", v, found)
+	}
+	floats.Put(sample{"origin", math.Copysign(0, -1)}, "negative zero")
+	if floats.Size() != 1 {
+		t.Errorf("Expected -0 to replace +0, got %d entries", floats.Size())
+	}
+}
+
+func TestHashTableSeeds(t *testing.T) {
+	a := NewHashTable[string, int](64, WithSeed(7))
+	b := NewHashTable[string, int](64, WithSeed(7))
+	c := NewHashTable[string, int](64, WithSeed(8))
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprint("key", i)
+		a.Put(key, i)
+		b.Put(key, i)
+		c.Put(key, i)
+	}
+	if a.String() != b.String() {
+		t.Error("Expected tables with the same seed to have the same layout")
+	}
+	if a.String() == c.String() {
+		t.Error("Expected tables with different seeds to have different layouts")
+	}
+}
+
+// collidingKeys builds 2^blocks strings that all collide under "hash*31 + c"
+// by concatenating the equal-hash pairs "Aa" and "BB"
+func collidingKeys(blocks int) []string {
+	keys := []string{""}
+	for i := 0; i < blocks; i++ {
+		var next []string
+		for _, key := range keys {
+			next = append(next, key+"Aa", key+"BB")
+		}
+		keys = next
+	}
+	return keys
+}
+
+func TestStatsDetectsCraftedCollisions(t *testing.T) {
+	keys := collidingKeys(6)
+
+	weak := NewHashTableWithHasher[string, int](128, PolynomialHash[string](), WithMaxLoadFactor(0))
+	strong := NewHashTable[string, int](128, WithMaxLoadFactor(0), WithSeed(1))
+	for i, key := range keys {
+		weak.Put(key, i)
+		strong.Put(key, i)
+	}
+
+	weakStats := weak.Stats()
+	if weakStats.MaxChainLength != len(keys) {
+		t.Errorf("Expected polynomial hash to put all %d keys in one chain, got max %d", len(keys), weakStats.MaxChainLength)
+	}
+	if weakStats.ObservedCollisions != len(keys)-1 {
+		t.Errorf("Expected %d collisions, got %d", len(keys)-1, weakStats.ObservedCollisions)
+	}
+
+	strongStats := strong.Stats()
+	if strongStats.MaxChainLength > 5 {
+		t.Errorf("Expected SipHash to spread crafted keys, got max chain %d\n%s", strongStats.MaxChainLength, strongStats)
+	}
+	if float64(strongStats.ObservedCollisions) > 2*strongStats.ExpectedCollisions {
+		t.Errorf("Observed %d collisions, expected about %.1f", strongStats.ObservedCollisions, strongStats.ExpectedCollisions)
+	}
+}
+
+func TestStatsHistogram(t *testing.T) {
+	stats := newStats([]int{0, 1, 3, 0, 1})
+	if stats.Entries != 5 || stats.Buckets != 5 || stats.EmptyBuckets != 2 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	expected := []int{2, 2, 0, 1}
+	for i, count := range expected {
+		if stats.ChainLengths[i] != count {
+			t.Errorf("Expected %d buckets of length %d, got %d", count, i, stats.ChainLengths[i])
+		}
+	}
+	if stats.ObservedCollisions != 2 {
+		t.Errorf("Expected 2 collisions, got %d", stats.ObservedCollisions)
+	}
+	// 5 - 5 + 5*(4/5)^5
+	if math.Abs(stats.ExpectedCollisions-1.6384) > 1e-9 {
+		t.Errorf("Expected 1.6384 expected collisions, got %f", stats.ExpectedCollisions)
+	}
+}
+
+func BenchmarkHashers(b *testing.B) {
+	hashers := []struct {
+		name   string
+		hasher Hasher[string]
+	}{
+		{"polynomial", PolynomialHash[string]()},
+		{"fnv1a", NewFNV1aHasher[string](1)},
+		{"siphash", NewSeededSipHasher[string](1)},
+	}
+	for _, h := range hashers {
+		b.Run(h.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h.hasher.Hash("session:0123456789")
+			}
+		})
+	}
+}
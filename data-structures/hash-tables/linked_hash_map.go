@@ -0,0 +1,219 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	linkedlists "go-programming/data-structures/linked-lists"
+)
+
+// Ordering selects how a LinkedHashMap orders its entries
+type Ordering int
+
+const (
+	// InsertionOrder keeps keys in the order they were first put;
+	// updating an existing key does not move it
+	InsertionOrder Ordering = iota
+	// AccessOrder moves a key to the back whenever it is put or read,
+	// so the front is always the least recently used key
+	AccessOrder
+)
+
+// String returns the name of the ordering
+func (o Ordering) String() string {
+	switch o {
+	case InsertionOrder:
+		return "insertion"
+	case AccessOrder:
+		return "access"
+	default:
+		return fmt.Sprintf("Ordering(%d)", int(o))
+	}
+}
+
+// LinkedHashMap represents a hash table that remembers the order of its
+// keys. A HashTable maps each key to its node in a doubly linked list, so
+// lookups, inserts and deletes stay O(1) and iteration is deterministic.
+type LinkedHashMap[K comparable, V any] struct {
+	index    *HashTable[K, *linkedlists.DoublyNode[*Entry[K, V]]]
+	order    *linkedlists.DoublyLinkedList[*Entry[K, V]]
+	ordering Ordering
+}
+
+// NewLinkedHashMap creates a new linked hash map with the given ordering.
+// The options configure the underlying HashTable.
+func NewLinkedHashMap[K comparable, V any](ordering Ordering, opts ...Option) *LinkedHashMap[K, V] {
+	return NewLinkedHashMapWithHasher[K, V](ordering, nil, opts...)
+}
+
+// NewLinkedHashMapWithHasher creates a new linked hash map that uses the given hasher
+func NewLinkedHashMapWithHasher[K comparable, V any](ordering Ordering, hasher Hasher[K], opts ...Option) *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{
+		index:    NewHashTableWithHasher[K, *linkedlists.DoublyNode[*Entry[K, V]]](16, hasher, opts...),
+		order:    linkedlists.NewDoublyLinkedList[*Entry[K, V]](),
+		ordering: ordering,
+	}
+}
+
+// Put inserts or updates a key-value pair. New keys go to the back; an
+// existing key keeps its position unless the map is access ordered.
+func (lm *LinkedHashMap[K, V]) Put(key K, value V) {
+	if node, found := lm.index.Get(key); found {
+		node.Data.Value = value
+		lm.touch(node)
+		return
+	}
+	lm.index.Put(key, lm.order.PushBack(&Entry[K, V]{Key: key, Value: value}))
+}
+
+// touch moves a node to the back in access order
+func (lm *LinkedHashMap[K, V]) touch(node *linkedlists.DoublyNode[*Entry[K, V]]) {
+	if lm.ordering == AccessOrder {
+		lm.order.MoveToBack(node)
+	}
+}
+
+// Get retrieves a value by key. In access order this counts as a use.
+func (lm *LinkedHashMap[K, V]) Get(key K) (V, bool) {
+	node, found := lm.index.Get(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	lm.touch(node)
+	return node.Data.Value, true
+}
+
+// Peek retrieves a value by key without affecting the order
+func (lm *LinkedHashMap[K, V]) Peek(key K) (V, bool) {
+	if node, found := lm.index.Get(key); found {
+		return node.Data.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes a key, leaving the order of the other keys intact
+func (lm *LinkedHashMap[K, V]) Delete(key K) bool {
+	node, found := lm.index.Get(key)
+	if !found {
+		return false
+	}
+	lm.order.Remove(node)
+	lm.index.Delete(key)
+	return true
+}
+
+// Contains checks if a key exists without affecting the order
+func (lm *LinkedHashMap[K, V]) Contains(key K) bool {
+	return lm.index.Contains(key)
+}
+
+// First returns the entry at the front: the oldest key in insertion
+// order, or the least recently used key in access order
+func (lm *LinkedHashMap[K, V]) First() (K, V, bool) {
+	return entryOf(lm.order.Front())
+}
+
+// Last returns the entry at the back: the newest key in insertion
+// order, or the most recently used key in access order
+func (lm *LinkedHashMap[K, V]) Last() (K, V, bool) {
+	return entryOf(lm.order.Back())
+}
+
+func entryOf[K comparable, V any](node *linkedlists.DoublyNode[*Entry[K, V]]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.Data.Key, node.Data.Value, true
+}
+
+// PopFirst removes and returns the entry at the front
+func (lm *LinkedHashMap[K, V]) PopFirst() (K, V, bool) {
+	key, value, ok := lm.First()
+	if ok {
+		lm.Delete(key)
+	}
+	return key, value, ok
+}
+
+// Size returns the number of key-value pairs
+func (lm *LinkedHashMap[K, V]) Size() int {
+	return lm.order.Size()
+}
+
+// IsEmpty checks if the map is empty
+func (lm *LinkedHashMap[K, V]) IsEmpty() bool {
+	return lm.order.IsEmpty()
+}
+
+// Ordering returns how the map orders its entries
+func (lm *LinkedHashMap[K, V]) Ordering() Ordering {
+	return lm.ordering
+}
+
+// All returns an iterator over the key-value pairs from front to back.
+// Iterating does not count as access.
+func (lm *LinkedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := range lm.order.All() {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the key-value pairs from back to front
+func (lm *LinkedHashMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := range lm.order.Backward() {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys from front to back
+func (lm *LinkedHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, lm.Size())
+	for key := range lm.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values from front to back
+func (lm *LinkedHashMap[K, V]) Values() []V {
+	values := make([]V, 0, lm.Size())
+	for _, value := range lm.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all key-value pairs
+func (lm *LinkedHashMap[K, V]) Clear() {
+	lm.index.Clear()
+	lm.order.Clear()
+}
+
+// String returns the key-value pairs from front to back
+func (lm *LinkedHashMap[K, V]) String() string {
+	var result strings.Builder
+	result.WriteString("LinkedHashMap: {")
+	first := true
+	for key, value := range lm.All() {
+		if !first {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%v: %v", key, value))
+		first = false
+	}
+	result.WriteString("}")
+	return result.String()
+}
@@ -0,0 +1,146 @@
+package hashtables
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLinkedHashMapInsertionOrder(t *testing.T) {
+	lm := NewLinkedHashMap[string, int](InsertionOrder)
+	if !lm.IsEmpty() || lm.String() != "LinkedHashMap: {}" {
+		t.Error("Expected linked hash map to be empty")
+	}
+
+	for i, key := range []string{"c", "a", "d", "b"} {
+		lm.Put(key, i)
+	}
+	// Updates and reads keep the insertion order
+	lm.Put("a", 10)
+	lm.Get("c")
+	if got := lm.String(); got != "LinkedHashMap: {c: 0, a: 10, d: 2, b: 3}" {
+		t.Errorf("Unexpected order: %s", got)
+	}
+
+	// Deleting keeps the remaining order; re-adding goes to the back
+	lm.Delete("a")
+	lm.Put("a", 1)
+	if got := fmt.Sprint(lm.Keys()); got != "[c d b a]" {
+		t.Errorf("Expected [c d b a], got %s", got)
+	}
+	if got := fmt.Sprint(lm.Values()); got != "[0 2 3 1]" {
+		t.Errorf("Expected [0 2 3 1], got %s", got)
+	}
+
+	var backward []string
+	for key := range lm.Backward() {
+		backward = append(backward, key)
+	}
+	if fmt.Sprint(backward) != "[a b d c]" {
+		t.Errorf("Expected [a b d c], got %v", backward)
+	}
+
+	if key, value, ok := lm.PopFirst(); !ok || key != "c" || value != 0 {
+		t.Errorf("Expected to pop (c, 0), got (%s, %d, %v)", key, value, ok)
+	}
+	if key, _, _ := lm.Last(); key != "a" {
+		t.Errorf("Expected last key a, got %s", key)
+	}
+	if lm.Size() != 3 || lm.Contains("c") {
+		t.Errorf("Expected 3 keys without c, got %s", lm)
+	}
+
+	lm.Clear()
+	if _, _, ok := lm.First(); ok || !lm.IsEmpty() {
+		t.Error("Expected map to be empty after clear")
+	}
+}
+
+func TestLinkedHashMapAccessOrder(t *testing.T) {
+	lm := NewLinkedHashMap[int, string](AccessOrder)
+	for i := 1; i <= 4; i++ {
+		lm.Put(i, fmt.Sprint(i))
+	}
+
+	lm.Get(1)        // reads move to the back
+	lm.Put(2, "two") // so do updates
+	lm.Peek(3)       // peeks do not
+	if got := fmt.Sprint(lm.Keys()); got != "[3 4 1 2]" {
+		t.Errorf("Expected [3 4 1 2], got %s", got)
+	}
+
+	// The front is the least recently used key, as for an LRU cache
+	if key, _, _ := lm.First(); key != 3 {
+		t.Errorf("Expected least recently used key 3, got %d", key)
+	}
+	if lm.Ordering() != AccessOrder || lm.Ordering().String() != "access" {
+		t.Errorf("Unexpected ordering %v", lm.Ordering())
+	}
+}
+
+func TestLinkedHashMapDeterministicAcrossSeeds(t *testing.T) {
+	// Bucket order depends on the seed; insertion order must not
+	a := NewLinkedHashMap[int, int](InsertionOrder, WithSeed(1))
+	b := NewLinkedHashMap[int, int](InsertionOrder, WithSeed(2))
+	for i := 100; i > 0; i-- {
+		a.Put(i, i*i)
+		b.Put(i, i*i)
+	}
+	if a.String() != b.String() {
+		t.Error("Expected the same string regardless of seed")
+	}
+}
+
+func TestMultiMap(t *testing.T) {
+	mm := NewMultiMap[string, int]()
+	mm.Put("b", 1, 2)
+	mm.Put("a", 3)
+	mm.Put("b", 4)
+	mm.Put("c")
+	if got := mm.String(); got != "MultiMap: {b: [1 2 4], a: [3]}" {
+		t.Errorf("Unexpected multimap: %s", got)
+	}
+	if mm.Size() != 4 || mm.KeyCount() != 2 {
+		t.Errorf("Expected 4 values under 2 keys, got %d and %d", mm.Size(), mm.KeyCount())
+	}
+
+	// Get returns a copy
+	values := mm.Get("b")
+	values[0] = 100
+	if !mm.ContainsValue("b", 1) || mm.ContainsValue("b", 100) {
+		t.Error("Expected Get to return a copy")
+	}
+
+	// Deleting a value keeps the order of the rest
+	if !mm.DeleteValue("b", 2) || mm.DeleteValue("b", 2) {
+		t.Error("Expected to delete 2 exactly once")
+	}
+	if got := fmt.Sprint(mm.Values()); got != "[1 4 3]" {
+		t.Errorf("Expected [1 4 3], got %s", got)
+	}
+
+	// Removing the last value removes the key
+	mm.DeleteValue("a", 3)
+	if mm.Contains("a") || fmt.Sprint(mm.Keys()) != "[b]" {
+		t.Errorf("Expected only key b, got %v", mm.Keys())
+	}
+
+	var pairs []string
+	for key, value := range mm.All() {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", key, value))
+	}
+	if fmt.Sprint(pairs) != "[b=1 b=4]" {
+		t.Errorf("Expected [b=1 b=4], got %v", pairs)
+	}
+	for key, group := range mm.Groups() {
+		if key != "b" || len(group) != 2 {
+			t.Errorf("Unexpected group %s: %v", key, group)
+		}
+	}
+
+	if mm.Delete("b") != 2 || !mm.IsEmpty() {
+		t.Errorf("Expected deleting b to remove 2 values, got %s", mm)
+	}
+	if mm.Delete("b") != 0 {
+		t.Error("Expected deleting a missing key to remove nothing")
+	}
+}
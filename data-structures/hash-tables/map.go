@@ -0,0 +1,41 @@
+package hashtables
+
+import "iter"
+
+// Map is the API shared by every map implementation in this package.
+// MultiMap holds several values per key and is deliberately not a Map.
+type Map[K comparable, V any] interface {
+	// Put inserts or updates a key-value pair
+	Put(key K, value V)
+	// Get retrieves the value associated with the given key
+	Get(key K) (V, bool)
+	// Delete removes the key-value pair with the given key
+	Delete(key K) bool
+	// Contains checks if the map contains the given key
+	Contains(key K) bool
+	// Size returns the number of key-value pairs
+	Size() int
+	// IsEmpty checks if the map is empty
+	IsEmpty() bool
+	// All returns an iterator over all key-value pairs
+	All() iter.Seq2[K, V]
+	// Keys returns all keys
+	Keys() []K
+	// Values returns all values
+	Values() []V
+	// Clear removes all key-value pairs
+	Clear()
+	// String returns a string representation of the map
+	String() string
+}
+
+var (
+	_ Map[string, int] = (*HashTable[string, int])(nil)
+	_ Map[string, int] = (*SimpleHashMap[string, int])(nil)
+	_ Map[string, int] = (*ProbingHashTable[string, int])(nil)
+	_ Map[string, int] = (*RobinHoodHashTable[string, int])(nil)
+	_ Map[string, int] = (*CuckooHashTable[string, int])(nil)
+	_ Map[string, int] = (*ConcurrentHashTable[string, int])(nil)
+	_ Map[string, int] = (*LinkedHashMap[string, int])(nil)
+	_ Map[string, int] = (*TTLMap[string, int])(nil)
+)
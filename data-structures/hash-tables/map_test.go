@@ -0,0 +1,88 @@
+package hashtables_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	hashtables "go-programming/data-structures/hash-tables"
+	"go-programming/data-structures/hash-tables/maptest"
+)
+
+// tickingClock is a Clock that moves forward by step every time it is
+// read, so a TTLMap sees time pass during a script
+type tickingClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *tickingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+func (c *tickingClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time) // the janitor is disabled
+}
+
+func TestMapConformance(t *testing.T) {
+	implementations := []struct {
+		name string
+		new  func() hashtables.Map[int, int]
+	}{
+		{"HashTable", func() hashtables.Map[int, int] {
+			return hashtables.NewHashTable[int, int](4, hashtables.WithMinLoadFactor(0.1))
+		}},
+		{"HashTable/incremental", func() hashtables.Map[int, int] {
+			return hashtables.NewHashTable[int, int](4, hashtables.WithIncrementalRehash(1), hashtables.WithMinLoadFactor(0.1))
+		}},
+		{"SimpleHashMap", func() hashtables.Map[int, int] {
+			return hashtables.NewSimpleHashMap[int, int]()
+		}},
+		{"ProbingHashTable/linear", func() hashtables.Map[int, int] {
+			return hashtables.NewProbingHashTable[int, int](4, hashtables.LinearProbing)
+		}},
+		{"ProbingHashTable/quadratic", func() hashtables.Map[int, int] {
+			return hashtables.NewProbingHashTable[int, int](4, hashtables.QuadraticProbing)
+		}},
+		{"RobinHoodHashTable", func() hashtables.Map[int, int] {
+			return hashtables.NewRobinHoodHashTable[int, int](4)
+		}},
+		{"CuckooHashTable", func() hashtables.Map[int, int] {
+			return hashtables.NewCuckooHashTable[int, int](4)
+		}},
+		{"ConcurrentHashTable", func() hashtables.Map[int, int] {
+			return hashtables.NewConcurrentHashTable[int, int](4)
+		}},
+		{"LinkedHashMap/insertion", func() hashtables.Map[int, int] {
+			return hashtables.NewLinkedHashMap[int, int](hashtables.InsertionOrder)
+		}},
+		{"LinkedHashMap/access", func() hashtables.Map[int, int] {
+			return hashtables.NewLinkedHashMap[int, int](hashtables.AccessOrder)
+		}},
+		{"TTLMap", func() hashtables.Map[int, int] {
+			m := hashtables.NewTTLMap(context.Background(), 0, hashtables.WithSweepInterval[int, int](0))
+			t.Cleanup(func() { m.Close() })
+			return m
+		}},
+		// Every entry expires, but no script runs long enough to see it
+		{"TTLMap/ttl", func() hashtables.Map[int, int] {
+			clock := &tickingClock{step: time.Millisecond}
+			m := hashtables.NewTTLMap(context.Background(), time.Hour,
+				hashtables.WithClock[int, int](clock),
+				hashtables.WithSweepInterval[int, int](0))
+			t.Cleanup(func() { m.Close() })
+			return m
+		}},
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			maptest.Run(t, impl.new)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Package maptest provides a conformance suite for hashtables.Map
+// implementations. Every case replays a script of operations against both
+// the implementation and a built-in Go map and fails on the first
+// difference.
+package maptest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	hashtables "go-programming/data-structures/hash-tables"
+)
+
+// OpKind names an operation in a script
+type OpKind int
+
+const (
+	Put OpKind = iota
+	Get
+	Delete
+	Contains
+	Clear
+)
+
+// String returns the name of the operation
+func (k OpKind) String() string {
+	switch k {
+	case Put:
+		return "Put"
+	case Get:
+		return "Get"
+	case Delete:
+		return "Delete"
+	case Contains:
+		return "Contains"
+	case Clear:
+		return "Clear"
+	default:
+		return fmt.Sprintf("OpKind(%d)", int(k))
+	}
+}
+
+// Op is one step of a script. Value is only used by Put.
+type Op struct {
+	Kind  OpKind
+	Key   int
+	Value int
+}
+
+// String returns the operation as a call, e.g. Put(1, 2)
+func (op Op) String() string {
+	switch op.Kind {
+	case Put:
+		return fmt.Sprintf("Put(%d, %d)", op.Key, op.Value)
+	case Clear:
+		return "Clear()"
+	default:
+		return fmt.Sprintf("%s(%d)", op.Kind, op.Key)
+	}
+}
+
+// Case is a named script of operations
+type Case struct {
+	Name string
+	Ops  []Op
+}
+
+// Cases returns the scripts every implementation must pass
+func Cases() []Case {
+	return []Case{
+		{"empty", []Op{{Get, 1, 0}, {Delete, 1, 0}, {Contains, 1, 0}}},
+		{"put and get", []Op{{Put, 1, 10}, {Put, 2, 20}, {Get, 1, 0}, {Get, 2, 0}, {Get, 3, 0}}},
+		{"update", []Op{{Put, 1, 10}, {Put, 1, 11}, {Get, 1, 0}}},
+		{"zero key and value", []Op{{Put, 0, 0}, {Contains, 0, 0}, {Get, 0, 0}, {Delete, 0, 0}, {Contains, 0, 0}}},
+		{"negative keys", []Op{{Put, -1, 1}, {Put, -2, 2}, {Get, -1, 0}, {Delete, -2, 0}}},
+		{"delete", []Op{{Put, 1, 10}, {Put, 2, 20}, {Delete, 1, 0}, {Delete, 1, 0}, {Get, 1, 0}, {Get, 2, 0}}},
+		{"reinsert after delete", []Op{{Put, 1, 10}, {Delete, 1, 0}, {Put, 1, 12}, {Get, 1, 0}}},
+		{"clear", []Op{{Put, 1, 10}, {Put, 2, 20}, {Clear, 0, 0}, {Get, 1, 0}, {Put, 3, 30}, {Get, 3, 0}}},
+		{"grow", sequential(Put, 0, 1000)},
+		{"grow and shrink", append(sequential(Put, 0, 500), sequential(Delete, 0, 500)...)},
+		{"interleaved", interleaved(200)},
+		{"random", random(1, 5000, 300)},
+		{"random dense", random(2, 5000, 20)},
+	}
+}
+
+// sequential returns kind applied to keys [from, to), putting key*key
+func sequential(kind OpKind, from, to int) []Op {
+	ops := make([]Op, 0, to-from)
+	for key := from; key < to; key++ {
+		ops = append(ops, Op{kind, key, key * key})
+	}
+	return ops
+}
+
+// interleaved puts keys and deletes every other one right behind them
+func interleaved(n int) []Op {
+	var ops []Op
+	for key := 0; key < n; key++ {
+		ops = append(ops, Op{Put, key, key})
+		if key%2 == 1 {
+			ops = append(ops, Op{Delete, key - 1, 0}, Op{Get, key, 0})
+		}
+	}
+	return ops
+}
+
+// random returns n operations on keys in [0, keys) from a fixed seed
+func random(seed int64, n, keys int) []Op {
+	rng := rand.New(rand.NewSource(seed))
+	ops := make([]Op, n)
+	for i := range ops {
+		kind := []OpKind{Put, Put, Put, Get, Delete, Delete, Contains}[rng.Intn(7)]
+		if rng.Intn(1000) == 0 {
+			kind = Clear
+		}
+		ops[i] = Op{kind, rng.Intn(keys), i}
+	}
+	return ops
+}
+
+// Run runs every case against a fresh map from newMap
+func Run(t *testing.T, newMap func() hashtables.Map[int, int]) {
+	t.Helper()
+	for _, c := range Cases() {
+		t.Run(c.Name, func(t *testing.T) {
+			RunCase(t, newMap(), c)
+		})
+	}
+	t.Run("early break", func(t *testing.T) {
+		testEarlyBreak(t, newMap())
+	})
+}
+
+// RunCase replays one case against m, checking each result and the size
+// after every step, and the full contents at the end
+func RunCase(t *testing.T, m hashtables.Map[int, int], c Case) {
+	t.Helper()
+	reference := make(map[int]int)
+
+	for step, op := range c.Ops {
+		switch op.Kind {
+		case Put:
+			m.Put(op.Key, op.Value)
+			reference[op.Key] = op.Value
+		case Get:
+			want, wantFound := reference[op.Key]
+			if got, found := m.Get(op.Key); got != want || found != wantFound {
+				t.Fatalf("step %d: %s = (%d, %v), want (%d, %v)", step, op, got, found, want, wantFound)
+			}
+		case Delete:
+			_, want := reference[op.Key]
+			if got := m.Delete(op.Key); got != want {
+				t.Fatalf("step %d: %s = %v, want %v", step, op, got, want)
+			}
+			delete(reference, op.Key)
+		case Contains:
+			_, want := reference[op.Key]
+			if got := m.Contains(op.Key); got != want {
+				t.Fatalf("step %d: %s = %v, want %v", step, op, got, want)
+			}
+		case Clear:
+			m.Clear()
+			clear(reference)
+		}
+
+		if m.Size() != len(reference) || m.IsEmpty() != (len(reference) == 0) {
+			t.Fatalf("step %d: after %s Size() = %d, IsEmpty() = %v; want %d",
+				step, op, m.Size(), m.IsEmpty(), len(reference))
+		}
+	}
+
+	CheckContents(t, m, reference)
+}
+
+// CheckContents verifies that m holds exactly the entries of reference,
+// through Get, All, Keys, Values and String
+func CheckContents(t *testing.T, m hashtables.Map[int, int], reference map[int]int) {
+	t.Helper()
+
+	for key, want := range reference {
+		if got, found := m.Get(key); !found || got != want {
+			t.Fatalf("Get(%d) = (%d, %v), want (%d, true)", key, got, found, want)
+		}
+	}
+
+	seen := make(map[int]bool, len(reference))
+	for key, value := range m.All() {
+		if want, found := reference[key]; !found || value != want {
+			t.Fatalf("All yielded (%d, %d), want value %d (present: %v)", key, value, want, found)
+		}
+		if seen[key] {
+			t.Fatalf("All yielded key %d twice", key)
+		}
+		seen[key] = true
+	}
+	if len(seen) != len(reference) {
+		t.Fatalf("All yielded %d entries, want %d", len(seen), len(reference))
+	}
+
+	keys := m.Keys()
+	sort.Ints(keys)
+	values := m.Values()
+	sort.Ints(values)
+	wantKeys := make([]int, 0, len(reference))
+	wantValues := make([]int, 0, len(reference))
+	for key, value := range reference {
+		wantKeys = append(wantKeys, key)
+		wantValues = append(wantValues, value)
+	}
+	sort.Ints(wantKeys)
+	sort.Ints(wantValues)
+	if fmt.Sprint(keys) != fmt.Sprint(wantKeys) {
+		t.Fatalf("Keys() = %v, want %v", keys, wantKeys)
+	}
+	if fmt.Sprint(values) != fmt.Sprint(wantValues) {
+		t.Fatalf("Values() = %v, want %v", values, wantValues)
+	}
+
+	// Formats differ between implementations, but each entry must appear
+	if len(reference) <= 100 {
+		s := m.String()
+		for key, value := range reference {
+			if entry := fmt.Sprintf("%d: %d", key, value); !strings.Contains(s, entry) {
+				t.Fatalf("String() = %q is missing %q", s, entry)
+			}
+		}
+	}
+}
+
+// testEarlyBreak checks that All stops when the loop body breaks
+func testEarlyBreak(t *testing.T, m hashtables.Map[int, int]) {
+	for key := 0; key < 10; key++ {
+		m.Put(key, key)
+	}
+	visited := 0
+	for range m.All() {
+		visited++
+		if visited == 3 {
+			break
+		}
+	}
+	if visited != 3 {
+		t.Errorf("Expected All to stop after 3 entries, visited %d", visited)
+	}
+}
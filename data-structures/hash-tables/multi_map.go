@@ -0,0 +1,157 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// MultiMap represents a map that holds several values per key. Keys are
+// kept in the order they were first added and each key's values in the
+// order they were put, so iteration and String are deterministic.
+type MultiMap[K comparable, V comparable] struct {
+	entries *LinkedHashMap[K, []V]
+	size    int
+}
+
+// NewMultiMap creates a new empty multimap. The options configure the
+// underlying HashTable.
+func NewMultiMap[K comparable, V comparable](opts ...Option) *MultiMap[K, V] {
+	return &MultiMap[K, V]{entries: NewLinkedHashMap[K, []V](InsertionOrder, opts...)}
+}
+
+// Put appends values to the values of key
+func (mm *MultiMap[K, V]) Put(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	existing, _ := mm.entries.Get(key)
+	mm.entries.Put(key, append(existing, values...))
+	mm.size += len(values)
+}
+
+// Get returns a copy of the values of key in the order they were put
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	values, _ := mm.entries.Get(key)
+	return slices.Clone(values)
+}
+
+// Delete removes key and all of its values, returning how many were removed
+func (mm *MultiMap[K, V]) Delete(key K) int {
+	values, found := mm.entries.Get(key)
+	if !found {
+		return 0
+	}
+	mm.entries.Delete(key)
+	mm.size -= len(values)
+	return len(values)
+}
+
+// DeleteValue removes the first occurrence of value from key. The key is
+// removed once its last value is gone.
+func (mm *MultiMap[K, V]) DeleteValue(key K, value V) bool {
+	values, found := mm.entries.Get(key)
+	if !found {
+		return false
+	}
+	i := slices.Index(values, value)
+	if i < 0 {
+		return false
+	}
+
+	values = slices.Delete(values, i, i+1)
+	if len(values) == 0 {
+		mm.entries.Delete(key)
+	} else {
+		mm.entries.Put(key, values)
+	}
+	mm.size--
+	return true
+}
+
+// Contains checks if key has at least one value
+func (mm *MultiMap[K, V]) Contains(key K) bool {
+	return mm.entries.Contains(key)
+}
+
+// ContainsValue checks if value is one of the values of key
+func (mm *MultiMap[K, V]) ContainsValue(key K, value V) bool {
+	values, _ := mm.entries.Get(key)
+	return slices.Contains(values, value)
+}
+
+// Size returns the number of key-value pairs, counting every value
+func (mm *MultiMap[K, V]) Size() int {
+	return mm.size
+}
+
+// KeyCount returns the number of distinct keys
+func (mm *MultiMap[K, V]) KeyCount() int {
+	return mm.entries.Size()
+}
+
+// IsEmpty checks if the multimap is empty
+func (mm *MultiMap[K, V]) IsEmpty() bool {
+	return mm.size == 0
+}
+
+// All returns an iterator over every key-value pair, grouped by key
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range mm.entries.All() {
+			for _, value := range values {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Groups returns an iterator over each key and its values
+func (mm *MultiMap[K, V]) Groups() iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		for key, values := range mm.entries.All() {
+			if !yield(key, slices.Clone(values)) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the distinct keys in the order they were first added
+func (mm *MultiMap[K, V]) Keys() []K {
+	return mm.entries.Keys()
+}
+
+// Values returns every value, grouped by key
+func (mm *MultiMap[K, V]) Values() []V {
+	values := make([]V, 0, mm.size)
+	for _, value := range mm.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all keys and values
+func (mm *MultiMap[K, V]) Clear() {
+	mm.entries.Clear()
+	mm.size = 0
+}
+
+// String returns each key with its values, in order
+func (mm *MultiMap[K, V]) String() string {
+	var result strings.Builder
+	result.WriteString("MultiMap: {")
+	first := true
+	for key, values := range mm.entries.All() {
+		if !first {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%v: %v", key, values))
+		first = false
+	}
+	result.WriteString("}")
+	return result.String()
+}
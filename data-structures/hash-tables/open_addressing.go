@@ -0,0 +1,310 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// ProbeStrategy selects how ProbingHashTable walks the table on a collision
+type ProbeStrategy int
+
+const (
+	// LinearProbing tries slots h, h+1, h+2, ...
+	LinearProbing ProbeStrategy = iota
+	// QuadraticProbing tries slots h, h+1, h+3, h+6, ... (triangular numbers),
+	// which visits every slot of a power-of-two table
+	QuadraticProbing
+)
+
+// String returns the name of the probe strategy
+func (ps ProbeStrategy) String() string {
+	switch ps {
+	case LinearProbing:
+		return "linear"
+	case QuadraticProbing:
+		return "quadratic"
+	default:
+		return fmt.Sprintf("ProbeStrategy(%d)", int(ps))
+	}
+}
+
+// slotState marks whether an open-addressing slot is in use
+type slotState uint8
+
+const (
+	slotEmpty slotState = iota
+	slotOccupied
+	slotDeleted // tombstone left behind by Delete
+)
+
+type probeSlot[K comparable, V any] struct {
+	key   K
+	value V
+	state slotState
+}
+
+// ProbingHashTable represents a hash table with open addressing.
+// Deleted slots are marked with tombstones so probe sequences stay intact;
+// tombstones are purged whenever the table is rehashed.
+type ProbingHashTable[K comparable, V any] struct {
+	slots      []probeSlot[K, V]
+	count      int
+	tombstones int
+	strategy   ProbeStrategy
+	hasher     Hasher[K]
+
+	minSize       int
+	maxLoadFactor float64
+	minLoadFactor float64
+}
+
+// NewProbingHashTable creates a new open-addressing hash table.
+// The size is rounded up to a power of two. Load factor options are
+// honoured; incremental rehashing is not supported.
+func NewProbingHashTable[K comparable, V any](size int, strategy ProbeStrategy, opts ...Option) *ProbingHashTable[K, V] {
+	return NewProbingHashTableWithHasher[K, V](size, strategy, nil, opts...)
+}
+
+// NewProbingHashTableWithHasher creates a new open-addressing hash table that uses the given hasher
+func NewProbingHashTableWithHasher[K comparable, V any](size int, strategy ProbeStrategy, hasher Hasher[K], opts ...Option) *ProbingHashTable[K, V] {
+	size = nextPowerOfTwo(size)
+	cfg := newOpenAddressingConfig(opts)
+	if hasher == nil {
+		hasher = newDefaultHasher[K](cfg)
+	}
+	return &ProbingHashTable[K, V]{
+		slots:         make([]probeSlot[K, V], size),
+		strategy:      strategy,
+		hasher:        hasher,
+		minSize:       size,
+		maxLoadFactor: cfg.maxLoadFactor,
+		minLoadFactor: cfg.minLoadFactor,
+	}
+}
+
+// newOpenAddressingConfig applies options for tables that cannot chain:
+// they must grow before filling up, so the maximum load factor is kept in (0, 1)
+func newOpenAddressingConfig(opts []Option) config {
+	cfg := newConfig(opts)
+	if cfg.maxLoadFactor <= 0 || cfg.maxLoadFactor >= 1 {
+		cfg.maxLoadFactor = DefaultMaxLoadFactor
+		if cfg.minLoadFactor*2 >= cfg.maxLoadFactor {
+			cfg.minLoadFactor = cfg.maxLoadFactor / 4
+		}
+	}
+	return cfg
+}
+
+// nextPowerOfTwo rounds n up to a power of two, defaulting to 16
+func nextPowerOfTwo(n int) int {
+	if n <= 0 {
+		return 16
+	}
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// mix64 scrambles a hash so that its low bits can be used as a table index
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// probe returns the i-th slot of the probe sequence starting at home
+func (pt *ProbingHashTable[K, V]) probe(home, i int) int {
+	mask := len(pt.slots) - 1
+	if pt.strategy == QuadraticProbing {
+		return (home + i*(i+1)/2) & mask
+	}
+	return (home + i) & mask
+}
+
+// lookup returns the slot holding key, or the slot where it should be inserted
+func (pt *ProbingHashTable[K, V]) lookup(key K) (int, bool) {
+	home := int(mix64(pt.hasher.Hash(key)) & uint64(len(pt.slots)-1))
+	firstTombstone := -1
+
+	for i := 0; i < len(pt.slots); i++ {
+		index := pt.probe(home, i)
+		switch pt.slots[index].state {
+		case slotEmpty:
+			if firstTombstone >= 0 {
+				return firstTombstone, false
+			}
+			return index, false
+		case slotDeleted:
+			if firstTombstone < 0 {
+				firstTombstone = index
+			}
+		case slotOccupied:
+			if pt.slots[index].key == key {
+				return index, true
+			}
+		}
+	}
+
+	return firstTombstone, false
+}
+
+// Put inserts or updates a key-value pair in the hash table
+func (pt *ProbingHashTable[K, V]) Put(key K, value V) {
+	index, found := pt.lookup(key)
+	if found {
+		pt.slots[index].value = value
+		return
+	}
+
+	// Grow when live entries are too dense; otherwise just purge tombstones
+	capacity := float64(len(pt.slots))
+	if float64(pt.count+1) > pt.maxLoadFactor*capacity {
+		pt.resize(len(pt.slots) * 2)
+		index, _ = pt.lookup(key)
+	} else if index < 0 || float64(pt.count+pt.tombstones+1) > pt.maxLoadFactor*capacity {
+		pt.resize(len(pt.slots))
+		index, _ = pt.lookup(key)
+	}
+
+	if pt.slots[index].state == slotDeleted {
+		pt.tombstones--
+	}
+	pt.slots[index] = probeSlot[K, V]{key: key, value: value, state: slotOccupied}
+	pt.count++
+}
+
+// Get retrieves the value associated with the given key
+func (pt *ProbingHashTable[K, V]) Get(key K) (V, bool) {
+	if index, found := pt.lookup(key); found {
+		return pt.slots[index].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the key-value pair with the given key, leaving a tombstone
+func (pt *ProbingHashTable[K, V]) Delete(key K) bool {
+	index, found := pt.lookup(key)
+	if !found {
+		return false
+	}
+
+	var zero probeSlot[K, V]
+	pt.slots[index] = zero
+	pt.slots[index].state = slotDeleted
+	pt.count--
+	pt.tombstones++
+
+	if pt.minLoadFactor > 0 && len(pt.slots)/2 >= pt.minSize && pt.LoadFactor() < pt.minLoadFactor {
+		pt.resize(len(pt.slots) / 2)
+	}
+	return true
+}
+
+// resize rehashes every live entry into a table of the given size
+func (pt *ProbingHashTable[K, V]) resize(size int) {
+	old := pt.slots
+	pt.slots = make([]probeSlot[K, V], size)
+	pt.count = 0
+	pt.tombstones = 0
+
+	for _, s := range old {
+		if s.state == slotOccupied {
+			index, _ := pt.lookup(s.key)
+			pt.slots[index] = s
+			pt.count++
+		}
+	}
+}
+
+// Contains checks if the hash table contains the given key
+func (pt *ProbingHashTable[K, V]) Contains(key K) bool {
+	_, found := pt.lookup(key)
+	return found
+}
+
+// Size returns the number of key-value pairs in the hash table
+func (pt *ProbingHashTable[K, V]) Size() int {
+	return pt.count
+}
+
+// Count returns the number of key-value pairs.
+//
+// Deprecated: Use Size, which every Map in this package provides.
+func (pt *ProbingHashTable[K, V]) Count() int {
+	return pt.Size()
+}
+
+// IsEmpty checks if the hash table is empty
+func (pt *ProbingHashTable[K, V]) IsEmpty() bool {
+	return pt.count == 0
+}
+
+// Tombstones returns the number of deleted slots awaiting a rehash
+func (pt *ProbingHashTable[K, V]) Tombstones() int {
+	return pt.tombstones
+}
+
+// All returns an iterator over all key-value pairs in slot order
+func (pt *ProbingHashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, s := range pt.slots {
+			if s.state == slotOccupied && !yield(s.key, s.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys in the hash table
+func (pt *ProbingHashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, pt.count)
+	for key := range pt.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values in the hash table
+func (pt *ProbingHashTable[K, V]) Values() []V {
+	values := make([]V, 0, pt.count)
+	for _, value := range pt.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all entries from the hash table
+func (pt *ProbingHashTable[K, V]) Clear() {
+	pt.slots = make([]probeSlot[K, V], pt.minSize)
+	pt.count = 0
+	pt.tombstones = 0
+}
+
+// LoadFactor returns the load factor of the hash table
+func (pt *ProbingHashTable[K, V]) LoadFactor() float64 {
+	return float64(pt.count) / float64(len(pt.slots))
+}
+
+// String returns a string representation of the hash table
+func (pt *ProbingHashTable[K, V]) String() string {
+	if pt.IsEmpty() {
+		return "ProbingHashTable: {}"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("ProbingHashTable (%s): {\n", pt.strategy))
+	for i, s := range pt.slots {
+		if s.state == slotOccupied {
+			result.WriteString(fmt.Sprintf("  [%d]: (%v: %v)\n", i, s.key, s.value))
+		}
+	}
+	result.WriteString("}")
+	return result.String()
+}
@@ -0,0 +1,222 @@
+package hashtables
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// table is the API shared by every hash table variant in this package
+type table interface {
+	Map[int, int]
+	LoadFactor() float64
+}
+
+var tableVariants = []struct {
+	name string
+	new  func(size int) table
+}{
+	{"chained", func(size int) table { return NewHashTable[int, int](size) }},
+	{"linear", func(size int) table { return NewProbingHashTable[int, int](size, LinearProbing) }},
+	{"quadratic", func(size int) table { return NewProbingHashTable[int, int](size, QuadraticProbing) }},
+	{"robinhood", func(size int) table { return NewRobinHoodHashTable[int, int](size) }},
+	{"cuckoo", func(size int) table { return NewCuckooHashTable[int, int](size) }},
+}
+
+func TestTableVariantsAgainstMap(t *testing.T) {
+	for _, variant := range tableVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			tbl := variant.new(4)
+			reference := make(map[int]int)
+
+			for i := 0; i < 5000; i++ {
+				key := rng.Intn(500)
+				switch rng.Intn(3) {
+				case 0, 1:
+					tbl.Put(key, i)
+					reference[key] = i
+				case 2:
+					_, want := reference[key]
+					if got := tbl.Delete(key); got != want {
+						t.Fatalf("Delete(%d) = %v, want %v", key, got, want)
+					}
+					delete(reference, key)
+				}
+
+				if tbl.Size() != len(reference) {
+					t.Fatalf("Expected count %d, got %d", len(reference), tbl.Size())
+				}
+			}
+
+			for key, want := range reference {
+				if got, found := tbl.Get(key); !found || got != want {
+					t.Errorf("Get(%d) = (%d, %v), want (%d, true)", key, got, found, want)
+				}
+			}
+			seen := 0
+			for key, value := range tbl.All() {
+				if reference[key] != value {
+					t.Errorf("All yielded (%d, %d), want value %d", key, value, reference[key])
+				}
+				seen++
+			}
+			if seen != len(reference) || len(tbl.Keys()) != seen || len(tbl.Values()) != seen {
+				t.Errorf("Expected %d entries, iterated %d", len(reference), seen)
+			}
+			if tbl.LoadFactor() > 1 && variant.name != "chained" {
+				t.Errorf("Open addressing load factor %f exceeds 1", tbl.LoadFactor())
+			}
+
+			tbl.Clear()
+			if !tbl.IsEmpty() || tbl.Contains(1) {
+				t.Error("Expected table to be empty after clear")
+			}
+		})
+	}
+}
+
+func TestProbingHashTableTombstones(t *testing.T) {
+	for _, strategy := range []ProbeStrategy{LinearProbing, QuadraticProbing} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			// Every key lands in slot 0 so they form one probe sequence
+			pt := NewProbingHashTableWithHasher[int, string](16, strategy, HashFunc[int](func(int) uint64 { return 0 }))
+			for i := 0; i < 5; i++ {
+				pt.Put(i, fmt.Sprint(i))
+			}
+
+			// Deleting from the middle must not cut off later keys
+			pt.Delete(1)
+			pt.Delete(2)
+			if pt.Tombstones() != 2 {
+				t.Errorf("Expected 2 tombstones, got %d", pt.Tombstones())
+			}
+			for _, key := range []int{0, 3, 4} {
+				if !pt.Contains(key) {
+					t.Errorf("Expected key %d to survive deletion of earlier keys", key)
+				}
+			}
+
+			// Inserting reuses a tombstone instead of extending the sequence
+			pt.Put(5, "5")
+			if pt.Tombstones() != 1 {
+				t.Errorf("Expected tombstone to be reused, got %d tombstones", pt.Tombstones())
+			}
+		})
+	}
+}
+
+func TestProbingHashTablePurgesTombstones(t *testing.T) {
+	pt := NewProbingHashTable[int, int](16, LinearProbing)
+	for i := 0; i < 1000; i++ {
+		pt.Put(i, i)
+		pt.Delete(i)
+	}
+	if float64(pt.Tombstones()) > pt.maxLoadFactor*float64(len(pt.slots)) {
+		t.Errorf("Expected tombstones to be purged, got %d in %d slots", pt.Tombstones(), len(pt.slots))
+	}
+	if len(pt.slots) != 16 {
+		t.Errorf("Expected delete-heavy churn not to grow the table, got %d slots", len(pt.slots))
+	}
+}
+
+func TestRobinHoodHashTableInvariants(t *testing.T) {
+	rh := NewRobinHoodHashTable[int, int](8)
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 2000; i++ {
+		key := rng.Intn(300)
+		if rng.Intn(2) == 0 {
+			rh.Put(key, key)
+		} else {
+			rh.Delete(key)
+		}
+
+		// Every stored distance matches the slot's offset from home, and
+		// backward-shift deletion leaves no gaps inside a probe run
+		mask := len(rh.slots) - 1
+		for index, s := range rh.slots {
+			if !s.occupied {
+				continue
+			}
+			if want := (index - rh.home(s.key)) & mask; s.distance != want {
+				t.Fatalf("Slot %d stores distance %d, want %d", index, s.distance, want)
+			}
+			if s.distance > 0 && !rh.slots[(index-1)&mask].occupied {
+				t.Fatalf("Slot %d is displaced but follows an empty slot", index)
+			}
+		}
+	}
+	if rh.MaxProbeLength() > 16 {
+		t.Errorf("Unexpectedly long probe length %d", rh.MaxProbeLength())
+	}
+}
+
+func TestCuckooHashTableStash(t *testing.T) {
+	// Keys with the same hash code can only share two slots, so the
+	// rest must overflow into the stash
+	ct := NewCuckooHashTableWithHasher[int, int](8, HashFunc[int](func(k int) uint64 { return uint64(k % 2) }))
+	for i := 0; i < 10; i += 2 {
+		ct.Put(i, i)
+	}
+	if ct.StashLen() != 3 {
+		t.Errorf("Expected 3 stashed entries, got %d", ct.StashLen())
+	}
+	for i := 0; i < 10; i += 2 {
+		if val, found := ct.Get(i); !found || val != i {
+			t.Errorf("Expected (%d, true), got (%d, %v)", i, val, found)
+		}
+	}
+
+	// Freeing a table slot pulls a stashed entry back in
+	ct.Delete(0)
+	if ct.StashLen() != 2 {
+		t.Errorf("Expected stash to drain to 2, got %d", ct.StashLen())
+	}
+	if ct.Size() != 4 {
+		t.Errorf("Expected count 4, got %d", ct.Size())
+	}
+}
+
+func benchmarkVariants(b *testing.B, run func(b *testing.B, tbl table)) {
+	for _, variant := range tableVariants {
+		b.Run(variant.name, func(b *testing.B) {
+			run(b, variant.new(16))
+		})
+	}
+}
+
+func BenchmarkTablePut(b *testing.B) {
+	benchmarkVariants(b, func(b *testing.B, tbl table) {
+		for i := 0; i < b.N; i++ {
+			tbl.Put(i, i)
+		}
+	})
+}
+
+func BenchmarkTableGet(b *testing.B) {
+	const n = 1 << 16
+	benchmarkVariants(b, func(b *testing.B, tbl table) {
+		for i := 0; i < n; i++ {
+			tbl.Put(i, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tbl.Get(i & (2*n - 1)) // half hits, half misses
+		}
+	})
+}
+
+func BenchmarkTableDeleteHeavy(b *testing.B) {
+	const n = 1 << 12
+	benchmarkVariants(b, func(b *testing.B, tbl table) {
+		for i := 0; i < n; i++ {
+			tbl.Put(i, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tbl.Delete(i)
+			tbl.Put(i+n, i)
+			tbl.Get(i + n/2)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package hashtables
+
+// Default growth policy for HashTable
+const (
+	DefaultMaxLoadFactor = 0.75
+	DefaultMinLoadFactor = 0.0
+)
+
+// config holds the settings collected from Options
+type config struct {
+	maxLoadFactor float64
+	minLoadFactor float64
+	rehashStep    int
+	seed          uint64
+	seeded        bool
+}
+
+// Option configures a hash table created by NewHashTable
+type Option func(*config)
+
+// WithMaxLoadFactor sets the load factor above which the table doubles
+// its bucket count. A value <= 0 disables growth.
+func WithMaxLoadFactor(f float64) Option {
+	return func(c *config) {
+		c.maxLoadFactor = f
+	}
+}
+
+// WithMinLoadFactor sets the load factor below which the table halves
+// its bucket count, never going below the initial size. A value <= 0
+// disables shrinking.
+func WithMinLoadFactor(f float64) Option {
+	return func(c *config) {
+		c.minLoadFactor = f
+	}
+}
+
+// WithIncrementalRehash spreads a resize over many operations, Redis-style:
+// every Put, Get and Delete migrates up to step buckets from the old table
+// to the new one. A step <= 0 rehashes the whole table at once.
+func WithIncrementalRehash(step int) Option {
+	return func(c *config) {
+		c.rehashStep = step
+	}
+}
+
+// WithSeed fixes the seed of the table's default hasher. Without it every
+// table draws a random seed, so bucket layouts differ between tables and runs.
+func WithSeed(seed uint64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		maxLoadFactor: DefaultMaxLoadFactor,
+		minLoadFactor: DefaultMinLoadFactor,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.seeded {
+		cfg.seed = randomSeed()
+	}
+
+	// Halving the table doubles the load factor, so a minimum above half
+	// the maximum would make every shrink trigger another grow
+	if cfg.maxLoadFactor > 0 && cfg.minLoadFactor*2 >= cfg.maxLoadFactor {
+		cfg.minLoadFactor = cfg.maxLoadFactor / 4
+	}
+	return cfg
+}
+
+// rehashing reports whether an incremental rehash is in progress
+func (ht *HashTable[K, V]) rehashing() bool {
+	return ht.rehashIndex >= 0
+}
+
+// Rehashing reports whether an incremental rehash is in progress
+func (ht *HashTable[K, V]) Rehashing() bool {
+	return ht.rehashing()
+}
+
+// BucketCount returns the number of buckets the table is sized for.
+// During an incremental rehash this is the size of the new table.
+func (ht *HashTable[K, V]) BucketCount() int {
+	if ht.rehashing() {
+		return len(ht.next)
+	}
+	return ht.size
+}
+
+// Resize rehashes the table into the given number of buckets.
+// With incremental rehashing enabled the migration happens over the
+// following operations; otherwise it completes before Resize returns.
+func (ht *HashTable[K, V]) Resize(buckets int) {
+	if buckets <= 0 {
+		return
+	}
+	ht.finishRehash()
+	if buckets == ht.size {
+		return
+	}
+
+	ht.next = make([]*Entry[K, V], buckets)
+	ht.rehashIndex = 0
+	if ht.rehashStep <= 0 {
+		ht.finishRehash()
+	}
+}
+
+// maybeGrow doubles the table once the load factor exceeds the maximum
+func (ht *HashTable[K, V]) maybeGrow() {
+	if ht.rehashing() || ht.maxLoadFactor <= 0 {
+		return
+	}
+	if ht.LoadFactor() > ht.maxLoadFactor {
+		ht.Resize(ht.size * 2)
+	}
+}
+
+// maybeShrink halves the table once the load factor drops below the minimum
+func (ht *HashTable[K, V]) maybeShrink() {
+	if ht.rehashing() || ht.minLoadFactor <= 0 || ht.size/2 < ht.minSize {
+		return
+	}
+	if ht.LoadFactor() < ht.minLoadFactor {
+		ht.Resize(ht.size / 2)
+	}
+}
+
+// rehashSteps migrates the next batch of buckets during an incremental rehash
+func (ht *HashTable[K, V]) rehashSteps() {
+	if !ht.rehashing() {
+		return
+	}
+
+	// Like Redis, bound the number of empty buckets visited per step so
+	// a sparse table does not make a single operation slow
+	moved, emptyVisits := 0, ht.rehashStep*10
+	for moved < ht.rehashStep && ht.rehashIndex < len(ht.buckets) {
+		if ht.buckets[ht.rehashIndex] == nil {
+			ht.rehashIndex++
+			emptyVisits--
+			if emptyVisits == 0 {
+				break
+			}
+			continue
+		}
+		ht.migrateBucket(ht.rehashIndex)
+		ht.rehashIndex++
+		moved++
+	}
+
+	if ht.rehashIndex >= len(ht.buckets) {
+		ht.completeRehash()
+	}
+}
+
+// finishRehash migrates every remaining bucket of an in-progress rehash
+func (ht *HashTable[K, V]) finishRehash() {
+	if !ht.rehashing() {
+		return
+	}
+	for ; ht.rehashIndex < len(ht.buckets); ht.rehashIndex++ {
+		ht.migrateBucket(ht.rehashIndex)
+	}
+	ht.completeRehash()
+}
+
+// migrateBucket moves every entry of an old bucket into the new table
+func (ht *HashTable[K, V]) migrateBucket(index int) {
+	current := ht.buckets[index]
+	for current != nil {
+		next := current.Next
+		current.Next = nil
+		appendEntry(ht.next, ht.index(current.Key, len(ht.next)), current)
+		current = next
+	}
+	ht.buckets[index] = nil
+}
+
+// completeRehash swaps the new table in once migration is done
+func (ht *HashTable[K, V]) completeRehash() {
+	ht.buckets = ht.next
+	ht.size = len(ht.next)
+	ht.next = nil
+	ht.rehashIndex = -1
+}
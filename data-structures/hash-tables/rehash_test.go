@@ -0,0 +1,165 @@
+package hashtables
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHashTableGrows(t *testing.T) {
+	ht := NewHashTable[int, int](4)
+	for i := 0; i < 100; i++ {
+		ht.Put(i, i)
+	}
+	if ht.BucketCount() != 256 {
+		t.Errorf("Expected 256 buckets, got %d", ht.BucketCount())
+	}
+	if ht.LoadFactor() > DefaultMaxLoadFactor {
+		t.Errorf("Expected load factor <= %f, got %f", DefaultMaxLoadFactor, ht.LoadFactor())
+	}
+	for i := 0; i < 100; i++ {
+		if val, found := ht.Get(i); !found || val != i {
+			t.Errorf("Expected (%d, true), got (%d, %v)", i, val, found)
+		}
+	}
+}
+
+func TestHashTableShrinks(t *testing.T) {
+	ht := NewHashTable[int, int](4, WithMinLoadFactor(0.2))
+	for i := 0; i < 100; i++ {
+		ht.Put(i, i)
+	}
+	for i := 0; i < 98; i++ {
+		ht.Delete(i)
+	}
+	if ht.BucketCount() != 8 {
+		t.Errorf("Expected 8 buckets after shrinking, got %d", ht.BucketCount())
+	}
+	for i := 98; i < 100; i++ {
+		if !ht.Contains(i) {
+			t.Errorf("Expected key %d to survive shrinking", i)
+		}
+	}
+
+	// Never shrink below the initial size
+	ht.Delete(98)
+	ht.Delete(99)
+	if ht.BucketCount() < 4 {
+		t.Errorf("Expected at least 4 buckets, got %d", ht.BucketCount())
+	}
+}
+
+func TestHashTableLoadFactorPolicy(t *testing.T) {
+	ht := NewHashTable[int, int](8, WithMaxLoadFactor(2))
+	for i := 0; i < 16; i++ {
+		ht.Put(i, i)
+	}
+	if ht.BucketCount() != 8 {
+		t.Errorf("Expected 8 buckets at load factor 2, got %d", ht.BucketCount())
+	}
+	ht.Put(16, 16)
+	if ht.BucketCount() != 16 {
+		t.Errorf("Expected 16 buckets after exceeding load factor 2, got %d", ht.BucketCount())
+	}
+
+	// A minimum above half the maximum is clamped to avoid thrashing
+	cfg := newConfig([]Option{WithMaxLoadFactor(1), WithMinLoadFactor(0.9)})
+	if cfg.minLoadFactor != 0.25 {
+		t.Errorf("Expected min load factor to be clamped to 0.25, got %f", cfg.minLoadFactor)
+	}
+}
+
+func TestHashTableIncrementalRehash(t *testing.T) {
+	ht := NewHashTable[string, int](8, WithIncrementalRehash(1))
+	for i := 0; i < 6; i++ {
+		ht.Put(fmt.Sprint("key", i), i)
+	}
+	if ht.Rehashing() {
+		t.Fatal("Expected no rehash before exceeding the load factor")
+	}
+
+	// Crossing the load factor starts a migration instead of doing it all at once
+	ht.Put("key6", 6)
+	if !ht.Rehashing() {
+		t.Fatal("Expected an incremental rehash to be in progress")
+	}
+	if ht.BucketCount() != 16 {
+		t.Errorf("Expected target of 16 buckets, got %d", ht.BucketCount())
+	}
+
+	// Every key stays reachable while the migration advances one bucket per Get
+	steps := 0
+	for ht.Rehashing() {
+		key := fmt.Sprint("key", steps%7)
+		if val, found := ht.Get(key); !found || val != steps%7 {
+			t.Fatalf("During rehash expected (%d, true) for %s, got (%d, %v)", steps%7, key, val, found)
+		}
+		for i := 0; i < 7; i++ {
+			if entry := ht.find(fmt.Sprint("key", i)); entry == nil || entry.Value != i {
+				t.Fatalf("During rehash lost key%d", i)
+			}
+		}
+		seen := make(map[string]int)
+		for k, v := range ht.All() {
+			seen[k] = v
+		}
+		if ht.Size() != 7 || len(seen) != 7 {
+			t.Fatalf("During rehash expected 7 entries, got %d", len(seen))
+		}
+		steps++
+	}
+	if steps < 2 {
+		t.Errorf("Expected migration to take several operations, took %d", steps)
+	}
+}
+
+func TestHashTableMutationsDuringRehash(t *testing.T) {
+	ht := NewHashTable[int, int](16, WithIncrementalRehash(1))
+	for i := 0; i < 13; i++ {
+		ht.Put(i, i)
+	}
+	if !ht.Rehashing() {
+		t.Fatal("Expected an incremental rehash to be in progress")
+	}
+
+	// Updates, inserts and deletes must see both tables
+	ht.Put(0, 100)
+	ht.Put(50, 50)
+	if !ht.Delete(12) {
+		t.Error("Expected to delete key 12 during rehash")
+	}
+	if ht.Delete(12) {
+		t.Error("Expected second delete of key 12 to fail")
+	}
+	if val, _ := ht.Get(0); val != 100 {
+		t.Errorf("Expected updated value 100, got %d", val)
+	}
+
+	ht.Resize(ht.BucketCount())
+	if ht.Rehashing() {
+		t.Error("Expected Resize to finish the pending rehash")
+	}
+	if ht.Size() != 13 {
+		t.Errorf("Expected 13 entries, got %d", ht.Size())
+	}
+	for key, value := range ht.All() {
+		if got, found := ht.Get(key); !found || got != value {
+			t.Errorf("Expected (%d, true) for key %d, got (%d, %v)", value, key, got, found)
+		}
+	}
+
+	ht.Clear()
+	if ht.Rehashing() || ht.BucketCount() != 16 {
+		t.Errorf("Expected clear to reset to 16 idle buckets, got %d", ht.BucketCount())
+	}
+}
+
+func BenchmarkHashTablePutGrowing(b *testing.B) {
+	for _, step := range []int{0, 4} {
+		b.Run(fmt.Sprintf("step=%d", step), func(b *testing.B) {
+			ht := NewHashTable[int, int](16, WithIncrementalRehash(step))
+			for i := 0; i < b.N; i++ {
+				ht.Put(i, i)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package hashtables
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+type robinHoodSlot[K comparable, V any] struct {
+	key      K
+	value    V
+	distance int // probe sequence length from the key's home slot
+	occupied bool
+}
+
+// RobinHoodHashTable represents an open-addressing hash table using
+// Robin Hood hashing: on insert, an entry that is further from its home
+// slot takes the place of one that is closer, which keeps probe lengths
+// short and even. Deletion shifts the following entries back instead of
+// leaving tombstones.
+type RobinHoodHashTable[K comparable, V any] struct {
+	slots  []robinHoodSlot[K, V]
+	count  int
+	hasher Hasher[K]
+
+	minSize       int
+	maxLoadFactor float64
+	minLoadFactor float64
+}
+
+// NewRobinHoodHashTable creates a new Robin Hood hash table.
+// The size is rounded up to a power of two. Load factor options are
+// honoured; incremental rehashing is not supported.
+func NewRobinHoodHashTable[K comparable, V any](size int, opts ...Option) *RobinHoodHashTable[K, V] {
+	return NewRobinHoodHashTableWithHasher[K, V](size, nil, opts...)
+}
+
+// NewRobinHoodHashTableWithHasher creates a new Robin Hood hash table that uses the given hasher
+func NewRobinHoodHashTableWithHasher[K comparable, V any](size int, hasher Hasher[K], opts ...Option) *RobinHoodHashTable[K, V] {
+	size = nextPowerOfTwo(size)
+	cfg := newOpenAddressingConfig(opts)
+	if hasher == nil {
+		hasher = newDefaultHasher[K](cfg)
+	}
+	return &RobinHoodHashTable[K, V]{
+		slots:         make([]robinHoodSlot[K, V], size),
+		hasher:        hasher,
+		minSize:       size,
+		maxLoadFactor: cfg.maxLoadFactor,
+		minLoadFactor: cfg.minLoadFactor,
+	}
+}
+
+// home returns the preferred slot of a key
+func (rh *RobinHoodHashTable[K, V]) home(key K) int {
+	return int(mix64(rh.hasher.Hash(key)) & uint64(len(rh.slots)-1))
+}
+
+// lookup returns the slot holding key
+func (rh *RobinHoodHashTable[K, V]) lookup(key K) (int, bool) {
+	mask := len(rh.slots) - 1
+	index := rh.home(key)
+
+	// Once we reach a slot whose entry is closer to home than we are,
+	// the key cannot be further along
+	for distance := 0; ; distance++ {
+		s := &rh.slots[index]
+		if !s.occupied || s.distance < distance {
+			return -1, false
+		}
+		if s.key == key {
+			return index, true
+		}
+		index = (index + 1) & mask
+	}
+}
+
+// Put inserts or updates a key-value pair in the hash table
+func (rh *RobinHoodHashTable[K, V]) Put(key K, value V) {
+	if index, found := rh.lookup(key); found {
+		rh.slots[index].value = value
+		return
+	}
+
+	if float64(rh.count+1) > rh.maxLoadFactor*float64(len(rh.slots)) {
+		rh.resize(len(rh.slots) * 2)
+	}
+	rh.insert(robinHoodSlot[K, V]{key: key, value: value, occupied: true})
+	rh.count++
+}
+
+// insert places a new entry, displacing richer entries along the way
+func (rh *RobinHoodHashTable[K, V]) insert(entry robinHoodSlot[K, V]) {
+	mask := len(rh.slots) - 1
+	index := rh.home(entry.key)
+	entry.distance = 0
+
+	for {
+		s := &rh.slots[index]
+		if !s.occupied {
+			*s = entry
+			return
+		}
+		// Take from the rich (close to home), give to the poor (far from home)
+		if s.distance < entry.distance {
+			*s, entry = entry, *s
+		}
+		index = (index + 1) & mask
+		entry.distance++
+	}
+}
+
+// Get retrieves the value associated with the given key
+func (rh *RobinHoodHashTable[K, V]) Get(key K) (V, bool) {
+	if index, found := rh.lookup(key); found {
+		return rh.slots[index].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the key-value pair with the given key using backward-shift deletion
+func (rh *RobinHoodHashTable[K, V]) Delete(key K) bool {
+	index, found := rh.lookup(key)
+	if !found {
+		return false
+	}
+
+	// Shift the following entries back one slot until we reach an empty
+	// slot or an entry that is already in its home slot
+	mask := len(rh.slots) - 1
+	for {
+		next := (index + 1) & mask
+		if !rh.slots[next].occupied || rh.slots[next].distance == 0 {
+			break
+		}
+		rh.slots[index] = rh.slots[next]
+		rh.slots[index].distance--
+		index = next
+	}
+	rh.slots[index] = robinHoodSlot[K, V]{}
+	rh.count--
+
+	if rh.minLoadFactor > 0 && len(rh.slots)/2 >= rh.minSize && rh.LoadFactor() < rh.minLoadFactor {
+		rh.resize(len(rh.slots) / 2)
+	}
+	return true
+}
+
+// resize rehashes every entry into a table of the given size
+func (rh *RobinHoodHashTable[K, V]) resize(size int) {
+	old := rh.slots
+	rh.slots = make([]robinHoodSlot[K, V], size)
+	for _, s := range old {
+		if s.occupied {
+			rh.insert(s)
+		}
+	}
+}
+
+// Contains checks if the hash table contains the given key
+func (rh *RobinHoodHashTable[K, V]) Contains(key K) bool {
+	_, found := rh.lookup(key)
+	return found
+}
+
+// Size returns the number of key-value pairs in the hash table
+func (rh *RobinHoodHashTable[K, V]) Size() int {
+	return rh.count
+}
+
+// Count returns the number of key-value pairs.
+//
+// Deprecated: Use Size, which every Map in this package provides.
+func (rh *RobinHoodHashTable[K, V]) Count() int {
+	return rh.Size()
+}
+
+// IsEmpty checks if the hash table is empty
+func (rh *RobinHoodHashTable[K, V]) IsEmpty() bool {
+	return rh.count == 0
+}
+
+// MaxProbeLength returns the longest distance any entry sits from its home slot
+func (rh *RobinHoodHashTable[K, V]) MaxProbeLength() int {
+	longest := 0
+	for _, s := range rh.slots {
+		if s.occupied && s.distance > longest {
+			longest = s.distance
+		}
+	}
+	return longest
+}
+
+// All returns an iterator over all key-value pairs in slot order
+func (rh *RobinHoodHashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, s := range rh.slots {
+			if s.occupied && !yield(s.key, s.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys in the hash table
+func (rh *RobinHoodHashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, rh.count)
+	for key := range rh.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values in the hash table
+func (rh *RobinHoodHashTable[K, V]) Values() []V {
+	values := make([]V, 0, rh.count)
+	for _, value := range rh.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all entries from the hash table
+func (rh *RobinHoodHashTable[K, V]) Clear() {
+	rh.slots = make([]robinHoodSlot[K, V], rh.minSize)
+	rh.count = 0
+}
+
+// LoadFactor returns the load factor of the hash table
+func (rh *RobinHoodHashTable[K, V]) LoadFactor() float64 {
+	return float64(rh.count) / float64(len(rh.slots))
+}
+
+// String returns a string representation of the hash table
+func (rh *RobinHoodHashTable[K, V]) String() string {
+	if rh.IsEmpty() {
+		return "RobinHoodHashTable: {}"
+	}
+
+	var result strings.Builder
+	result.WriteString("RobinHoodHashTable: {\n")
+	for i, s := range rh.slots {
+		if s.occupied {
+			result.WriteString(fmt.Sprintf("  [%d]: (%v: %v) +%d\n", i, s.key, s.value, s.distance))
+		}
+	}
+	result.WriteString("}")
+	return result.String()
+}
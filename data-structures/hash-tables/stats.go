@@ -0,0 +1,75 @@
+package hashtables
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Stats describes how evenly a hash table's keys are spread over its buckets
+type Stats struct {
+	Entries      int
+	Buckets      int
+	EmptyBuckets int
+	LoadFactor   float64
+
+	// ChainLengths[i] is the number of buckets holding exactly i entries
+	ChainLengths   []int
+	MaxChainLength int
+
+	// A collision is an entry that lands in an already occupied bucket.
+	// ExpectedCollisions is what a uniformly random hash would produce
+	// for the same number of entries and buckets.
+	ObservedCollisions int
+	ExpectedCollisions float64
+}
+
+// Stats reports bucket occupancy for the table's current hasher.
+// During an incremental rehash keys are counted against the new table.
+func (ht *HashTable[K, V]) Stats() Stats {
+	buckets := ht.BucketCount()
+	occupancy := make([]int, buckets)
+	for key := range ht.All() {
+		occupancy[ht.index(key, buckets)]++
+	}
+	return newStats(occupancy)
+}
+
+// newStats summarizes per-bucket entry counts
+func newStats(occupancy []int) Stats {
+	stats := Stats{Buckets: len(occupancy)}
+	for _, n := range occupancy {
+		stats.Entries += n
+		if n > stats.MaxChainLength {
+			stats.MaxChainLength = n
+		}
+	}
+
+	stats.ChainLengths = make([]int, stats.MaxChainLength+1)
+	for _, n := range occupancy {
+		stats.ChainLengths[n]++
+	}
+	stats.EmptyBuckets = stats.ChainLengths[0]
+	stats.ObservedCollisions = stats.Entries - (stats.Buckets - stats.EmptyBuckets)
+
+	if stats.Buckets > 0 {
+		n, m := float64(stats.Entries), float64(stats.Buckets)
+		stats.LoadFactor = n / m
+		// n balls in m bins leave m(1-1/m)^n bins empty on average
+		stats.ExpectedCollisions = n - m + m*math.Pow(1-1/m, n)
+	}
+	return stats
+}
+
+// String returns a summary of the stats with a histogram of chain lengths
+func (s Stats) String() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("entries=%d buckets=%d load=%.2f empty=%d max chain=%d\n",
+		s.Entries, s.Buckets, s.LoadFactor, s.EmptyBuckets, s.MaxChainLength))
+	result.WriteString(fmt.Sprintf("collisions: observed=%d expected=%.1f\n",
+		s.ObservedCollisions, s.ExpectedCollisions))
+	for length, buckets := range s.ChainLengths {
+		result.WriteString(fmt.Sprintf("  %3d: %d\n", length, buckets))
+	}
+	return result.String()
+}
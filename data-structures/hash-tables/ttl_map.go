@@ -0,0 +1,366 @@
+package hashtables
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSweepInterval is how often the janitor sweeps when no interval is given
+const DefaultSweepInterval = time.Minute
+
+// Clock tells a TTLMap the time and lets its janitor wait. Tests inject a
+// manual clock so expiry is deterministic without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// ttlConfig holds the settings collected from TTLOptions
+type ttlConfig[K comparable, V any] struct {
+	clock         Clock
+	sweepInterval time.Duration
+	onExpire      func(key K, value V)
+	tableOptions  []Option
+}
+
+// TTLOption configures a map created by NewTTLMap
+type TTLOption[K comparable, V any] func(*ttlConfig[K, V])
+
+// WithClock replaces the system clock
+func WithClock[K comparable, V any](clock Clock) TTLOption[K, V] {
+	return func(c *ttlConfig[K, V]) {
+		c.clock = clock
+	}
+}
+
+// WithSweepInterval sets how often the janitor removes expired entries.
+// An interval <= 0 disables the janitor, leaving only lazy expiry and Sweep.
+func WithSweepInterval[K comparable, V any](interval time.Duration) TTLOption[K, V] {
+	return func(c *ttlConfig[K, V]) {
+		c.sweepInterval = interval
+	}
+}
+
+// WithOnExpire registers a callback that runs for every entry removed
+// because it expired. It is not called for Clear or for deletes and
+// overwrites of live entries. The callback runs without the map's lock held, so it may
+// call back into the map.
+func WithOnExpire[K comparable, V any](onExpire func(key K, value V)) TTLOption[K, V] {
+	return func(c *ttlConfig[K, V]) {
+		c.onExpire = onExpire
+	}
+}
+
+// WithTableOptions configures the underlying HashTable
+func WithTableOptions[K comparable, V any](opts ...Option) TTLOption[K, V] {
+	return func(c *ttlConfig[K, V]) {
+		c.tableOptions = append(c.tableOptions, opts...)
+	}
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time // zero means the entry never expires
+}
+
+func (e *ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// TTLMap represents a hash table whose entries expire after a time to live.
+// Expired entries are removed lazily when they are looked up and in bulk by
+// a background janitor goroutine. TTLMap is safe for concurrent use.
+type TTLMap[K comparable, V any] struct {
+	mu         sync.Mutex
+	table      *HashTable[K, *ttlEntry[V]]
+	defaultTTL time.Duration
+	cfg        ttlConfig[K, V]
+	cancel     context.CancelFunc
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewTTLMap creates a new TTL map in which Put stores entries for
+// defaultTTL; a defaultTTL <= 0 means entries never expire by default.
+// The janitor runs until ctx is cancelled or Close is called.
+func NewTTLMap[K comparable, V any](ctx context.Context, defaultTTL time.Duration, opts ...TTLOption[K, V]) *TTLMap[K, V] {
+	cfg := ttlConfig[K, V]{
+		clock:         systemClock{},
+		sweepInterval: DefaultSweepInterval,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	m := &TTLMap[K, V]{
+		table:      NewHashTable[K, *ttlEntry[V]](16, cfg.tableOptions...),
+		defaultTTL: defaultTTL,
+		cfg:        cfg,
+		done:       make(chan struct{}),
+	}
+
+	ctx, m.cancel = context.WithCancel(ctx)
+	if cfg.sweepInterval > 0 {
+		go m.janitor(ctx)
+	} else {
+		close(m.done)
+	}
+	return m
+}
+
+// janitor sweeps expired entries every interval until ctx is done
+func (m *TTLMap[K, V]) janitor(ctx context.Context) {
+	defer close(m.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.cfg.clock.After(m.cfg.sweepInterval):
+			m.Sweep()
+		}
+	}
+}
+
+// Close stops the janitor and waits for it to exit. The map stays usable
+// with lazy expiry. Close is safe to call more than once.
+func (m *TTLMap[K, V]) Close() error {
+	m.closeOnce.Do(m.cancel)
+	<-m.done
+	return nil
+}
+
+// Put inserts or updates a key-value pair that expires after the default TTL
+func (m *TTLMap[K, V]) Put(key K, value V) {
+	m.PutWithTTL(key, value, m.defaultTTL)
+}
+
+// PutWithTTL inserts or updates a key-value pair that expires after ttl.
+// A ttl <= 0 stores the entry without expiry.
+func (m *TTLMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	now := m.cfg.clock.Now()
+	old, found := m.table.Get(key)
+	entry := &ttlEntry[V]{value: value}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+	m.table.Put(key, entry)
+	m.mu.Unlock()
+
+	// Overwriting an entry that had already expired still reports its expiry
+	if found && old.expired(now) {
+		m.notify(key, old.value)
+	}
+}
+
+// Get retrieves the value for key if it has not expired. An expired entry
+// is removed on the spot.
+func (m *TTLMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	entry, found := m.table.Get(key)
+	if found && entry.expired(m.cfg.clock.Now()) {
+		m.table.Delete(key)
+		m.mu.Unlock()
+		m.notify(key, entry.value)
+		found = false
+	} else {
+		m.mu.Unlock()
+	}
+
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// TTL returns how long key has left to live. It reports false if key is
+// missing or expired, and a zero duration for entries without expiry.
+func (m *TTLMap[K, V]) TTL(key K) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, found := m.table.Get(key)
+	now := m.cfg.clock.Now()
+	if !found || entry.expired(now) {
+		return 0, false
+	}
+	if entry.expires.IsZero() {
+		return 0, true
+	}
+	return entry.expires.Sub(now), true
+}
+
+// Expire resets the time to live of an existing entry. A ttl <= 0 removes
+// its expiry. It reports false if key is missing or already expired.
+func (m *TTLMap[K, V]) Expire(key K, ttl time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, found := m.table.Get(key)
+	now := m.cfg.clock.Now()
+	if !found || entry.expired(now) {
+		return false
+	}
+	entry.expires = time.Time{}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+	return true
+}
+
+// Delete removes key and reports whether it was live. An entry that had
+// already expired is reported as missing and, as in Get, its expiry is
+// passed to the callback.
+func (m *TTLMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	entry, found := m.table.Get(key)
+	if !found {
+		m.mu.Unlock()
+		return false
+	}
+	m.table.Delete(key)
+	expired := entry.expired(m.cfg.clock.Now())
+	m.mu.Unlock()
+
+	if expired {
+		m.notify(key, entry.value)
+		return false
+	}
+	return true
+}
+
+// Contains checks if key is present and has not expired
+func (m *TTLMap[K, V]) Contains(key K) bool {
+	_, found := m.TTL(key)
+	return found
+}
+
+// Sweep removes every expired entry, calls the expiry callback for each
+// and returns how many were removed
+func (m *TTLMap[K, V]) Sweep() int {
+	m.mu.Lock()
+	now := m.cfg.clock.Now()
+	var expired []Entry[K, V]
+	for key, entry := range m.table.All() {
+		if entry.expired(now) {
+			expired = append(expired, Entry[K, V]{Key: key, Value: entry.value})
+		}
+	}
+	// Deleting while iterating would skip buckets, so delete afterwards
+	for _, e := range expired {
+		m.table.Delete(e.Key)
+	}
+	m.mu.Unlock()
+
+	for _, e := range expired {
+		m.notify(e.Key, e.Value)
+	}
+	return len(expired)
+}
+
+// notify runs the expiry callback, if any
+func (m *TTLMap[K, V]) notify(key K, value V) {
+	if m.cfg.onExpire != nil {
+		m.cfg.onExpire(key, value)
+	}
+}
+
+// Size returns the number of live entries, so it always matches
+// len(Keys()). Expired entries that have not been swept yet are skipped,
+// which makes Size O(n).
+func (m *TTLMap[K, V]) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.cfg.clock.Now()
+	size := 0
+	for _, entry := range m.table.All() {
+		if !entry.expired(now) {
+			size++
+		}
+	}
+	return size
+}
+
+// IsEmpty checks if the map holds no live entries
+func (m *TTLMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// snapshot returns the live entries at the current time
+func (m *TTLMap[K, V]) snapshot() []Entry[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.cfg.clock.Now()
+	entries := make([]Entry[K, V], 0, m.table.Size())
+	for key, entry := range m.table.All() {
+		if !entry.expired(now) {
+			entries = append(entries, Entry[K, V]{Key: key, Value: entry.value})
+		}
+	}
+	return entries
+}
+
+// All returns an iterator over a snapshot of the live entries. The loop
+// body may safely modify the map.
+func (m *TTLMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, entry := range m.snapshot() {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys of the live entries
+func (m *TTLMap[K, V]) Keys() []K {
+	snapshot := m.snapshot()
+	keys := make([]K, len(snapshot))
+	for i, entry := range snapshot {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Values returns the values of the live entries
+func (m *TTLMap[K, V]) Values() []V {
+	snapshot := m.snapshot()
+	values := make([]V, len(snapshot))
+	for i, entry := range snapshot {
+		values[i] = entry.Value
+	}
+	return values
+}
+
+// Clear removes all entries without calling the expiry callback
+func (m *TTLMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.table.Clear()
+}
+
+// String returns a string representation of the live entries
+func (m *TTLMap[K, V]) String() string {
+	var result strings.Builder
+	result.WriteString("TTLMap: {")
+	for i, entry := range m.snapshot() {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%v: %v", entry.Key, entry.Value))
+	}
+	result.WriteString("}")
+	return result.String()
+}
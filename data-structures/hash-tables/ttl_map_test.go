@@ -0,0 +1,236 @@
+package hashtables
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock that only moves when advanced
+type manualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+	waiting chan struct{} // signalled whenever After registers a waiter
+}
+
+type manualWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{
+		now:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		waiting: make(chan struct{}, 16),
+	}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, manualWaiter{deadline: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+	return ch
+}
+
+// Advance moves the clock forward and fires every waiter that is due
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if c.now.Before(w.deadline) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = pending
+}
+
+func TestTTLMapLazyExpiry(t *testing.T) {
+	clock := newManualClock()
+	var expired []string
+	m := NewTTLMap[string, int](context.Background(), time.Minute,
+		WithClock[string, int](clock),
+		WithSweepInterval[string, int](0),
+		WithOnExpire(func(key string, _ int) { expired = append(expired, key) }))
+	defer m.Close()
+
+	m.Put("a", 1)
+	m.PutWithTTL("b", 2, 2*time.Minute)
+	m.PutWithTTL("forever", 3, 0)
+
+	clock.Advance(30 * time.Second)
+	if ttl, ok := m.TTL("a"); !ok || ttl != 30*time.Second {
+		t.Errorf("Expected 30s left for a, got (%v, %v)", ttl, ok)
+	}
+	if ttl, ok := m.TTL("forever"); !ok || ttl != 0 {
+		t.Errorf("Expected no expiry for forever, got (%v, %v)", ttl, ok)
+	}
+
+	clock.Advance(30 * time.Second)
+	if _, found := m.Get("a"); found {
+		t.Error("Expected a to expire exactly at its deadline")
+	}
+	if val, found := m.Get("b"); !found || val != 2 {
+		t.Errorf("Expected (2, true) for b, got (%d, %v)", val, found)
+	}
+	if len(expired) != 1 || expired[0] != "a" || m.Size() != 2 {
+		t.Errorf("Expected only a to expire, got %v with size %d", expired, m.Size())
+	}
+
+	// Expire extends a live entry but cannot revive an expired one
+	if !m.Expire("b", 5*time.Minute) {
+		t.Error("Expected to extend b")
+	}
+	clock.Advance(2 * time.Minute)
+	if !m.Contains("b") || m.Expire("a", time.Minute) {
+		t.Error("Expected b to live and a to stay expired")
+	}
+}
+
+func TestTTLMapDeleteExpired(t *testing.T) {
+	clock := newManualClock()
+	var expired []string
+	m := NewTTLMap[string, int](context.Background(), time.Minute,
+		WithClock[string, int](clock),
+		WithSweepInterval[string, int](0),
+		WithOnExpire(func(key string, _ int) { expired = append(expired, key) }))
+	defer m.Close()
+
+	m.Put("stale", 1)
+	m.PutWithTTL("live", 2, time.Hour)
+	clock.Advance(time.Minute)
+
+	// An expired entry that has not been swept is not there to delete
+	if m.Delete("stale") {
+		t.Error("Expected deleting an expired entry to report false")
+	}
+	if len(expired) != 1 || expired[0] != "stale" {
+		t.Errorf("Expected stale to be reported as expired, got %v", expired)
+	}
+	if !m.Delete("live") || m.Delete("live") {
+		t.Error("Expected live to be deleted exactly once")
+	}
+	if len(expired) != 1 || m.Sweep() != 0 {
+		t.Errorf("Expected no further expiries, got %v", expired)
+	}
+}
+
+func TestTTLMapSweep(t *testing.T) {
+	clock := newManualClock()
+	expired := map[int]int{}
+	m := NewTTLMap[int, int](context.Background(), time.Second,
+		WithClock[int, int](clock),
+		WithSweepInterval[int, int](0),
+		WithOnExpire(func(key, value int) { expired[key] = value }))
+	defer m.Close()
+
+	for i := 0; i < 100; i++ {
+		m.PutWithTTL(i, i*i, time.Duration(i%2+1)*time.Second)
+	}
+	clock.Advance(time.Second)
+
+	// Expired entries are hidden from Size and views before they are swept
+	if m.Size() != 50 || len(m.Keys()) != 50 || len(m.Values()) != 50 {
+		t.Errorf("Expected 50 live entries, got size %d and %d keys", m.Size(), len(m.Keys()))
+	}
+	if n := m.Sweep(); n != 50 || len(expired) != 50 || m.Size() != 50 {
+		t.Errorf("Expected to sweep 50, swept %d with %d callbacks", n, len(expired))
+	}
+	if expired[4] != 16 {
+		t.Errorf("Expected callback for key 4 with value 16, got %d", expired[4])
+	}
+
+	// Overwriting an expired entry reports it; overwriting a live one does not
+	clock.Advance(time.Second)
+	m.Put(1, -1)
+	m.Put(1, -2)
+	if expired[1] != 1 || len(expired) != 51 {
+		t.Errorf("Expected only the expired overwrite to be reported, got %d callbacks", len(expired))
+	}
+
+	keys := m.Keys()
+	sort.Ints(keys)
+	if len(keys) != 1 || keys[0] != 1 || m.String() != "TTLMap: {1: -2}" {
+		t.Errorf("Expected only key 1 to remain, got %s", m)
+	}
+}
+
+func TestTTLMapJanitor(t *testing.T) {
+	clock := newManualClock()
+	expired := make(chan string, 10)
+	m := NewTTLMap[string, string](context.Background(), time.Minute,
+		WithClock[string, string](clock),
+		WithSweepInterval[string, string](time.Minute),
+		WithOnExpire(func(key, _ string) { expired <- key }))
+
+	m.Put("session", "token")
+	<-clock.waiting // the janitor is waiting for its first tick
+	clock.Advance(time.Minute)
+
+	select {
+	case key := <-expired:
+		if key != "session" {
+			t.Errorf("Expected session to expire, got %s", key)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the janitor to sweep the expired entry")
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal("Expected Close to be idempotent")
+	}
+}
+
+func TestTTLMapJanitorStopsWithContext(t *testing.T) {
+	clock := newManualClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	m := NewTTLMap[int, int](ctx, time.Minute, WithClock[int, int](clock))
+
+	<-clock.waiting
+	cancel()
+	select {
+	case <-m.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the janitor to stop when the context is cancelled")
+	}
+	m.Close()
+}
+
+func TestTTLMapConcurrent(t *testing.T) {
+	m := NewTTLMap[int, int](context.Background(), time.Millisecond,
+		WithSweepInterval[int, int](time.Millisecond))
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Put(g*1000+i, i)
+				m.Get(g*1000 + i/2)
+				if i%10 == 0 {
+					m.Delete(g*1000 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+package heaps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// DaryHeap represents a heap in which every node has up to d children.
+// A wider heap is shallower, so pushes and priority decreases are cheaper
+// while pops compare more children per level, and the flatter array is
+// friendlier to the cache.
+type DaryHeap[T any] struct {
+	items   []T
+	d       int
+	compare func(a, b T) int
+}
+
+// NewDaryHeap creates a new empty d-ary min-heap. A d below 2 is raised
+// to 2.
+func NewDaryHeap[T cmp.Ordered](d int) *DaryHeap[T] {
+	return NewDaryHeapFunc(d, cmp.Compare[T])
+}
+
+// NewDaryHeapFunc creates a new empty d-ary heap ordered by compare,
+// which returns a negative number when a should be popped before b
+func NewDaryHeapFunc[T any](d int, compare func(a, b T) int) *DaryHeap[T] {
+	return &DaryHeap[T]{d: max(d, 2), compare: compare}
+}
+
+// NewDaryHeapFrom creates a d-ary heap ordered by compare holding a copy
+// of values, heapified in O(n)
+func NewDaryHeapFrom[T any](d int, values []T, compare func(a, b T) int) *DaryHeap[T] {
+	h := NewDaryHeapFunc(d, compare)
+	h.items = slices.Clone(values)
+	heapify(h.items, h.d, h.compare)
+	return h
+}
+
+// Arity returns the number of children per node
+func (h *DaryHeap[T]) Arity() int {
+	return h.d
+}
+
+// Push adds a value in O(log_d n)
+func (h *DaryHeap[T]) Push(value T) {
+	h.items = append(h.items, value)
+	siftUp(h.items, len(h.items)-1, h.d, h.compare)
+}
+
+// Pop removes and returns the top value in O(d log_d n)
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	return pop(&h.items, h.d, h.compare)
+}
+
+// Peek returns the top value without removing it
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// PushPop pushes value and then pops the top value with a single sift
+func (h *DaryHeap[T]) PushPop(value T) T {
+	return pushPop(h.items, value, h.d, h.compare)
+}
+
+// Merge moves every value of other into h in O(n + m), leaving other
+// empty. The heaps may have different arities. Merging a heap into itself
+// changes nothing.
+func (h *DaryHeap[T]) Merge(other *DaryHeap[T]) {
+	if other == h {
+		return
+	}
+	h.items = append(h.items, other.items...)
+	heapify(h.items, h.d, h.compare)
+	other.Clear()
+}
+
+// Len returns the number of values
+func (h *DaryHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty checks if the heap is empty
+func (h *DaryHeap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all values
+func (h *DaryHeap[T]) Clear() {
+	h.items = nil
+}
+
+// Values returns a copy of the values in heap order
+func (h *DaryHeap[T]) Values() []T {
+	return slices.Clone(h.items)
+}
+
+// Validate checks that no value compares smaller than its parent
+func (h *DaryHeap[T]) Validate() error {
+	return validate(h.items, h.d, h.compare)
+}
+
+// String returns the arity and the values in heap order
+func (h *DaryHeap[T]) String() string {
+	return fmt.Sprintf("DaryHeap(d=%d): %v", h.d, h.items)
+}
@@ -0,0 +1,115 @@
+package heaps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Heap represents a binary heap ordered by a comparator. The value that
+// compares smallest is always on top, so a min-heap uses the natural
+// order and a max-heap uses the reverse.
+type Heap[T any] struct {
+	items   []T
+	compare func(a, b T) int
+}
+
+// NewMinHeap creates a new empty heap that pops the smallest value first
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeapFunc(cmp.Compare[T])
+}
+
+// NewMaxHeap creates a new empty heap that pops the largest value first
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeapFunc(reverse(cmp.Compare[T]))
+}
+
+// NewHeapFunc creates a new empty heap ordered by compare, which returns
+// a negative number when a should be popped before b
+func NewHeapFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{compare: compare}
+}
+
+// NewHeapFrom creates a heap ordered by compare holding a copy of values.
+// It heapifies bottom-up in O(n), which beats n pushes.
+func NewHeapFrom[T any](values []T, compare func(a, b T) int) *Heap[T] {
+	h := &Heap[T]{items: slices.Clone(values), compare: compare}
+	heapify(h.items, 2, h.compare)
+	return h
+}
+
+// Push adds a value in O(log n)
+func (h *Heap[T]) Push(value T) {
+	h.items = append(h.items, value)
+	siftUp(h.items, len(h.items)-1, 2, h.compare)
+}
+
+// Pop removes and returns the top value in O(log n)
+func (h *Heap[T]) Pop() (T, bool) {
+	return pop(&h.items, 2, h.compare)
+}
+
+// Peek returns the top value without removing it
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// PushPop pushes value and then pops the top value with a single sift.
+// If value would be on top it is returned straight away.
+func (h *Heap[T]) PushPop(value T) T {
+	return pushPop(h.items, value, 2, h.compare)
+}
+
+// Merge moves every value of other into h, leaving other empty. The
+// values are appended and the whole heap is heapified again in O(n + m).
+// Merging a heap into itself changes nothing.
+func (h *Heap[T]) Merge(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.items = append(h.items, other.items...)
+	heapify(h.items, 2, h.compare)
+	other.Clear()
+}
+
+// Len returns the number of values
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty checks if the heap is empty
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all values
+func (h *Heap[T]) Clear() {
+	h.items = nil
+}
+
+// Values returns a copy of the values in heap order
+func (h *Heap[T]) Values() []T {
+	return slices.Clone(h.items)
+}
+
+// Validate checks that no value compares smaller than its parent. It
+// returns the first violation found, or nil.
+func (h *Heap[T]) Validate() error {
+	return validate(h.items, 2, h.compare)
+}
+
+// String returns the values in heap order
+func (h *Heap[T]) String() string {
+	return fmt.Sprintf("Heap: %v", h.items)
+}
+
+// reverse returns a comparator for the opposite order
+func reverse[T any](compare func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return compare(b, a)
+	}
+}
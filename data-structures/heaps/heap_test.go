@@ -0,0 +1,201 @@
+package heaps
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// intHeap is the API shared by Heap and DaryHeap
+type intHeap interface {
+	Push(value int)
+	Pop() (int, bool)
+	Peek() (int, bool)
+	PushPop(value int) int
+	Len() int
+	Values() []int
+	Validate() error
+}
+
+var heapVariants = []struct {
+	name string
+	new  func(values []int) intHeap
+}{
+	{"binary", func(values []int) intHeap { return NewHeapFrom(values, cmp.Compare[int]) }},
+	{"3-ary", func(values []int) intHeap { return NewDaryHeapFrom(3, values, cmp.Compare[int]) }},
+	{"4-ary", func(values []int) intHeap { return NewDaryHeapFrom(4, values, cmp.Compare[int]) }},
+	{"8-ary", func(values []int) intHeap { return NewDaryHeapFrom(8, values, cmp.Compare[int]) }},
+}
+
+func TestHeap(t *testing.T) {
+	h := NewMinHeap[int]()
+	if _, ok := h.Pop(); ok || !h.IsEmpty() {
+		t.Error("Expected Pop on an empty heap to fail")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("Expected Peek on an empty heap to fail")
+	}
+	if h.PushPop(5) != 5 || !h.IsEmpty() {
+		t.Error("Expected PushPop on an empty heap to return its value")
+	}
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(v)
+	}
+	if top, _ := h.Peek(); top != 1 || h.Len() != 6 {
+		t.Errorf("Expected 1 on top of 6 values, got %d of %d", top, h.Len())
+	}
+	if h.String() != "Heap: [1 3 2 5 9 8]" {
+		t.Errorf("Unexpected string %s", h)
+	}
+
+	// 0 beats the top so it comes straight back; 4 replaces the top
+	if h.PushPop(0) != 0 || h.PushPop(4) != 1 {
+		t.Error("Unexpected PushPop results")
+	}
+
+	maxHeap := NewMaxHeap[int]()
+	for _, v := range []int{5, 3, 8} {
+		maxHeap.Push(v)
+	}
+	if top, _ := maxHeap.Pop(); top != 8 {
+		t.Errorf("Expected max heap to pop 8, got %d", top)
+	}
+
+	// Any type works with a comparator
+	type task struct {
+		name     string
+		priority int
+	}
+	tasks := NewHeapFunc(func(a, b task) int { return cmp.Compare(b.priority, a.priority) })
+	tasks.Push(task{"low", 1})
+	tasks.Push(task{"high", 9})
+	if top, _ := tasks.Pop(); top.name != "high" {
+		t.Errorf("Expected high first, got %s", top.name)
+	}
+
+	h.Clear()
+	if !h.IsEmpty() || h.Len() != 0 {
+		t.Error("Expected heap to be empty after clear")
+	}
+}
+
+func TestHeapFromCopiesAndHeapifies(t *testing.T) {
+	values := []int{9, 8, 7, 6, 5, 4, 3, 2, 1}
+	h := NewHeapFrom(values, cmp.Compare[int])
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if values[0] != 9 {
+		t.Error("Expected the input slice to be left alone")
+	}
+	var popped []int
+	for !h.IsEmpty() {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	if !slices.IsSorted(popped) || len(popped) != 9 {
+		t.Errorf("Expected sorted output, got %v", popped)
+	}
+}
+
+func TestHeapMerge(t *testing.T) {
+	a := NewHeapFrom([]int{1, 4, 7}, cmp.Compare[int])
+	b := NewHeapFrom([]int{0, 5, 2}, cmp.Compare[int])
+	a.Merge(b)
+	if a.Len() != 6 || !b.IsEmpty() || a.Validate() != nil {
+		t.Errorf("Expected 6 merged values and an empty source, got %v and %v", a, b)
+	}
+	if top, _ := a.Peek(); top != 0 {
+		t.Errorf("Expected 0 on top, got %d", top)
+	}
+
+	d := NewDaryHeapFrom(4, []int{3, 1}, cmp.Compare[int])
+	d.Merge(NewDaryHeapFrom(2, []int{2, 0}, cmp.Compare[int]))
+	if d.Len() != 4 || d.Validate() != nil || d.String() != "DaryHeap(d=4): [0 3 1 2]" {
+		t.Errorf("Unexpected merged d-ary heap %s", d)
+	}
+	// Merging a heap into itself keeps its values
+	a.Merge(a)
+	d.Merge(d)
+	if a.Len() != 6 || d.Len() != 4 {
+		t.Errorf("Expected self-merges to keep 6 and 4 values, got %d and %d", a.Len(), d.Len())
+	}
+
+	if NewDaryHeap[int](1).Arity() != 2 {
+		t.Error("Expected arity to be raised to 2")
+	}
+}
+
+func TestHeapsAgainstSortedSlice(t *testing.T) {
+	for _, variant := range heapVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			initial := make([]int, 100)
+			for i := range initial {
+				initial[i] = rng.Intn(1000)
+			}
+			h := variant.new(initial)
+			reference := slices.Clone(initial)
+			slices.Sort(reference)
+
+			for step := 0; step < 5000; step++ {
+				v := rng.Intn(1000)
+				switch rng.Intn(3) {
+				case 0:
+					got, ok := h.Pop()
+					if ok != (len(reference) > 0) || (ok && got != reference[0]) {
+						t.Fatalf("step %d: Pop() = %d, %v", step, got, ok)
+					}
+					if ok {
+						reference = reference[1:]
+					}
+				case 1:
+					want := v
+					if len(reference) > 0 && reference[0] < v {
+						want = reference[0]
+						reference[0] = v
+						slices.Sort(reference)
+					}
+					if got := h.PushPop(v); got != want {
+						t.Fatalf("step %d: PushPop(%d) = %d, want %d", step, v, got, want)
+					}
+				default:
+					h.Push(v)
+					i, _ := slices.BinarySearch(reference, v)
+					reference = slices.Insert(reference, i, v)
+				}
+				if err := h.Validate(); err != nil {
+					t.Fatalf("step %d: %v", step, err)
+				}
+			}
+
+			if h.Len() != len(reference) {
+				t.Errorf("Expected %d values, got %d", len(reference), h.Len())
+			}
+			values := h.Values()
+			slices.Sort(values)
+			if !slices.Equal(values, reference) {
+				t.Error("Expected Values to match the reference")
+			}
+		})
+	}
+}
+
+func BenchmarkHeapPushPop(b *testing.B) {
+	for _, variant := range heapVariants {
+		b.Run(variant.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < b.N; i++ {
+				h := variant.new(nil)
+				for j := 0; j < 1000; j++ {
+					h.Push(rng.Intn(1000))
+				}
+				for j := 0; j < 1000; j++ {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package heaps
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidHandle is returned for a handle that was popped, removed
+	// or belongs to another queue
+	ErrInvalidHandle = errors.New("handle is not in the queue")
+	// ErrPriorityDirection is returned when DecreaseKey would raise a
+	// priority or IncreaseKey would lower one
+	ErrPriorityDirection = errors.New("priority moves the wrong way")
+)
+
+// Handle refers to a value in an IndexedPriorityQueue so its priority can
+// be changed or it can be removed later. It stays valid until the value
+// leaves the queue.
+type Handle[T any, P any] struct {
+	Value    T
+	priority P
+	index    int // position in the heap, -1 once the value leaves
+	queue    *IndexedPriorityQueue[T, P]
+}
+
+// Priority returns the priority of the value
+func (h *Handle[T, P]) Priority() P {
+	return h.priority
+}
+
+// IndexedPriorityQueue represents a binary heap of values keyed by a
+// priority, smallest first. Each handle tracks its position in the heap,
+// so a priority can be changed in O(log n), as Dijkstra's and Prim's
+// algorithms need.
+type IndexedPriorityQueue[T any, P any] struct {
+	items   []*Handle[T, P]
+	compare func(a, b P) int
+}
+
+// NewIndexedPriorityQueue creates a new empty queue that pops the
+// smallest priority first
+func NewIndexedPriorityQueue[T any, P cmp.Ordered]() *IndexedPriorityQueue[T, P] {
+	return NewIndexedPriorityQueueFunc[T](cmp.Compare[P])
+}
+
+// NewIndexedPriorityQueueFunc creates a new empty queue ordered by
+// compare, which returns a negative number when priority a should be
+// popped before b
+func NewIndexedPriorityQueueFunc[T any, P any](compare func(a, b P) int) *IndexedPriorityQueue[T, P] {
+	return &IndexedPriorityQueue[T, P]{compare: compare}
+}
+
+func (q *IndexedPriorityQueue[T, P]) less(i, j int) bool {
+	return q.compare(q.items[i].priority, q.items[j].priority) < 0
+}
+
+func (q *IndexedPriorityQueue[T, P]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *IndexedPriorityQueue[T, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			return
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+func (q *IndexedPriorityQueue[T, P]) down(i int) {
+	for {
+		smallest := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(q.items) && q.less(child, smallest) {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		q.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// Push adds value with the given priority and returns its handle
+func (q *IndexedPriorityQueue[T, P]) Push(value T, priority P) *Handle[T, P] {
+	h := &Handle[T, P]{Value: value, priority: priority, index: len(q.items), queue: q}
+	q.items = append(q.items, h)
+	q.up(h.index)
+	return h
+}
+
+// Peek returns the handle with the smallest priority without removing it
+func (q *IndexedPriorityQueue[T, P]) Peek() (*Handle[T, P], bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	return q.items[0], true
+}
+
+// Pop removes the value with the smallest priority and returns its
+// handle, which is no longer valid
+func (q *IndexedPriorityQueue[T, P]) Pop() (*Handle[T, P], bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	h := q.items[0]
+	q.removeAt(0)
+	return h, true
+}
+
+func (q *IndexedPriorityQueue[T, P]) removeAt(i int) {
+	h := q.items[i]
+	last := len(q.items) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+	q.items[last] = nil
+	q.items = q.items[:last]
+	if i != last {
+		// The moved value may belong above or below its new position
+		q.down(i)
+		q.up(i)
+	}
+	h.index = -1
+	h.queue = nil
+}
+
+// Contains checks if h is still in the queue
+func (q *IndexedPriorityQueue[T, P]) Contains(h *Handle[T, P]) bool {
+	return h != nil && h.queue == q && h.index >= 0
+}
+
+func (q *IndexedPriorityQueue[T, P]) checkHandle(h *Handle[T, P]) error {
+	if !q.Contains(h) {
+		return ErrInvalidHandle
+	}
+	return nil
+}
+
+// DecreaseKey moves h to an earlier priority. A priority that would be
+// popped later returns ErrPriorityDirection and leaves h unchanged.
+func (q *IndexedPriorityQueue[T, P]) DecreaseKey(h *Handle[T, P], priority P) error {
+	if err := q.checkHandle(h); err != nil {
+		return err
+	}
+	if q.compare(priority, h.priority) > 0 {
+		return fmt.Errorf("%w: DecreaseKey from %v to %v", ErrPriorityDirection, h.priority, priority)
+	}
+	h.priority = priority
+	q.up(h.index)
+	return nil
+}
+
+// IncreaseKey moves h to a later priority. A priority that would be
+// popped sooner returns ErrPriorityDirection and leaves h unchanged.
+func (q *IndexedPriorityQueue[T, P]) IncreaseKey(h *Handle[T, P], priority P) error {
+	if err := q.checkHandle(h); err != nil {
+		return err
+	}
+	if q.compare(priority, h.priority) < 0 {
+		return fmt.Errorf("%w: IncreaseKey from %v to %v", ErrPriorityDirection, h.priority, priority)
+	}
+	h.priority = priority
+	q.down(h.index)
+	return nil
+}
+
+// Update sets the priority of h in either direction
+func (q *IndexedPriorityQueue[T, P]) Update(h *Handle[T, P], priority P) error {
+	if err := q.checkHandle(h); err != nil {
+		return err
+	}
+	h.priority = priority
+	q.down(h.index)
+	q.up(h.index)
+	return nil
+}
+
+// Remove takes h out of the queue wherever it is
+func (q *IndexedPriorityQueue[T, P]) Remove(h *Handle[T, P]) error {
+	if err := q.checkHandle(h); err != nil {
+		return err
+	}
+	q.removeAt(h.index)
+	return nil
+}
+
+// Len returns the number of values
+func (q *IndexedPriorityQueue[T, P]) Len() int {
+	return len(q.items)
+}
+
+// IsEmpty checks if the queue is empty
+func (q *IndexedPriorityQueue[T, P]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+// Clear removes all values and invalidates their handles
+func (q *IndexedPriorityQueue[T, P]) Clear() {
+	for _, h := range q.items {
+		h.index = -1
+		h.queue = nil
+	}
+	q.items = nil
+}
+
+// Validate checks the heap order and that every handle knows its
+// position. It returns the first violation found, or nil.
+func (q *IndexedPriorityQueue[T, P]) Validate() error {
+	for i, h := range q.items {
+		if h.index != i || h.queue != q {
+			return fmt.Errorf("handle for %v at %d records index %d", h.Value, i, h.index)
+		}
+		if parent := (i - 1) / 2; i > 0 && q.less(i, parent) {
+			return fmt.Errorf("priority %v at %d is smaller than its parent %v", h.priority, i, q.items[parent].priority)
+		}
+	}
+	return nil
+}
+
+// String returns the value: priority pairs in heap order
+func (q *IndexedPriorityQueue[T, P]) String() string {
+	var result strings.Builder
+	result.WriteString("IndexedPriorityQueue: [")
+	for i, h := range q.items {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%v: %v", h.Value, h.priority))
+	}
+	result.WriteString("]")
+	return result.String()
+}
@@ -0,0 +1,172 @@
+package heaps
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	q := NewIndexedPriorityQueue[string, int]()
+	if _, ok := q.Pop(); ok || !q.IsEmpty() {
+		t.Error("Expected Pop on an empty queue to fail")
+	}
+
+	a := q.Push("a", 5)
+	b := q.Push("b", 3)
+	c := q.Push("c", 8)
+	if top, _ := q.Peek(); top != b || q.Len() != 3 {
+		t.Errorf("Expected b on top of 3 values, got %s", q)
+	}
+
+	if err := q.DecreaseKey(c, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.IncreaseKey(b, 9); err != nil {
+		t.Fatal(err)
+	}
+	if q.String() != "IndexedPriorityQueue: [c: 1, a: 5, b: 9]" {
+		t.Errorf("Unexpected string %s", q)
+	}
+
+	// Keys only move the named way
+	if err := q.DecreaseKey(a, 6); !errors.Is(err, ErrPriorityDirection) || a.Priority() != 5 {
+		t.Errorf("Expected ErrPriorityDirection, got %v", err)
+	}
+	if err := q.IncreaseKey(a, 4); !errors.Is(err, ErrPriorityDirection) {
+		t.Errorf("Expected ErrPriorityDirection, got %v", err)
+	}
+	if err := q.Update(a, 0); err != nil || a.Priority() != 0 {
+		t.Errorf("Expected Update to move a to 0, got %v", err)
+	}
+
+	if h, _ := q.Pop(); h != a || q.Contains(a) {
+		t.Errorf("Expected a to be popped, got %v", h.Value)
+	}
+	if err := q.DecreaseKey(a, -1); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Expected ErrInvalidHandle for a popped handle, got %v", err)
+	}
+	other := NewIndexedPriorityQueue[string, int]()
+	if err := other.Remove(b); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Expected ErrInvalidHandle for a foreign handle, got %v", err)
+	}
+
+	if err := q.Remove(c); err != nil || q.Len() != 1 || q.Validate() != nil {
+		t.Errorf("Expected only b left, got %s", q)
+	}
+	q.Clear()
+	if !q.IsEmpty() || q.Contains(b) {
+		t.Error("Expected queue to be empty after clear")
+	}
+}
+
+func TestIndexedPriorityQueueAgainstReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewIndexedPriorityQueue[int, int]()
+	var handles []*Handle[int, int]
+
+	for step := 0; step < 5000; step++ {
+		switch op := rng.Intn(5); {
+		case op == 0 || len(handles) == 0:
+			handles = append(handles, q.Push(step, rng.Intn(1000)))
+		case op == 1:
+			// The popped priority is the smallest of all live handles
+			smallest := math.MaxInt
+			for _, h := range handles {
+				smallest = min(smallest, h.Priority())
+			}
+			h, _ := q.Pop()
+			if h.Priority() != smallest {
+				t.Fatalf("step %d: popped %d, want %d", step, h.Priority(), smallest)
+			}
+			for i := range handles {
+				if handles[i] == h {
+					handles = append(handles[:i], handles[i+1:]...)
+					break
+				}
+			}
+		case op == 2:
+			i := rng.Intn(len(handles))
+			if err := q.Remove(handles[i]); err != nil {
+				t.Fatal(err)
+			}
+			handles = append(handles[:i], handles[i+1:]...)
+		default:
+			h := handles[rng.Intn(len(handles))]
+			if err := q.Update(h, rng.Intn(1000)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := q.Validate(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+		if q.Len() != len(handles) {
+			t.Fatalf("step %d: expected %d values, got %d", step, len(handles), q.Len())
+		}
+	}
+}
+
+type edge struct{ to, weight int }
+
+// dijkstra finds shortest distances from source using DecreaseKey
+func dijkstra(graph [][]edge, source int) []int {
+	dist := make([]int, len(graph))
+	q := NewIndexedPriorityQueue[int, int]()
+	handles := make([]*Handle[int, int], len(graph))
+	for v := range graph {
+		dist[v] = math.MaxInt
+		handles[v] = q.Push(v, math.MaxInt)
+	}
+	dist[source] = 0
+	q.DecreaseKey(handles[source], 0)
+
+	for !q.IsEmpty() {
+		h, _ := q.Pop()
+		u := h.Value
+		if dist[u] == math.MaxInt {
+			break
+		}
+		for _, e := range graph[u] {
+			if d := dist[u] + e.weight; d < dist[e.to] {
+				dist[e.to] = d
+				q.DecreaseKey(handles[e.to], d)
+			}
+		}
+	}
+	return dist
+}
+
+func TestIndexedPriorityQueueDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 60
+	graph := make([][]edge, n)
+	for i := 0; i < 4*n; i++ {
+		u, v := rng.Intn(n), rng.Intn(n)
+		graph[u] = append(graph[u], edge{v, rng.Intn(20)})
+	}
+
+	// Bellman-Ford as the reference
+	want := make([]int, n)
+	for v := range want {
+		want[v] = math.MaxInt
+	}
+	want[0] = 0
+	for round := 0; round < n; round++ {
+		for u := range graph {
+			if want[u] == math.MaxInt {
+				continue
+			}
+			for _, e := range graph[u] {
+				want[e.to] = min(want[e.to], want[u]+e.weight)
+			}
+		}
+	}
+
+	got := dijkstra(graph, 0)
+	for v := range want {
+		if got[v] != want[v] {
+			t.Errorf("Distance to %d is %d, want %d", v, got[v], want[v])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package heaps
+
+import "fmt"
+
+// The helpers below work on a slice laid out as a d-ary heap: the
+// children of i are d*i+1 through d*i+d and its parent is (i-1)/d.
+
+// siftUp moves items[i] up until its parent does not compare greater
+func siftUp[T any](items []T, i, d int, compare func(a, b T) int) {
+	for i > 0 {
+		parent := (i - 1) / d
+		if compare(items[i], items[parent]) >= 0 {
+			return
+		}
+		items[i], items[parent] = items[parent], items[i]
+		i = parent
+	}
+}
+
+// siftDown moves items[i] down until none of its children compares
+// smaller
+func siftDown[T any](items []T, i, d int, compare func(a, b T) int) {
+	for {
+		smallest := i
+		first := d*i + 1
+		for child := first; child < first+d && child < len(items); child++ {
+			if compare(items[child], items[smallest]) < 0 {
+				smallest = child
+			}
+		}
+		if smallest == i {
+			return
+		}
+		items[i], items[smallest] = items[smallest], items[i]
+		i = smallest
+	}
+}
+
+// heapify orders items as a heap bottom-up. Most nodes are near the
+// leaves and sift only a little way, so this takes O(n).
+func heapify[T any](items []T, d int, compare func(a, b T) int) {
+	for i := (len(items) - 2) / d; i >= 0; i-- {
+		siftDown(items, i, d, compare)
+	}
+}
+
+func pop[T any](items *[]T, d int, compare func(a, b T) int) (T, bool) {
+	var zero T
+	n := len(*items)
+	if n == 0 {
+		return zero, false
+	}
+	top := (*items)[0]
+	(*items)[0] = (*items)[n-1]
+	(*items)[n-1] = zero // let the value be collected
+	*items = (*items)[:n-1]
+	siftDown(*items, 0, d, compare)
+	return top, true
+}
+
+func pushPop[T any](items []T, value T, d int, compare func(a, b T) int) T {
+	if len(items) == 0 || compare(value, items[0]) <= 0 {
+		return value
+	}
+	top := items[0]
+	items[0] = value
+	siftDown(items, 0, d, compare)
+	return top
+}
+
+func validate[T any](items []T, d int, compare func(a, b T) int) error {
+	for i := 1; i < len(items); i++ {
+		if parent := (i - 1) / d; compare(items[i], items[parent]) < 0 {
+			return fmt.Errorf("value %v at %d is smaller than its parent %v at %d", items[i], i, items[parent], parent)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Package treeprint draws trees with box-drawing connectors for the
+// String methods of the tree packages.
+package treeprint
+
+import "strings"
+
+// Branch writes the connector for a node in a tree drawing and returns
+// the prefix for its children
+func Branch(result *strings.Builder, prefix string, isLast bool) string {
+	result.WriteString(prefix)
+	if isLast {
+		result.WriteString("└── ")
+		return prefix + "    "
+	}
+	result.WriteString("├── ")
+	return prefix + "│   "
+}
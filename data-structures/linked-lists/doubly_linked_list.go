@@ -0,0 +1,224 @@
+package linkedlists
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DoublyNode represents a node in a doubly linked list.
+// Nodes returned by the list act as handles for O(1) removal and moves.
+type DoublyNode[T any] struct {
+	Data T
+	prev *DoublyNode[T]
+	next *DoublyNode[T]
+	list *DoublyLinkedList[T]
+}
+
+// Next returns the following node, or nil at the back of the list
+func (n *DoublyNode[T]) Next() *DoublyNode[T] {
+	return n.next
+}
+
+// Prev returns the preceding node, or nil at the front of the list
+func (n *DoublyNode[T]) Prev() *DoublyNode[T] {
+	return n.prev
+}
+
+// DoublyLinkedList represents a doubly linked list
+type DoublyLinkedList[T any] struct {
+	head *DoublyNode[T]
+	tail *DoublyNode[T]
+	size int
+}
+
+// NewDoublyLinkedList creates a new empty doubly linked list
+func NewDoublyLinkedList[T any]() *DoublyLinkedList[T] {
+	return &DoublyLinkedList[T]{}
+}
+
+// Front returns the first node, or nil if the list is empty
+func (dl *DoublyLinkedList[T]) Front() *DoublyNode[T] {
+	return dl.head
+}
+
+// Back returns the last node, or nil if the list is empty
+func (dl *DoublyLinkedList[T]) Back() *DoublyNode[T] {
+	return dl.tail
+}
+
+// PushFront adds a value at the beginning of the list
+func (dl *DoublyLinkedList[T]) PushFront(data T) *DoublyNode[T] {
+	return dl.link(&DoublyNode[T]{Data: data}, nil, dl.head)
+}
+
+// PushBack adds a value at the end of the list
+func (dl *DoublyLinkedList[T]) PushBack(data T) *DoublyNode[T] {
+	return dl.link(&DoublyNode[T]{Data: data}, dl.tail, nil)
+}
+
+// InsertBefore adds a value immediately before mark, which must belong to the list
+func (dl *DoublyLinkedList[T]) InsertBefore(data T, mark *DoublyNode[T]) *DoublyNode[T] {
+	if mark == nil || mark.list != dl {
+		return nil
+	}
+	return dl.link(&DoublyNode[T]{Data: data}, mark.prev, mark)
+}
+
+// InsertAfter adds a value immediately after mark, which must belong to the list
+func (dl *DoublyLinkedList[T]) InsertAfter(data T, mark *DoublyNode[T]) *DoublyNode[T] {
+	if mark == nil || mark.list != dl {
+		return nil
+	}
+	return dl.link(&DoublyNode[T]{Data: data}, mark, mark.next)
+}
+
+// link splices node in between prev and next
+func (dl *DoublyLinkedList[T]) link(node, prev, next *DoublyNode[T]) *DoublyNode[T] {
+	node.prev = prev
+	node.next = next
+	node.list = dl
+
+	if prev == nil {
+		dl.head = node
+	} else {
+		prev.next = node
+	}
+	if next == nil {
+		dl.tail = node
+	} else {
+		next.prev = node
+	}
+
+	dl.size++
+	return node
+}
+
+// unlink detaches node from its neighbours
+func (dl *DoublyLinkedList[T]) unlink(node *DoublyNode[T]) {
+	if node.prev == nil {
+		dl.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		dl.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+
+	node.prev = nil
+	node.next = nil
+	dl.size--
+}
+
+// Remove removes node from the list and returns its value.
+// It reports false if node does not belong to the list.
+func (dl *DoublyLinkedList[T]) Remove(node *DoublyNode[T]) (T, bool) {
+	if node == nil || node.list != dl {
+		var zero T
+		return zero, false
+	}
+	dl.unlink(node)
+	node.list = nil
+	return node.Data, true
+}
+
+// MoveToFront moves node to the beginning of the list
+func (dl *DoublyLinkedList[T]) MoveToFront(node *DoublyNode[T]) {
+	if node == nil || node.list != dl || dl.head == node {
+		return
+	}
+	dl.unlink(node)
+	dl.link(node, nil, dl.head)
+}
+
+// MoveToBack moves node to the end of the list
+func (dl *DoublyLinkedList[T]) MoveToBack(node *DoublyNode[T]) {
+	if node == nil || node.list != dl || dl.tail == node {
+		return
+	}
+	dl.unlink(node)
+	dl.link(node, dl.tail, nil)
+}
+
+// PopFront removes and returns the first value
+func (dl *DoublyLinkedList[T]) PopFront() (T, bool) {
+	return dl.Remove(dl.head)
+}
+
+// PopBack removes and returns the last value
+func (dl *DoublyLinkedList[T]) PopBack() (T, bool) {
+	return dl.Remove(dl.tail)
+}
+
+// Size returns the number of elements in the list
+func (dl *DoublyLinkedList[T]) Size() int {
+	return dl.size
+}
+
+// IsEmpty checks if the list is empty
+func (dl *DoublyLinkedList[T]) IsEmpty() bool {
+	return dl.size == 0
+}
+
+// Clear removes all elements from the list
+func (dl *DoublyLinkedList[T]) Clear() {
+	for node := dl.head; node != nil; {
+		next := node.next
+		node.prev, node.next, node.list = nil, nil, nil
+		node = next
+	}
+	dl.head = nil
+	dl.tail = nil
+	dl.size = 0
+}
+
+// All returns an iterator over the values from front to back
+func (dl *DoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := dl.head; node != nil; node = node.next {
+			if !yield(node.Data) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values from back to front
+func (dl *DoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := dl.tail; node != nil; node = node.prev {
+			if !yield(node.Data) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice converts the list to a slice
+func (dl *DoublyLinkedList[T]) ToSlice() []T {
+	result := make([]T, 0, dl.size)
+	for data := range dl.All() {
+		result = append(result, data)
+	}
+	return result
+}
+
+// String returns a string representation of the list
+func (dl *DoublyLinkedList[T]) String() string {
+	if dl.head == nil {
+		return "[]"
+	}
+
+	var result strings.Builder
+	result.WriteString("[")
+	for node := dl.head; node != nil; node = node.next {
+		result.WriteString(fmt.Sprintf("%v", node.Data))
+		if node.next != nil {
+			result.WriteString(" <-> ")
+		}
+	}
+	result.WriteString("]")
+	return result.String()
+}
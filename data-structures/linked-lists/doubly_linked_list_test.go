@@ -0,0 +1,105 @@
+package linkedlists
+
+import (
+	"testing"
+)
+
+func equalSlices(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDoublyLinkedList(t *testing.T) {
+	dl := NewDoublyLinkedList[int]()
+	if !dl.IsEmpty() || dl.String() != "[]" {
+		t.Error("Expected list to be empty")
+	}
+
+	// Test push
+	two := dl.PushBack(2)
+	dl.PushBack(3)
+	one := dl.PushFront(1)
+	if got := dl.ToSlice(); !equalSlices(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	// Test insert around a node
+	dl.InsertAfter(25, two)
+	dl.InsertBefore(15, two)
+	if got := dl.ToSlice(); !equalSlices(got, []int{1, 15, 2, 25, 3}) {
+		t.Errorf("Expected [1 15 2 25 3], got %v", got)
+	}
+
+	// Test moves
+	dl.MoveToFront(two)
+	dl.MoveToBack(one)
+	if got := dl.ToSlice(); !equalSlices(got, []int{2, 15, 25, 3, 1}) {
+		t.Errorf("Expected [2 15 25 3 1], got %v", got)
+	}
+
+	// Test backward iteration
+	var backward []int
+	for v := range dl.Backward() {
+		backward = append(backward, v)
+	}
+	if !equalSlices(backward, []int{1, 3, 25, 15, 2}) {
+		t.Errorf("Expected [1 3 25 15 2], got %v", backward)
+	}
+
+	// Test remove
+	if val, ok := dl.Remove(two); !ok || val != 2 {
+		t.Errorf("Expected to remove 2, got (%d, %v)", val, ok)
+	}
+	if _, ok := dl.Remove(two); ok {
+		t.Error("Expected removing a detached node to fail")
+	}
+	if val, _ := dl.PopFront(); val != 15 {
+		t.Errorf("Expected to pop 15, got %d", val)
+	}
+	if val, _ := dl.PopBack(); val != 1 {
+		t.Errorf("Expected to pop 1, got %d", val)
+	}
+	if dl.Size() != 2 || dl.Front().Data != 25 || dl.Back().Data != 3 {
+		t.Errorf("Expected [25 <-> 3], got %s", dl)
+	}
+	if dl.Front().Next() != dl.Back() || dl.Back().Prev() != dl.Front() {
+		t.Error("Expected front and back to be linked")
+	}
+
+	// Nodes from another list are rejected
+	other := NewDoublyLinkedList[int]()
+	foreign := other.PushBack(99)
+	if dl.InsertAfter(1, foreign) != nil {
+		t.Error("Expected insert next to a foreign node to fail")
+	}
+	if _, ok := dl.Remove(foreign); ok || other.Size() != 1 {
+		t.Error("Expected remove of a foreign node to fail")
+	}
+
+	dl.Clear()
+	if !dl.IsEmpty() || dl.Front() != nil || dl.Back() != nil {
+		t.Error("Expected list to be empty after clear")
+	}
+	if _, ok := dl.PopFront(); ok {
+		t.Error("Expected pop from an empty list to fail")
+	}
+}
+
+func BenchmarkDoublyLinkedListMoveToFront(b *testing.B) {
+	dl := NewDoublyLinkedList[int]()
+	nodes := make([]*DoublyNode[int], 1024)
+	for i := range nodes {
+		nodes[i] = dl.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dl.MoveToFront(nodes[i&1023])
+	}
+}
@@ -0,0 +1,312 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	kindBloomFilter         = 'B'
+	kindCountingBloomFilter = 'C'
+)
+
+// bloomParameters returns the optimal number of bits m and hash functions k
+// for n items at false positive rate p: m = -n ln p / (ln 2)^2, k = m/n ln 2
+func bloomParameters(n int, p float64) (uint64, int) {
+	if n <= 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return uint64(m), k
+}
+
+// BloomFilter represents a set that answers "possibly present" or
+// "definitely absent". False positives occur at a bounded rate; false
+// negatives never do. Contains does not modify the filter, so concurrent
+// queries are safe; Add needs exclusive access.
+type BloomFilter struct {
+	bits  []uint64
+	m     uint64
+	k     int
+	count uint64
+}
+
+// NewBloomFilter creates a Bloom filter sized for expectedItems insertions
+// at the given false positive rate
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	m, k := bloomParameters(expectedItems, falsePositiveRate)
+	return newBloomFilter(m, k)
+}
+
+func newBloomFilter(m uint64, k int) *BloomFilter {
+	return &BloomFilter{
+		bits: make([]uint64, bloomWords(m)),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Add inserts data into the filter
+func (bf *BloomFilter) Add(data []byte) {
+	var buf indexBuffer
+	for _, i := range indexes(data, bf.k, bf.m, buf[:0]) {
+		bf.bits[i/64] |= 1 << (i % 64)
+	}
+	bf.count++
+}
+
+// AddString inserts s into the filter
+func (bf *BloomFilter) AddString(s string) {
+	bf.Add([]byte(s))
+}
+
+// Contains reports whether data may have been added
+func (bf *BloomFilter) Contains(data []byte) bool {
+	var buf indexBuffer
+	for _, i := range indexes(data, bf.k, bf.m, buf[:0]) {
+		if bf.bits[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString reports whether s may have been added
+func (bf *BloomFilter) ContainsString(s string) bool {
+	return bf.Contains([]byte(s))
+}
+
+// Count returns the number of Add calls, including duplicates
+func (bf *BloomFilter) Count() uint64 {
+	return bf.count
+}
+
+// BitCount returns the number of bits in the filter
+func (bf *BloomFilter) BitCount() uint64 {
+	return bf.m
+}
+
+// HashCount returns the number of hash functions
+func (bf *BloomFilter) HashCount() int {
+	return bf.k
+}
+
+// FalsePositiveRate estimates the current false positive rate from the
+// fraction of set bits: (set/m)^k
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, word := range bf.bits {
+		set += bits.OnesCount64(word)
+	}
+	return math.Pow(float64(set)/float64(bf.m), float64(bf.k))
+}
+
+// Union adds every item of other to the filter. Both filters must have
+// the same size and number of hash functions.
+func (bf *BloomFilter) Union(other *BloomFilter) error {
+	if bf.m != other.m || bf.k != other.k {
+		return fmt.Errorf("cannot union filters with %d/%d and %d/%d bits/hashes", bf.m, bf.k, other.m, other.k)
+	}
+	for i := range bf.bits {
+		bf.bits[i] |= other.bits[i]
+	}
+	bf.count += other.count
+	return nil
+}
+
+// Clear removes all items from the filter
+func (bf *BloomFilter) Clear() {
+	clear(bf.bits)
+	bf.count = 0
+}
+
+// MarshalBinary encodes the filter as bytes
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 2+24+8*len(bf.bits)), kindBloomFilter)
+	buf = binary.LittleEndian.AppendUint64(buf, bf.m)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(bf.k))
+	buf = binary.LittleEndian.AppendUint64(buf, bf.count)
+	for _, word := range bf.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	return buf, nil
+}
+
+// bloomWords returns the number of 64-bit words holding m bits, without
+// overflowing for any m
+func bloomWords(m uint64) uint64 {
+	return m/64 + min(m%64, 1)
+}
+
+// UnmarshalBinary decodes a filter produced by MarshalBinary
+func (bf *BloomFilter) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, kindBloomFilter)
+	if err != nil {
+		return err
+	}
+	params, data, err := readUint64s(data, 3)
+	if err != nil {
+		return err
+	}
+	m, k := params[0], params[1]
+	if m == 0 || k == 0 || k > 64 || len(data)%8 != 0 || uint64(len(data))/8 != bloomWords(m) {
+		return ErrInvalidData
+	}
+	words, _, err := readUint64s(data, int(bloomWords(m)))
+	if err != nil {
+		return err
+	}
+	*bf = BloomFilter{bits: words, m: m, k: int(k), count: params[2]}
+	return nil
+}
+
+// String returns a summary of the filter
+func (bf *BloomFilter) String() string {
+	return fmt.Sprintf("BloomFilter{bits: %d, hashes: %d, items: %d, fpr: %.4g}",
+		bf.m, bf.k, bf.count, bf.FalsePositiveRate())
+}
+
+// CountingBloomFilter represents a Bloom filter with a small counter per
+// slot instead of a bit, so items can be removed. Counters saturate at 255
+// and a saturated counter is never decremented, which keeps false
+// negatives impossible. Like BloomFilter, it allows concurrent Contains
+// calls but not concurrent updates.
+type CountingBloomFilter struct {
+	counters []uint8
+	m        uint64
+	k        int
+	count    uint64
+}
+
+// NewCountingBloomFilter creates a counting Bloom filter sized for
+// expectedItems items at the given false positive rate
+func NewCountingBloomFilter(expectedItems int, falsePositiveRate float64) *CountingBloomFilter {
+	m, k := bloomParameters(expectedItems, falsePositiveRate)
+	return &CountingBloomFilter{
+		counters: make([]uint8, m),
+		m:        m,
+		k:        k,
+	}
+}
+
+// Add inserts data into the filter
+func (cf *CountingBloomFilter) Add(data []byte) {
+	var buf indexBuffer
+	for _, i := range indexes(data, cf.k, cf.m, buf[:0]) {
+		if cf.counters[i] < math.MaxUint8 {
+			cf.counters[i]++
+		}
+	}
+	cf.count++
+}
+
+// AddString inserts s into the filter
+func (cf *CountingBloomFilter) AddString(s string) {
+	cf.Add([]byte(s))
+}
+
+// Remove deletes one occurrence of data. It reports false, leaving the
+// filter unchanged, if data is definitely absent. Removing an item that
+// was never added but collides with present items causes false negatives.
+func (cf *CountingBloomFilter) Remove(data []byte) bool {
+	var buf indexBuffer
+	idx := indexes(data, cf.k, cf.m, buf[:0])
+	for _, i := range idx {
+		if cf.counters[i] == 0 {
+			return false
+		}
+	}
+	for _, i := range idx {
+		if cf.counters[i] < math.MaxUint8 {
+			cf.counters[i]--
+		}
+	}
+	cf.count--
+	return true
+}
+
+// RemoveString deletes one occurrence of s
+func (cf *CountingBloomFilter) RemoveString(s string) bool {
+	return cf.Remove([]byte(s))
+}
+
+// Contains reports whether data may be present
+func (cf *CountingBloomFilter) Contains(data []byte) bool {
+	var buf indexBuffer
+	for _, i := range indexes(data, cf.k, cf.m, buf[:0]) {
+		if cf.counters[i] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString reports whether s may be present
+func (cf *CountingBloomFilter) ContainsString(s string) bool {
+	return cf.Contains([]byte(s))
+}
+
+// Count returns the number of items added minus the number removed
+func (cf *CountingBloomFilter) Count() uint64 {
+	return cf.count
+}
+
+// FalsePositiveRate estimates the current false positive rate from the
+// fraction of non-zero counters
+func (cf *CountingBloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, c := range cf.counters {
+		if c != 0 {
+			set++
+		}
+	}
+	return math.Pow(float64(set)/float64(cf.m), float64(cf.k))
+}
+
+// Clear removes all items from the filter
+func (cf *CountingBloomFilter) Clear() {
+	clear(cf.counters)
+	cf.count = 0
+}
+
+// MarshalBinary encodes the filter as bytes
+func (cf *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 2+24+len(cf.counters)), kindCountingBloomFilter)
+	buf = binary.LittleEndian.AppendUint64(buf, cf.m)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(cf.k))
+	buf = binary.LittleEndian.AppendUint64(buf, cf.count)
+	return append(buf, cf.counters...), nil
+}
+
+// UnmarshalBinary decodes a filter produced by MarshalBinary
+func (cf *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, kindCountingBloomFilter)
+	if err != nil {
+		return err
+	}
+	params, data, err := readUint64s(data, 3)
+	if err != nil {
+		return err
+	}
+	m, k := params[0], params[1]
+	if m == 0 || k == 0 || k > 64 || uint64(len(data)) != m {
+		return ErrInvalidData
+	}
+	*cf = CountingBloomFilter{counters: append([]uint8(nil), data...), m: m, k: int(k), count: params[2]}
+	return nil
+}
+
+// String returns a summary of the filter
+func (cf *CountingBloomFilter) String() string {
+	return fmt.Sprintf("CountingBloomFilter{counters: %d, hashes: %d, items: %d, fpr: %.4g}",
+		cf.m, cf.k, cf.count, cf.FalsePositiveRate())
+}
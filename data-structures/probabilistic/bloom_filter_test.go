@@ -0,0 +1,246 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// encode builds serialized data with a header, the given uint64 fields and
+// a payload, for feeding hostile input to UnmarshalBinary
+func encode(kind byte, fields []uint64, payload int) []byte {
+	data := appendHeader(nil, kind)
+	for _, f := range fields {
+		data = binary.LittleEndian.AppendUint64(data, f)
+	}
+	return append(data, make([]byte, payload)...)
+}
+
+// falsePositiveRate measures the rate of false positives over probes that
+// were never added
+func falsePositiveRate(contains func(string) bool, probes int) float64 {
+	falsePositives := 0
+	for i := 0; i < probes; i++ {
+		if contains(fmt.Sprintf("absent-%d", i)) {
+			falsePositives++
+		}
+	}
+	return float64(falsePositives) / float64(probes)
+}
+
+func TestBloomFilter(t *testing.T) {
+	for _, rate := range []float64{0.1, 0.01, 0.001} {
+		t.Run(fmt.Sprint(rate), func(t *testing.T) {
+			const n = 10000
+			bf := NewBloomFilter(n, rate)
+			for i := 0; i < n; i++ {
+				bf.AddString(fmt.Sprintf("item-%d", i))
+			}
+
+			// No false negatives
+			for i := 0; i < n; i++ {
+				if !bf.ContainsString(fmt.Sprintf("item-%d", i)) {
+					t.Fatalf("Expected item-%d to be present", i)
+				}
+			}
+
+			// Allow 50% slack over the configured rate for sampling noise
+			observed := falsePositiveRate(bf.ContainsString, 100000)
+			if observed > rate*1.5 {
+				t.Errorf("False positive rate %.5f exceeds target %.5f", observed, rate)
+			}
+			if estimated := bf.FalsePositiveRate(); estimated > rate*1.5 {
+				t.Errorf("Estimated rate %.5f exceeds target %.5f", estimated, rate)
+			}
+		})
+	}
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	a := NewBloomFilter(100, 0.01)
+	b := NewBloomFilter(100, 0.01)
+	a.AddString("a")
+	b.AddString("b")
+	if err := a.Union(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.ContainsString("a") || !a.ContainsString("b") || a.Count() != 2 {
+		t.Errorf("Expected union to contain a and b, got %s", a)
+	}
+	if err := a.Union(NewBloomFilter(1000, 0.01)); err == nil {
+		t.Error("Expected union of different sizes to fail")
+	}
+
+	a.Clear()
+	if a.ContainsString("a") || a.Count() != 0 {
+		t.Error("Expected filter to be empty after clear")
+	}
+}
+
+func TestBloomFilterMarshal(t *testing.T) {
+	bf := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		bf.AddString(fmt.Sprint(i))
+	}
+	data, err := bf.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != bf.String() {
+		t.Errorf("Expected %s, got %s", bf, &decoded)
+	}
+	for i := 0; i < 500; i++ {
+		if !decoded.ContainsString(fmt.Sprint(i)) {
+			t.Fatalf("Expected %d to survive a round trip", i)
+		}
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err != ErrInvalidData {
+		t.Errorf("Expected ErrInvalidData for truncated data, got %v", err)
+	}
+	if err := decoded.UnmarshalBinary([]byte("H\x01")); err != ErrInvalidData {
+		t.Errorf("Expected ErrInvalidData for the wrong kind, got %v", err)
+	}
+}
+
+func TestBloomFilterRejectsHostileSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		// (m+63)/64 wraps to 0 words
+		{"max bits", encode(kindBloomFilter, []uint64{^uint64(0), 3, 0}, 0)},
+		{"too many bits", encode(kindBloomFilter, []uint64{1 << 40, 3, 0}, 8)},
+		{"too few bits", encode(kindBloomFilter, []uint64{64, 3, 0}, 16)},
+		{"partial word", encode(kindBloomFilter, []uint64{64, 3, 0}, 12)},
+		{"no hashes", encode(kindBloomFilter, []uint64{64, 0, 0}, 8)},
+	}
+	for _, tt := range tests {
+		var bf BloomFilter
+		if err := bf.UnmarshalBinary(tt.data); err != ErrInvalidData {
+			t.Errorf("%s: expected ErrInvalidData, got %v", tt.name, err)
+		}
+	}
+
+	// The smallest valid filter still works after decoding
+	var bf BloomFilter
+	if err := bf.UnmarshalBinary(encode(kindBloomFilter, []uint64{1, 1, 0}, 8)); err != nil {
+		t.Fatal(err)
+	}
+	bf.AddString("x")
+	if !bf.ContainsString("x") {
+		t.Error("Expected x after adding it")
+	}
+}
+
+func TestCountingBloomFilter(t *testing.T) {
+	const n = 5000
+	cf := NewCountingBloomFilter(n, 0.01)
+	for i := 0; i < n; i++ {
+		cf.AddString(fmt.Sprintf("item-%d", i))
+	}
+	if observed := falsePositiveRate(cf.ContainsString, 50000); observed > 0.015 {
+		t.Errorf("False positive rate %.5f exceeds target 0.01", observed)
+	}
+
+	// Removing half the items keeps the other half present
+	for i := 0; i < n; i += 2 {
+		if !cf.RemoveString(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Expected to remove item-%d", i)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		if !cf.ContainsString(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Expected item-%d to remain after removals", i)
+		}
+	}
+	if cf.Count() != n/2 {
+		t.Errorf("Expected count %d, got %d", n/2, cf.Count())
+	}
+
+	// Removed items are mostly gone; the survivors cause some false positives
+	stillPresent := 0
+	for i := 0; i < n; i += 2 {
+		if cf.ContainsString(fmt.Sprintf("item-%d", i)) {
+			stillPresent++
+		}
+	}
+	if rate := float64(stillPresent) / (n / 2); rate > 0.01 {
+		t.Errorf("Expected removed items to be absent, %.4f still present", rate)
+	}
+
+	if cf.RemoveString("never-added") {
+		t.Error("Expected removing an absent item to fail")
+	}
+}
+
+func TestCountingBloomFilterMarshal(t *testing.T) {
+	cf := NewCountingBloomFilter(100, 0.01)
+	cf.AddString("x")
+	cf.AddString("x")
+	data, _ := cf.MarshalBinary()
+
+	var decoded CountingBloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	decoded.RemoveString("x")
+	if !decoded.ContainsString("x") || decoded.Count() != 1 {
+		t.Error("Expected x to remain after removing one of two copies")
+	}
+}
+
+func BenchmarkBloomFilterAdd(b *testing.B) {
+	bf := NewBloomFilter(b.N+1, 0.01)
+	data := []byte("benchmark-item")
+	for i := 0; i < b.N; i++ {
+		bf.Add(data)
+	}
+}
+
+func BenchmarkBloomFilterContains(b *testing.B) {
+	bf := NewBloomFilter(1000, 0.01)
+	data := []byte("benchmark-item")
+	bf.Add(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bf.Contains(data)
+	}
+}
+
+// Queries leave the structures untouched, so they may run concurrently;
+// run with -race to check
+func TestConcurrentQueries(t *testing.T) {
+	const n = 1000
+	bf := NewBloomFilter(n, 0.01)
+	cf := NewCountingBloomFilter(n, 0.01)
+	s := NewCountMinSketch(0.001, 0.01)
+	for i := 0; i < n; i++ {
+		item := fmt.Sprintf("item-%d", i)
+		bf.AddString(item)
+		cf.AddString(item)
+		s.AddString(item, 1)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				item := fmt.Sprintf("item-%d", i)
+				if !bf.ContainsString(item) || !cf.ContainsString(item) || s.EstimateString(item) < 1 {
+					t.Errorf("Expected %s to be found", item)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
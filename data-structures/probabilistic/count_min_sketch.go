@@ -0,0 +1,256 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const kindCountMinSketch = 'M'
+
+// CountMinSketch estimates item frequencies in a stream using a depth x
+// width grid of counters. Estimates never undercount; with probability at
+// least 1 - delta they overcount by at most epsilon times the total count.
+// Estimate does not modify the sketch, so concurrent estimates are safe;
+// Add needs exclusive access.
+type CountMinSketch struct {
+	counters []uint64
+	width    uint64
+	depth    int
+	total    uint64
+}
+
+// NewCountMinSketch creates a sketch with error factor epsilon and failure
+// probability delta: width = ceil(e / epsilon), depth = ceil(ln(1 / delta))
+func NewCountMinSketch(epsilon, delta float64) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return newCountMinSketch(width, depth)
+}
+
+func newCountMinSketch(width uint64, depth int) *CountMinSketch {
+	return &CountMinSketch{
+		counters: make([]uint64, width*uint64(depth)),
+		width:    width,
+		depth:    depth,
+	}
+}
+
+// Add increases the count of data by count
+func (s *CountMinSketch) Add(data []byte, count uint64) {
+	var buf indexBuffer
+	for row, col := range indexes(data, s.depth, s.width, buf[:0]) {
+		s.counters[uint64(row)*s.width+col] += count
+	}
+	s.total += count
+}
+
+// AddString increases the count of item by count
+func (s *CountMinSketch) AddString(item string, count uint64) {
+	s.Add([]byte(item), count)
+}
+
+// Estimate returns the estimated count of data
+func (s *CountMinSketch) Estimate(data []byte) uint64 {
+	var buf indexBuffer
+	estimate := uint64(math.MaxUint64)
+	for row, col := range indexes(data, s.depth, s.width, buf[:0]) {
+		estimate = min(estimate, s.counters[uint64(row)*s.width+col])
+	}
+	return estimate
+}
+
+// EstimateString returns the estimated count of item
+func (s *CountMinSketch) EstimateString(item string) uint64 {
+	return s.Estimate([]byte(item))
+}
+
+// Total returns the sum of all counts added
+func (s *CountMinSketch) Total() uint64 {
+	return s.total
+}
+
+// Width returns the number of counters per row
+func (s *CountMinSketch) Width() uint64 {
+	return s.width
+}
+
+// Depth returns the number of rows
+func (s *CountMinSketch) Depth() int {
+	return s.depth
+}
+
+// Merge adds the counts of other into the sketch. Both sketches must have
+// the same dimensions.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s.width != other.width || s.depth != other.depth {
+		return fmt.Errorf("cannot merge sketches of %dx%d and %dx%d", s.depth, s.width, other.depth, other.width)
+	}
+	for i := range s.counters {
+		s.counters[i] += other.counters[i]
+	}
+	s.total += other.total
+	return nil
+}
+
+// Clear resets all counters
+func (s *CountMinSketch) Clear() {
+	clear(s.counters)
+	s.total = 0
+}
+
+// MarshalBinary encodes the sketch as bytes
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 2+24+8*len(s.counters)), kindCountMinSketch)
+	buf = binary.LittleEndian.AppendUint64(buf, s.width)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(s.depth))
+	buf = binary.LittleEndian.AppendUint64(buf, s.total)
+	for _, c := range s.counters {
+		buf = binary.LittleEndian.AppendUint64(buf, c)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a sketch produced by MarshalBinary
+func (s *CountMinSketch) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, kindCountMinSketch)
+	if err != nil {
+		return err
+	}
+	params, data, err := readUint64s(data, 3)
+	if err != nil {
+		return err
+	}
+	// Check the size by division, so a hostile width or depth cannot
+	// overflow the product and pass
+	width, depth := params[0], params[1]
+	words := uint64(len(data)) / 8
+	if width == 0 || depth == 0 || depth > 64 || len(data)%8 != 0 ||
+		width > words/depth || width*depth != words {
+		return ErrInvalidData
+	}
+	counters, _, err := readUint64s(data, int(width*depth))
+	if err != nil {
+		return err
+	}
+	*s = CountMinSketch{counters: counters, width: width, depth: int(depth), total: params[2]}
+	return nil
+}
+
+// String returns a summary of the sketch
+func (s *CountMinSketch) String() string {
+	return fmt.Sprintf("CountMinSketch{width: %d, depth: %d, total: %d}", s.width, s.depth, s.total)
+}
+
+// ItemCount pairs an item with its estimated count
+type ItemCount struct {
+	Item  string
+	Count uint64
+}
+
+// HeavyHitters tracks the k most frequent items of a stream with a
+// Count-Min Sketch. Only the current top k candidates are stored, so an
+// item is reported once its estimate beats the smallest candidate.
+type HeavyHitters struct {
+	sketch     *CountMinSketch
+	k          int
+	candidates map[string]uint64
+}
+
+// NewHeavyHitters creates a tracker for the k most frequent items, backed
+// by a sketch with error factor epsilon and failure probability delta
+func NewHeavyHitters(k int, epsilon, delta float64) *HeavyHitters {
+	if k <= 0 {
+		k = 1
+	}
+	return &HeavyHitters{
+		sketch:     NewCountMinSketch(epsilon, delta),
+		k:          k,
+		candidates: make(map[string]uint64, k+1),
+	}
+}
+
+// Add increases the count of item by count and updates the candidates
+func (h *HeavyHitters) Add(item string, count uint64) {
+	h.sketch.AddString(item, count)
+	estimate := h.sketch.EstimateString(item)
+
+	if _, found := h.candidates[item]; found || len(h.candidates) < h.k {
+		h.candidates[item] = estimate
+		return
+	}
+
+	// Replace the smallest candidate if item now outranks it
+	smallest, smallestCount := "", uint64(math.MaxUint64)
+	for candidate, c := range h.candidates {
+		if c < smallestCount || (c == smallestCount && candidate > smallest) {
+			smallest, smallestCount = candidate, c
+		}
+	}
+	if estimate > smallestCount {
+		delete(h.candidates, smallest)
+		h.candidates[item] = estimate
+	}
+}
+
+// Estimate returns the estimated count of item
+func (h *HeavyHitters) Estimate(item string) uint64 {
+	return h.sketch.EstimateString(item)
+}
+
+// Top returns the candidates from most to least frequent. Ties are
+// ordered by item.
+func (h *HeavyHitters) Top() []ItemCount {
+	top := make([]ItemCount, 0, len(h.candidates))
+	for item, count := range h.candidates {
+		top = append(top, ItemCount{Item: item, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Item < top[j].Item
+	})
+	return top
+}
+
+// AboveThreshold returns the candidates whose estimate is at least phi
+// times the total count, most frequent first
+func (h *HeavyHitters) AboveThreshold(phi float64) []ItemCount {
+	threshold := phi * float64(h.sketch.Total())
+	var result []ItemCount
+	for _, ic := range h.Top() {
+		if float64(ic.Count) < threshold {
+			break
+		}
+		result = append(result, ic)
+	}
+	return result
+}
+
+// Sketch returns the underlying Count-Min Sketch
+func (h *HeavyHitters) Sketch() *CountMinSketch {
+	return h.sketch
+}
+
+// String returns the candidates from most to least frequent
+func (h *HeavyHitters) String() string {
+	var result strings.Builder
+	result.WriteString("HeavyHitters: [")
+	for i, ic := range h.Top() {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprintf("%s: %d", ic.Item, ic.Count))
+	}
+	result.WriteString("]")
+	return result.String()
+}
@@ -0,0 +1,136 @@
+package probabilistic
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCountMinSketchErrorBound(t *testing.T) {
+	const epsilon, delta = 0.001, 0.01
+	sketch := NewCountMinSketch(epsilon, delta)
+
+	// Zipf-like stream: item i appears about 10000/(i+1) times
+	rng := rand.New(rand.NewPCG(1, 2))
+	truth := make(map[string]uint64)
+	for i := 0; i < 2000; i++ {
+		item := fmt.Sprintf("item-%d", i)
+		count := uint64(10000/(i+1)) + uint64(rng.IntN(3))
+		truth[item] = count
+		sketch.AddString(item, count)
+	}
+
+	bound := uint64(epsilon * float64(sketch.Total()))
+	violations := 0
+	for item, count := range truth {
+		estimate := sketch.EstimateString(item)
+		if estimate < count {
+			t.Fatalf("Estimate %d for %s undercounts %d", estimate, item, count)
+		}
+		if estimate-count > bound {
+			violations++
+		}
+	}
+	// Each estimate exceeds the bound with probability at most delta;
+	// allow twice that for sampling noise
+	if rate := float64(violations) / float64(len(truth)); rate > 2*delta {
+		t.Errorf("%.4f of estimates exceed the error bound %d; want <= %.2f", rate, bound, delta)
+	}
+}
+
+func TestCountMinSketchMergeAndMarshal(t *testing.T) {
+	a := NewCountMinSketch(0.01, 0.01)
+	b := NewCountMinSketch(0.01, 0.01)
+	a.AddString("x", 3)
+	b.AddString("x", 4)
+	b.AddString("y", 1)
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if a.EstimateString("x") < 7 || a.Total() != 8 {
+		t.Errorf("Expected merged x >= 7 and total 8, got %d and %d", a.EstimateString("x"), a.Total())
+	}
+	if err := a.Merge(NewCountMinSketch(0.1, 0.01)); err == nil {
+		t.Error("Expected merging different dimensions to fail")
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CountMinSketch
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != a.String() || decoded.EstimateString("x") != a.EstimateString("x") {
+		t.Errorf("Expected %s, got %s", a, &decoded)
+	}
+	if err := decoded.UnmarshalBinary(data[:10]); err != ErrInvalidData {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
+
+func TestCountMinSketchRejectsHostileSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		// 8*width*depth wraps to 0
+		{"overflowing product", encode(kindCountMinSketch, []uint64{1 << 61, 8, 0}, 0)},
+		{"overflowing width", encode(kindCountMinSketch, []uint64{^uint64(0), 1, 0}, 8)},
+		{"too few counters", encode(kindCountMinSketch, []uint64{4, 2, 0}, 56)},
+		{"partial counter", encode(kindCountMinSketch, []uint64{1, 1, 0}, 12)},
+		{"too deep", encode(kindCountMinSketch, []uint64{1, 65, 0}, 8*65)},
+	}
+	for _, tt := range tests {
+		var sketch CountMinSketch
+		if err := sketch.UnmarshalBinary(tt.data); err != ErrInvalidData {
+			t.Errorf("%s: expected ErrInvalidData, got %v", tt.name, err)
+		}
+	}
+
+	var sketch CountMinSketch
+	if err := sketch.UnmarshalBinary(encode(kindCountMinSketch, []uint64{2, 3, 0}, 48)); err != nil {
+		t.Fatal(err)
+	}
+	sketch.AddString("x", 2)
+	if sketch.EstimateString("x") != 2 {
+		t.Errorf("Expected estimate 2, got %d", sketch.EstimateString("x"))
+	}
+}
+
+func TestHeavyHitters(t *testing.T) {
+	hh := NewHeavyHitters(3, 0.001, 0.01)
+
+	// Three planted heavy items hidden in a long tail of singletons
+	rng := rand.New(rand.NewPCG(3, 4))
+	for i := 0; i < 20000; i++ {
+		switch r := rng.IntN(10); {
+		case r < 2:
+			hh.Add("heavy-a", 1)
+		case r < 3:
+			hh.Add("heavy-b", 1)
+		case r < 4:
+			hh.Add("heavy-c", 1)
+		default:
+			hh.Add(fmt.Sprintf("tail-%d", i), 1)
+		}
+	}
+
+	top := hh.Top()
+	if len(top) != 3 || top[0].Item != "heavy-a" {
+		t.Fatalf("Expected heavy-a first among 3, got %v", top)
+	}
+	found := map[string]bool{}
+	for _, ic := range top {
+		found[ic.Item] = true
+	}
+	if !found["heavy-b"] || !found["heavy-c"] {
+		t.Errorf("Expected heavy-b and heavy-c in top, got %v", top)
+	}
+
+	above := hh.AboveThreshold(0.15)
+	if len(above) != 1 || above[0].Item != "heavy-a" {
+		t.Errorf("Expected only heavy-a above 15%%, got %v", above)
+	}
+}
@@ -0,0 +1,68 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"errors"
+
+	hashtables "go-programming/data-structures/hash-tables"
+)
+
+// Every structure hashes with SipHash-2-4 under a fixed key, so sketches
+// built in different processes agree and can be merged or deserialized
+const (
+	hashKey0 = 0x5bd1e9955bd1e995
+	hashKey1 = 0x9e3779b97f4a7c15
+)
+
+// hash64 returns the 64-bit hash of data
+func hash64(data []byte) uint64 {
+	return hashtables.SipHash24(hashKey0, hashKey1, data)
+}
+
+// indexBuffer holds the indexes of one item on the stack. Unmarshaling
+// caps k and depth at 64, so it rarely has to grow, and queries that use
+// it never write shared state.
+type indexBuffer [64]uint64
+
+// indexes derives k indexes in [0, m) from one hash using double hashing
+// (Kirsch and Mitzenmacher): g_i(x) = h1(x) + i*h2(x)
+func indexes(data []byte, k int, m uint64, out []uint64) []uint64 {
+	h := hash64(data)
+	h1 := h
+	h2 := (h>>32 | h<<32) | 1 // odd, so successive indexes differ
+	out = out[:0]
+	for i := 0; i < k; i++ {
+		out = append(out, (h1+uint64(i)*h2)%m)
+	}
+	return out
+}
+
+// ErrInvalidData is returned when unmarshaling malformed bytes
+var ErrInvalidData = errors.New("invalid serialized data")
+
+// header identifies the structure and format version of serialized data
+const formatVersion = 1
+
+func appendHeader(buf []byte, kind byte) []byte {
+	return append(buf, kind, formatVersion)
+}
+
+// readHeader checks the header and returns the rest of data
+func readHeader(data []byte, kind byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != kind || data[1] != formatVersion {
+		return nil, ErrInvalidData
+	}
+	return data[2:], nil
+}
+
+// readUint64s decodes n little-endian uint64 values from the front of data
+func readUint64s(data []byte, n int) ([]uint64, []byte, error) {
+	if len(data) < 8*n {
+		return nil, nil, ErrInvalidData
+	}
+	values := make([]uint64, n)
+	for i := range values {
+		values[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	return values, data[8*n:], nil
+}
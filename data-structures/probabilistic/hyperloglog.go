@@ -0,0 +1,156 @@
+package probabilistic
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	kindHyperLogLog = 'H'
+
+	// MinPrecision and MaxPrecision bound the number of index bits
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct items in a stream using
+// 2^p one-byte registers. The standard error is about 1.04 / sqrt(2^p).
+type HyperLogLog struct {
+	registers []uint8
+	precision uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog whose standard error is at most
+// standardError, using the smallest precision that achieves it
+func NewHyperLogLog(standardError float64) *HyperLogLog {
+	if standardError <= 0 || standardError >= 1 {
+		standardError = 0.01
+	}
+	m := math.Pow(1.04/standardError, 2)
+	precision := int(math.Ceil(math.Log2(m)))
+	return NewHyperLogLogWithPrecision(precision)
+}
+
+// NewHyperLogLogWithPrecision creates a HyperLogLog with 2^precision
+// registers. Precision is clamped to [MinPrecision, MaxPrecision].
+func NewHyperLogLogWithPrecision(precision int) *HyperLogLog {
+	precision = max(MinPrecision, min(MaxPrecision, precision))
+	return &HyperLogLog{
+		registers: make([]uint8, 1<<precision),
+		precision: uint8(precision),
+	}
+}
+
+// Add records data in the estimate
+func (h *HyperLogLog) Add(data []byte) {
+	x := hash64(data)
+	index := x >> (64 - h.precision)
+	// Rank is the position of the first set bit after the index bits
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// AddString records s in the estimate
+func (h *HyperLogLog) AddString(s string) {
+	h.Add([]byte(s))
+}
+
+// Count returns the estimated number of distinct items added
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(m) * m * m / sum
+
+	// Small range correction: linear counting is more accurate while
+	// many registers are still empty
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// alpha is the bias correction constant for m registers
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/m)
+}
+
+// Precision returns the number of index bits
+func (h *HyperLogLog) Precision() int {
+	return int(h.precision)
+}
+
+// StandardError returns the expected relative error of Count
+func (h *HyperLogLog) StandardError() float64 {
+	return 1.04 / math.Sqrt(float64(len(h.registers)))
+}
+
+// Merge combines other into h, so that h estimates the union of both
+// streams. Both must have the same precision.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("cannot merge precisions %d and %d", h.precision, other.precision)
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Clear resets the estimate to zero
+func (h *HyperLogLog) Clear() {
+	clear(h.registers)
+}
+
+// MarshalBinary encodes the registers as bytes
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	buf := appendHeader(make([]byte, 0, 3+len(h.registers)), kindHyperLogLog)
+	buf = append(buf, h.precision)
+	return append(buf, h.registers...), nil
+}
+
+// UnmarshalBinary decodes registers produced by MarshalBinary
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, kindHyperLogLog)
+	if err != nil {
+		return err
+	}
+	if len(data) < 1 {
+		return ErrInvalidData
+	}
+	precision := data[0]
+	if precision < MinPrecision || precision > MaxPrecision || len(data)-1 != 1<<precision {
+		return ErrInvalidData
+	}
+	for _, r := range data[1:] {
+		if r > 65-precision {
+			return ErrInvalidData
+		}
+	}
+	*h = HyperLogLog{registers: append([]uint8(nil), data[1:]...), precision: precision}
+	return nil
+}
+
+// String returns a summary of the estimate
+func (h *HyperLogLog) String() string {
+	return fmt.Sprintf("HyperLogLog{precision: %d, count: %d}", h.precision, h.Count())
+}
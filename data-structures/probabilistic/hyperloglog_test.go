@@ -0,0 +1,84 @@
+package probabilistic
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func relativeError(estimate, actual uint64) float64 {
+	return math.Abs(float64(estimate)-float64(actual)) / float64(actual)
+}
+
+func TestHyperLogLogAccuracy(t *testing.T) {
+	for _, standardError := range []float64{0.05, 0.02, 0.01} {
+		hll := NewHyperLogLog(standardError)
+		if hll.StandardError() > standardError {
+			t.Errorf("Precision %d gives error %.4f above target %.4f",
+				hll.Precision(), hll.StandardError(), standardError)
+		}
+
+		added := uint64(0)
+		for _, n := range []uint64{100, 1000, 10000, 100000} {
+			for ; added < n; added++ {
+				hll.AddString(fmt.Sprintf("user-%d", added))
+			}
+			// Duplicates do not change the estimate
+			hll.AddString("user-0")
+
+			// Three standard errors covers 99.7% of estimates
+			if e := relativeError(hll.Count(), n); e > 3*standardError {
+				t.Errorf("precision %d, n=%d: estimate %d off by %.4f; want <= %.4f",
+					hll.Precision(), n, hll.Count(), e, 3*standardError)
+			}
+		}
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLogWithPrecision(14)
+	b := NewHyperLogLogWithPrecision(14)
+	for i := 0; i < 30000; i++ {
+		a.AddString(fmt.Sprint(i))
+	}
+	for i := 20000; i < 50000; i++ {
+		b.AddString(fmt.Sprint(i))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if e := relativeError(a.Count(), 50000); e > 3*a.StandardError() {
+		t.Errorf("Expected union of about 50000, got %d", a.Count())
+	}
+	if err := a.Merge(NewHyperLogLogWithPrecision(10)); err == nil {
+		t.Error("Expected merging different precisions to fail")
+	}
+
+	a.Clear()
+	if a.Count() != 0 {
+		t.Errorf("Expected 0 after clear, got %d", a.Count())
+	}
+}
+
+func TestHyperLogLogMarshal(t *testing.T) {
+	hll := NewHyperLogLogWithPrecision(10)
+	for i := 0; i < 5000; i++ {
+		hll.AddString(fmt.Sprint(i))
+	}
+	data, err := hll.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded HyperLogLog
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Count() != hll.Count() || decoded.Precision() != 10 {
+		t.Errorf("Expected %s, got %s", hll, &decoded)
+	}
+
+	data[3] = 200 // register above the maximum rank
+	if err := decoded.UnmarshalBinary(data); err != ErrInvalidData {
+		t.Errorf("Expected ErrInvalidData, got %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+package segmenttrees
+
+import "fmt"
+
+// Fenwick represents a binary indexed tree: an array of numbers with
+// point updates and prefix or range sums in O(log n), in n words of memory
+type Fenwick[T Number] struct {
+	tree []T // 1-based; tree[i] sums the elements in (i - lowbit(i), i]
+}
+
+// NewFenwick creates a Fenwick tree of n zeros. A negative n is treated
+// as 0.
+func NewFenwick[T Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, max(n, 0)+1)}
+}
+
+// NewFenwickFrom builds a Fenwick tree over values in O(n)
+func NewFenwickFrom[T Number](values []T) *Fenwick[T] {
+	f := &Fenwick[T]{tree: make([]T, len(values)+1)}
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		if parent := i + i&-i; parent < len(f.tree) {
+			f.tree[parent] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// Len returns the number of elements
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// add adds delta at the 1-based position i
+func (f *Fenwick[T]) add(i int, delta T) {
+	for ; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// prefix returns the sum of the first i elements
+func (f *Fenwick[T]) prefix(i int) T {
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// Add adds delta to the element at index i
+func (f *Fenwick[T]) Add(i int, delta T) error {
+	if err := checkIndex(i, f.Len()); err != nil {
+		return err
+	}
+	f.add(i+1, delta)
+	return nil
+}
+
+// Set replaces the element at index i
+func (f *Fenwick[T]) Set(i int, value T) error {
+	current, err := f.Get(i)
+	if err != nil {
+		return err
+	}
+	f.add(i+1, value-current)
+	return nil
+}
+
+// Get returns the element at index i
+func (f *Fenwick[T]) Get(i int) (T, error) {
+	return f.Sum(i, i+1)
+}
+
+// PrefixSum returns the sum of the elements in [0, i)
+func (f *Fenwick[T]) PrefixSum(i int) (T, error) {
+	return f.Sum(0, i)
+}
+
+// Sum returns the sum of the elements in [lo, hi)
+func (f *Fenwick[T]) Sum(lo, hi int) (T, error) {
+	if err := checkRange(lo, hi, f.Len()); err != nil {
+		return 0, err
+	}
+	return f.prefix(hi) - f.prefix(lo), nil
+}
+
+// LowerBound returns the smallest i such that the sum of [0, i] is at
+// least target, or Len if there is none. It needs every element to be
+// non-negative, so that prefix sums never decrease.
+func (f *Fenwick[T]) LowerBound(target T) int {
+	pos := 0
+	step := 1
+	for step*2 < len(f.tree) {
+		step *= 2
+	}
+	var sum T
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next < len(f.tree) && sum+f.tree[next] < target {
+			pos = next
+			sum += f.tree[next]
+		}
+	}
+	return pos
+}
+
+// Values returns the elements
+func (f *Fenwick[T]) Values() []T {
+	values := make([]T, f.Len())
+	for i := range values {
+		values[i] = f.prefix(i+1) - f.prefix(i)
+	}
+	return values
+}
+
+// String returns a string representation of the elements
+func (f *Fenwick[T]) String() string {
+	return fmt.Sprintf("Fenwick: %v", f.Values())
+}
+
+// RangeFenwick represents an array of numbers with range additions and
+// point reads in O(log n). It keeps a Fenwick tree of the differences
+// between neighbouring elements, so an element is the prefix sum of the
+// differences up to it.
+type RangeFenwick[T Number] struct {
+	diff *Fenwick[T]
+}
+
+// NewRangeFenwick creates a range-update Fenwick tree of n zeros
+func NewRangeFenwick[T Number](n int) *RangeFenwick[T] {
+	// One spare slot lets RangeAdd cancel its delta past the last element
+	return &RangeFenwick[T]{diff: NewFenwick[T](max(n, 0) + 1)}
+}
+
+// NewRangeFenwickFrom builds a range-update Fenwick tree over values in O(n)
+func NewRangeFenwickFrom[T Number](values []T) *RangeFenwick[T] {
+	diff := make([]T, len(values)+1)
+	var prev T
+	for i, value := range values {
+		diff[i] = value - prev
+		prev = value
+	}
+	return &RangeFenwick[T]{diff: NewFenwickFrom(diff)}
+}
+
+// Len returns the number of elements
+func (f *RangeFenwick[T]) Len() int {
+	return f.diff.Len() - 1
+}
+
+// RangeAdd adds delta to every element in [lo, hi)
+func (f *RangeFenwick[T]) RangeAdd(lo, hi int, delta T) error {
+	if err := checkRange(lo, hi, f.Len()); err != nil {
+		return err
+	}
+	f.diff.add(lo+1, delta)
+	f.diff.add(hi+1, -delta)
+	return nil
+}
+
+// Get returns the element at index i
+func (f *RangeFenwick[T]) Get(i int) (T, error) {
+	if err := checkIndex(i, f.Len()); err != nil {
+		return 0, err
+	}
+	return f.diff.prefix(i + 1), nil
+}
+
+// Values returns the elements
+func (f *RangeFenwick[T]) Values() []T {
+	values := make([]T, f.Len())
+	for i := range values {
+		values[i] = f.diff.prefix(i + 1)
+	}
+	return values
+}
+
+// String returns a string representation of the elements
+func (f *RangeFenwick[T]) String() string {
+	return fmt.Sprintf("RangeFenwick: %v", f.Values())
+}
+
+// Fenwick2D represents a grid of numbers with point updates and rectangle
+// sums in O(log rows * log cols)
+type Fenwick2D[T Number] struct {
+	rows, cols int
+	tree       [][]T // 1-based in both dimensions
+}
+
+// NewFenwick2D creates a 2D Fenwick tree of zeros. Negative dimensions
+// are treated as 0.
+func NewFenwick2D[T Number](rows, cols int) *Fenwick2D[T] {
+	rows, cols = max(rows, 0), max(cols, 0)
+	tree := make([][]T, rows+1)
+	for i := range tree {
+		tree[i] = make([]T, cols+1)
+	}
+	return &Fenwick2D[T]{rows: rows, cols: cols, tree: tree}
+}
+
+// NewFenwick2DFrom builds a 2D Fenwick tree over a rectangular grid. It
+// returns ErrIndexOutOfRange if a row differs in length from the first.
+func NewFenwick2DFrom[T Number](grid [][]T) (*Fenwick2D[T], error) {
+	rows, cols := len(grid), 0
+	if rows > 0 {
+		cols = len(grid[0])
+	}
+	for r, row := range grid {
+		if len(row) != cols {
+			return nil, fmt.Errorf("%w: row %d has length %d, want %d", ErrIndexOutOfRange, r, len(row), cols)
+		}
+	}
+
+	f := NewFenwick2D[T](rows, cols)
+	for r, row := range grid {
+		for c, value := range row {
+			f.add(r+1, c+1, value)
+		}
+	}
+	return f, nil
+}
+
+// Rows returns the number of rows
+func (f *Fenwick2D[T]) Rows() int {
+	return f.rows
+}
+
+// Cols returns the number of columns
+func (f *Fenwick2D[T]) Cols() int {
+	return f.cols
+}
+
+func (f *Fenwick2D[T]) add(row, col int, delta T) {
+	for r := row; r <= f.rows; r += r & -r {
+		for c := col; c <= f.cols; c += c & -c {
+			f.tree[r][c] += delta
+		}
+	}
+}
+
+// prefix returns the sum of the cells above and left of (row, col),
+// exclusive
+func (f *Fenwick2D[T]) prefix(row, col int) T {
+	var sum T
+	for r := row; r > 0; r -= r & -r {
+		for c := col; c > 0; c -= c & -c {
+			sum += f.tree[r][c]
+		}
+	}
+	return sum
+}
+
+// Add adds delta to the cell at (row, col)
+func (f *Fenwick2D[T]) Add(row, col int, delta T) error {
+	if err := checkIndex(row, f.rows); err != nil {
+		return err
+	}
+	if err := checkIndex(col, f.cols); err != nil {
+		return err
+	}
+	f.add(row+1, col+1, delta)
+	return nil
+}
+
+// Get returns the cell at (row, col)
+func (f *Fenwick2D[T]) Get(row, col int) (T, error) {
+	return f.Sum(row, col, row+1, col+1)
+}
+
+// Sum returns the sum of the cells in rows [r1, r2) and columns [c1, c2)
+func (f *Fenwick2D[T]) Sum(r1, c1, r2, c2 int) (T, error) {
+	if err := checkRange(r1, r2, f.rows); err != nil {
+		return 0, err
+	}
+	if err := checkRange(c1, c2, f.cols); err != nil {
+		return 0, err
+	}
+	return f.prefix(r2, c2) - f.prefix(r1, c2) - f.prefix(r2, c1) + f.prefix(r1, c1), nil
+}
+
+// String returns the dimensions of the grid
+func (f *Fenwick2D[T]) String() string {
+	return fmt.Sprintf("Fenwick2D: %dx%d", f.rows, f.cols)
+}
@@ -0,0 +1,166 @@
+package segmenttrees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	io "go-programming/utils/input-output"
+)
+
+func TestFenwick(t *testing.T) {
+	f := NewFenwickFrom([]int{3, 1, 4, 1, 5})
+	if sum, err := f.Sum(1, 4); err != nil || sum != 6 {
+		t.Errorf("Expected (6, nil), got (%d, %v)", sum, err)
+	}
+	f.Add(2, 10)
+	f.Set(0, 0)
+	if got := f.String(); got != "Fenwick: [0 1 14 1 5]" {
+		t.Errorf("Unexpected tree %s", got)
+	}
+	if sum, _ := f.PrefixSum(3); sum != 15 {
+		t.Errorf("Expected 15, got %d", sum)
+	}
+
+	// Prefix sums are 0 1 15 16 21
+	for target, want := range map[int]int{0: 0, 1: 1, 2: 2, 15: 2, 16: 3, 21: 4, 22: 5} {
+		if got := f.LowerBound(target); got != want {
+			t.Errorf("LowerBound(%d) = %d, want %d", target, got, want)
+		}
+	}
+
+	if _, err := f.Sum(2, 6); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if err := f.Add(5, 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+
+	// Negative lengths give empty trees
+	if n := NewFenwick[int](-3).Len(); n != 0 {
+		t.Errorf("Expected an empty tree, got length %d", n)
+	}
+	if n := NewRangeFenwick[int](-3).Len(); n != 0 {
+		t.Errorf("Expected an empty range tree, got length %d", n)
+	}
+}
+
+func TestFenwickAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, size := range []int{1, 8, 37, 100} {
+		values := io.GenerateRandomArray(size, -50, 50)
+		f := NewFenwickFrom(values)
+		empty := NewFenwick[int](size)
+		for i, v := range values {
+			empty.Add(i, v)
+		}
+
+		for op := 0; op < 500; op++ {
+			lo := rng.Intn(size + 1)
+			hi := lo + rng.Intn(size-lo+1)
+			if rng.Intn(3) == 0 && lo < size {
+				v := rng.Intn(101) - 50
+				values[lo] = v
+				f.Set(lo, v)
+				empty.Set(lo, v)
+				continue
+			}
+			want := bruteSum(values, lo, hi)
+			if got, _ := f.Sum(lo, hi); got != want {
+				t.Fatalf("Sum of [%d, %d) in %v = %d, want %d", lo, hi, values, got, want)
+			}
+			if got, _ := empty.Sum(lo, hi); got != want {
+				t.Fatalf("Sum of [%d, %d) after Adds = %d, want %d", lo, hi, got, want)
+			}
+		}
+		if !slices.Equal(f.Values(), values) {
+			t.Fatalf("Expected values %v, got %v", values, f.Values())
+		}
+	}
+}
+
+func TestRangeFenwickAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for _, size := range []int{1, 9, 64} {
+		values := io.GenerateRandomArray(size, -50, 50)
+		f := NewRangeFenwickFrom(values)
+		if !slices.Equal(f.Values(), values) {
+			t.Fatalf("Expected values %v, got %v", values, f.Values())
+		}
+
+		for op := 0; op < 500; op++ {
+			lo := rng.Intn(size + 1)
+			hi := lo + rng.Intn(size-lo+1)
+			delta := rng.Intn(21) - 10
+			f.RangeAdd(lo, hi, delta)
+			for i := lo; i < hi; i++ {
+				values[i] += delta
+			}
+			i := rng.Intn(size)
+			if got, _ := f.Get(i); got != values[i] {
+				t.Fatalf("Get(%d) = %d, want %d in %v", i, got, values[i], values)
+			}
+		}
+		if !slices.Equal(f.Values(), values) {
+			t.Fatalf("Expected values %v, got %v", values, f.Values())
+		}
+	}
+
+	f := NewRangeFenwick[float64](3)
+	f.RangeAdd(0, 3, 0.5)
+	f.RangeAdd(1, 2, 2)
+	if got := f.String(); got != "RangeFenwick: [0.5 2.5 0.5]" {
+		t.Errorf("Unexpected tree %s", got)
+	}
+	if _, err := f.Get(3); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+}
+
+func TestFenwick2DAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	rows, cols := 12, 17
+	grid := make([][]int, rows)
+	for r := range grid {
+		grid[r] = io.GenerateRandomArray(cols, -20, 20)
+	}
+	f, err := NewFenwick2DFrom(grid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for op := 0; op < 1000; op++ {
+		if rng.Intn(3) == 0 {
+			r, c, delta := rng.Intn(rows), rng.Intn(cols), rng.Intn(21)-10
+			grid[r][c] += delta
+			f.Add(r, c, delta)
+			continue
+		}
+		r1, c1 := rng.Intn(rows+1), rng.Intn(cols+1)
+		r2, c2 := r1+rng.Intn(rows-r1+1), c1+rng.Intn(cols-c1+1)
+		want := 0
+		for r := r1; r < r2; r++ {
+			want += bruteSum(grid[r], c1, c2)
+		}
+		if got, err := f.Sum(r1, c1, r2, c2); err != nil || got != want {
+			t.Fatalf("Sum of rows [%d, %d) and columns [%d, %d) = %d, want %d", r1, r2, c1, c2, got, want)
+		}
+	}
+	if v, _ := f.Get(3, 4); v != grid[3][4] {
+		t.Errorf("Expected %d, got %d", grid[3][4], v)
+	}
+	if _, err := f.Sum(0, 0, rows+1, 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if f.Rows() != rows || f.Cols() != cols || f.String() != "Fenwick2D: 12x17" {
+		t.Errorf("Unexpected dimensions %s", f)
+	}
+
+	if _, err := NewFenwick2DFrom([][]int{{1, 2}, {3}}); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange for ragged rows, got %v", err)
+	}
+	if empty := NewFenwick2D[int](-1, 4); empty.Rows() != 0 || empty.Cols() != 4 {
+		t.Errorf("Expected a 0x4 grid, got %s", empty)
+	}
+}
@@ -0,0 +1,248 @@
+package segmenttrees
+
+import "fmt"
+
+// LazyOps tells a LazySegmentTree what to compute over a range and how
+// range updates change that result. Sum, Min and Max cover the usual
+// cases; any other aggregate works as long as an assignment or addition
+// over a whole range can be applied to its aggregate without looking at
+// the elements.
+type LazyOps[T Number] struct {
+	// Name is shown by String
+	Name string
+	// Combine merges the aggregates of two adjacent ranges, left first.
+	// It must be associative.
+	Combine func(a, b T) T
+	// Assign returns the aggregate of length elements all equal to value
+	Assign func(value T, length int) T
+	// Add returns the aggregate of length elements after delta is added
+	// to each of them, given their aggregate before
+	Add func(aggregate, delta T, length int) T
+}
+
+// Sum returns the operations for range sums
+func Sum[T Number]() LazyOps[T] {
+	return LazyOps[T]{
+		Name:    "sum",
+		Combine: func(a, b T) T { return a + b },
+		Assign:  func(value T, length int) T { return value * T(length) },
+		Add:     func(sum, delta T, length int) T { return sum + delta*T(length) },
+	}
+}
+
+// Min returns the operations for range minimums
+func Min[T Number]() LazyOps[T] {
+	return LazyOps[T]{
+		Name:    "min",
+		Combine: func(a, b T) T { return min(a, b) },
+		Assign:  func(value T, _ int) T { return value },
+		Add:     func(smallest, delta T, _ int) T { return smallest + delta },
+	}
+}
+
+// Max returns the operations for range maximums
+func Max[T Number]() LazyOps[T] {
+	return LazyOps[T]{
+		Name:    "max",
+		Combine: func(a, b T) T { return max(a, b) },
+		Assign:  func(value T, _ int) T { return value },
+		Add:     func(largest, delta T, _ int) T { return largest + delta },
+	}
+}
+
+// LazySegmentTree represents an array of numbers that answers range
+// queries under the aggregate given by its LazyOps and applies range-add
+// and range-assign updates, all in O(log n). Updates are recorded on the
+// highest nodes they cover and only pushed down when a later operation
+// needs to look below them.
+type LazySegmentTree[T Number] struct {
+	n        int
+	ops      LazyOps[T]
+	tree     []T    // aggregate of each node's range, with its pending update applied
+	add      []T    // pending addition for the children
+	assign   []T    // pending assignment for the children
+	assigned []bool // whether assign is pending
+}
+
+// NewLazySegmentTree builds a lazy segment tree over values in O(n)
+func NewLazySegmentTree[T Number](values []T, ops LazyOps[T]) *LazySegmentTree[T] {
+	n := len(values)
+	size := 4 * max(n, 1)
+	st := &LazySegmentTree[T]{
+		n:        n,
+		ops:      ops,
+		tree:     make([]T, size),
+		add:      make([]T, size),
+		assign:   make([]T, size),
+		assigned: make([]bool, size),
+	}
+	if n > 0 {
+		st.build(values, 1, 0, n)
+	}
+	return st
+}
+
+func (st *LazySegmentTree[T]) build(values []T, node, l, r int) {
+	if r-l == 1 {
+		st.tree[node] = st.ops.Assign(values[l], 1)
+		return
+	}
+	mid := (l + r) / 2
+	st.build(values, 2*node, l, mid)
+	st.build(values, 2*node+1, mid, r)
+	st.tree[node] = st.combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *LazySegmentTree[T]) combine(a, b T) T {
+	return st.ops.Combine(a, b)
+}
+
+// Len returns the number of elements
+func (st *LazySegmentTree[T]) Len() int {
+	return st.n
+}
+
+// applyAssign sets every element in node's range [l, r) to value
+func (st *LazySegmentTree[T]) applyAssign(node, l, r int, value T) {
+	st.tree[node] = st.ops.Assign(value, r-l)
+	st.assign[node], st.assigned[node] = value, true
+	st.add[node] = 0
+}
+
+// applyAdd adds delta to every element in node's range [l, r)
+func (st *LazySegmentTree[T]) applyAdd(node, l, r int, delta T) {
+	st.tree[node] = st.ops.Add(st.tree[node], delta, r-l)
+	// An addition after an assignment folds into the assigned value
+	if st.assigned[node] {
+		st.assign[node] += delta
+	} else {
+		st.add[node] += delta
+	}
+}
+
+// push hands node's pending update to its children
+func (st *LazySegmentTree[T]) push(node, l, r int) {
+	mid := (l + r) / 2
+	if st.assigned[node] {
+		st.applyAssign(2*node, l, mid, st.assign[node])
+		st.applyAssign(2*node+1, mid, r, st.assign[node])
+		st.assigned[node] = false
+	}
+	if st.add[node] != 0 {
+		st.applyAdd(2*node, l, mid, st.add[node])
+		st.applyAdd(2*node+1, mid, r, st.add[node])
+		st.add[node] = 0
+	}
+}
+
+// update applies an assignment or addition to [lo, hi) below node
+func (st *LazySegmentTree[T]) update(node, l, r, lo, hi int, value T, isAssign bool) {
+	if hi <= l || r <= lo {
+		return
+	}
+	if lo <= l && r <= hi {
+		if isAssign {
+			st.applyAssign(node, l, r, value)
+		} else {
+			st.applyAdd(node, l, r, value)
+		}
+		return
+	}
+	st.push(node, l, r)
+	mid := (l + r) / 2
+	st.update(2*node, l, mid, lo, hi, value, isAssign)
+	st.update(2*node+1, mid, r, lo, hi, value, isAssign)
+	st.tree[node] = st.combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+// RangeAdd adds delta to every element in [lo, hi)
+func (st *LazySegmentTree[T]) RangeAdd(lo, hi int, delta T) error {
+	if err := checkRange(lo, hi, st.n); err != nil {
+		return err
+	}
+	if lo < hi {
+		st.update(1, 0, st.n, lo, hi, delta, false)
+	}
+	return nil
+}
+
+// RangeAssign sets every element in [lo, hi) to value
+func (st *LazySegmentTree[T]) RangeAssign(lo, hi int, value T) error {
+	if err := checkRange(lo, hi, st.n); err != nil {
+		return err
+	}
+	if lo < hi {
+		st.update(1, 0, st.n, lo, hi, value, true)
+	}
+	return nil
+}
+
+// Set replaces the element at index i
+func (st *LazySegmentTree[T]) Set(i int, value T) error {
+	if err := checkIndex(i, st.n); err != nil {
+		return err
+	}
+	return st.RangeAssign(i, i+1, value)
+}
+
+func (st *LazySegmentTree[T]) query(node, l, r, lo, hi int) T {
+	if lo <= l && r <= hi {
+		return st.tree[node]
+	}
+	st.push(node, l, r)
+	mid := (l + r) / 2
+	switch {
+	case hi <= mid:
+		return st.query(2*node, l, mid, lo, hi)
+	case lo >= mid:
+		return st.query(2*node+1, mid, r, lo, hi)
+	}
+	return st.combine(st.query(2*node, l, mid, lo, hi), st.query(2*node+1, mid, r, lo, hi))
+}
+
+// Query returns the aggregate of the elements in [lo, hi). The range must
+// not be empty, as min and max have no value for it.
+func (st *LazySegmentTree[T]) Query(lo, hi int) (T, error) {
+	if err := checkRange(lo, hi, st.n); err != nil {
+		return 0, err
+	}
+	if lo == hi {
+		return 0, ErrEmptyRange
+	}
+	return st.query(1, 0, st.n, lo, hi), nil
+}
+
+// Get returns the aggregate of the element at index i, which is the
+// element itself for Sum, Min and Max
+func (st *LazySegmentTree[T]) Get(i int) (T, error) {
+	if err := checkIndex(i, st.n); err != nil {
+		return 0, err
+	}
+	return st.query(1, 0, st.n, i, i+1), nil
+}
+
+// Values returns the aggregate of each element with every pending update
+// applied
+func (st *LazySegmentTree[T]) Values() []T {
+	values := make([]T, st.n)
+	var collect func(node, l, r int)
+	collect = func(node, l, r int) {
+		if r-l == 1 {
+			values[l] = st.tree[node]
+			return
+		}
+		st.push(node, l, r)
+		mid := (l + r) / 2
+		collect(2*node, l, mid)
+		collect(2*node+1, mid, r)
+	}
+	if st.n > 0 {
+		collect(1, 0, st.n)
+	}
+	return values
+}
+
+// String returns a string representation of the elements
+func (st *LazySegmentTree[T]) String() string {
+	return fmt.Sprintf("LazySegmentTree(%s): %v", st.ops.Name, st.Values())
+}
@@ -0,0 +1,37 @@
+package segmenttrees
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Number is the set of types the summing trees work with
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+var (
+	// ErrIndexOutOfRange is returned for an index or range outside the tree
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// ErrEmptyRange is returned when a query needs at least one element
+	ErrEmptyRange = errors.New("empty range")
+)
+
+// checkIndex checks that 0 <= i < n
+func checkIndex(i, n int) error {
+	if i < 0 || i >= n {
+		return fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, i, n)
+	}
+	return nil
+}
+
+// checkRange checks that [lo, hi) is a valid, possibly empty, range of n
+// elements
+func checkRange(lo, hi, n int) error {
+	if lo < 0 || hi > n || lo > hi {
+		return fmt.Errorf("%w: [%d, %d) with length %d", ErrIndexOutOfRange, lo, hi, n)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package segmenttrees
+
+import "fmt"
+
+// SegmentTree represents an array that answers range queries under any
+// associative combine function, such as sum, min, max, gcd or
+// concatenation, in O(log n) while allowing point updates. The function
+// need not be commutative: results always combine elements left to right.
+type SegmentTree[T any] struct {
+	n        int
+	tree     []T // tree[n:] holds the elements, tree[i] combines tree[2i] and tree[2i+1]
+	identity T
+	combine  func(a, b T) T
+}
+
+// NewSegmentTree builds a segment tree over a copy of values in O(n).
+// identity must satisfy combine(identity, x) == combine(x, identity) == x.
+func NewSegmentTree[T any](values []T, identity T, combine func(a, b T) T) *SegmentTree[T] {
+	n := len(values)
+	st := &SegmentTree[T]{n: n, tree: make([]T, 2*n), identity: identity, combine: combine}
+	copy(st.tree[n:], values)
+	for i := n - 1; i > 0; i-- {
+		st.tree[i] = combine(st.tree[2*i], st.tree[2*i+1])
+	}
+	return st
+}
+
+// Len returns the number of elements
+func (st *SegmentTree[T]) Len() int {
+	return st.n
+}
+
+// Get returns the element at index i
+func (st *SegmentTree[T]) Get(i int) (T, error) {
+	if err := checkIndex(i, st.n); err != nil {
+		var zero T
+		return zero, err
+	}
+	return st.tree[st.n+i], nil
+}
+
+// Set replaces the element at index i
+func (st *SegmentTree[T]) Set(i int, value T) error {
+	if err := checkIndex(i, st.n); err != nil {
+		return err
+	}
+	i += st.n
+	st.tree[i] = value
+	for i > 1 {
+		i /= 2
+		st.tree[i] = st.combine(st.tree[2*i], st.tree[2*i+1])
+	}
+	return nil
+}
+
+// Query combines the elements in [lo, hi). An empty range gives the
+// identity.
+func (st *SegmentTree[T]) Query(lo, hi int) (T, error) {
+	if err := checkRange(lo, hi, st.n); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// Walk up from both ends, keeping the left and right results apart so
+	// the order of combination is preserved
+	left, right := st.identity, st.identity
+	for lo, hi = lo+st.n, hi+st.n; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo%2 == 1 {
+			left = st.combine(left, st.tree[lo])
+			lo++
+		}
+		if hi%2 == 1 {
+			hi--
+			right = st.combine(st.tree[hi], right)
+		}
+	}
+	return st.combine(left, right), nil
+}
+
+// Values returns a copy of the elements
+func (st *SegmentTree[T]) Values() []T {
+	return append([]T(nil), st.tree[st.n:]...)
+}
+
+// String returns a string representation of the elements
+func (st *SegmentTree[T]) String() string {
+	return fmt.Sprintf("SegmentTree: %v", st.tree[st.n:])
+}
@@ -0,0 +1,181 @@
+package segmenttrees
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	io "go-programming/utils/input-output"
+)
+
+func bruteSum(values []int, lo, hi int) int {
+	sum := 0
+	for _, v := range values[lo:hi] {
+		sum += v
+	}
+	return sum
+}
+
+func TestSegmentTree(t *testing.T) {
+	st := NewSegmentTree([]int{5, 3, 8, 1, 9}, 0, func(a, b int) int { return a + b })
+	if sum, err := st.Query(1, 4); err != nil || sum != 12 {
+		t.Errorf("Expected (12, nil), got (%d, %v)", sum, err)
+	}
+	if sum, _ := st.Query(2, 2); sum != 0 {
+		t.Errorf("Expected the identity for an empty range, got %d", sum)
+	}
+	if err := st.Set(3, 10); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := st.Get(3); v != 10 {
+		t.Errorf("Expected 10, got %d", v)
+	}
+	if sum, _ := st.Query(0, 5); sum != 35 {
+		t.Errorf("Expected 35, got %d", sum)
+	}
+	if st.String() != "SegmentTree: [5 3 8 10 9]" {
+		t.Errorf("Unexpected string %s", st)
+	}
+
+	if _, err := st.Query(3, 6); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if err := st.Set(-1, 0); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := NewSegmentTree[int](nil, 0, func(a, b int) int { return a + b }).Query(0, 0); err != nil {
+		t.Errorf("Expected an empty query on an empty tree to work, got %v", err)
+	}
+}
+
+func TestSegmentTreeAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{1, 2, 7, 64, 100} {
+		values := io.GenerateRandomArray(size, -50, 50)
+		sums := NewSegmentTree(values, 0, func(a, b int) int { return a + b })
+		mins := NewSegmentTree(values, int(^uint(0)>>1), func(a, b int) int { return min(a, b) })
+
+		// Concatenation is associative but not commutative
+		words := make([]string, size)
+		for i, v := range values {
+			words[i] = string(rune('a' + (v+50)%26))
+		}
+		concat := NewSegmentTree(words, "", func(a, b string) string { return a + b })
+
+		for op := 0; op < 500; op++ {
+			if rng.Intn(3) == 0 {
+				i, v := rng.Intn(size), rng.Intn(101)-50
+				values[i] = v
+				words[i] = string(rune('a' + (v+50)%26))
+				sums.Set(i, v)
+				mins.Set(i, v)
+				concat.Set(i, words[i])
+				continue
+			}
+
+			lo := rng.Intn(size)
+			hi := lo + 1 + rng.Intn(size-lo)
+			if got, _ := sums.Query(lo, hi); got != bruteSum(values, lo, hi) {
+				t.Fatalf("Sum of [%d, %d) in %v = %d, want %d", lo, hi, values, got, bruteSum(values, lo, hi))
+			}
+			if got, _ := mins.Query(lo, hi); got != slices.Min(values[lo:hi]) {
+				t.Fatalf("Min of [%d, %d) in %v = %d", lo, hi, values, got)
+			}
+			if got, _ := concat.Query(lo, hi); got != strings.Join(words[lo:hi], "") {
+				t.Fatalf("Concatenation of [%d, %d) = %q, want %q", lo, hi, got, strings.Join(words[lo:hi], ""))
+			}
+		}
+		if !slices.Equal(sums.Values(), values) {
+			t.Fatalf("Expected values %v, got %v", values, sums.Values())
+		}
+	}
+}
+
+func TestLazySegmentTree(t *testing.T) {
+	st := NewLazySegmentTree([]int{1, 2, 3, 4, 5}, Sum[int]())
+	st.RangeAdd(1, 4, 10)
+	st.RangeAssign(3, 5, 7)
+	if got := st.String(); got != "LazySegmentTree(sum): [1 12 13 7 7]" {
+		t.Errorf("Unexpected tree %s", got)
+	}
+	if sum, _ := st.Query(0, 5); sum != 40 {
+		t.Errorf("Expected 40, got %d", sum)
+	}
+	if _, err := st.Query(2, 2); !errors.Is(err, ErrEmptyRange) {
+		t.Errorf("Expected ErrEmptyRange, got %v", err)
+	}
+	if err := st.RangeAdd(0, 6, 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+
+	floats := NewLazySegmentTree([]float64{1.5, -2, 4}, Max[float64]())
+	floats.RangeAdd(0, 2, 3)
+	if got, _ := floats.Query(0, 3); got != 4.5 {
+		t.Errorf("Expected max 4.5, got %v", got)
+	}
+	if !strings.HasPrefix(floats.String(), "LazySegmentTree(max): ") {
+		t.Errorf("Unexpected name in %s", floats)
+	}
+}
+
+// sumMod returns operations for range sums modulo m, a custom aggregate
+func sumMod(m int) LazyOps[int] {
+	mod := func(v int) int { return ((v % m) + m) % m }
+	return LazyOps[int]{
+		Name:    fmt.Sprintf("sum mod %d", m),
+		Combine: func(a, b int) int { return mod(a + b) },
+		Assign:  func(value, length int) int { return mod(value * length) },
+		Add:     func(sum, delta, length int) int { return mod(sum + delta*length) },
+	}
+}
+
+func TestLazySegmentTreeAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const m = 97
+	aggregates := []struct {
+		ops   LazyOps[int]
+		brute func(values []int) int
+	}{
+		{Sum[int](), func(values []int) int { return bruteSum(values, 0, len(values)) }},
+		{Min[int](), slices.Min[[]int]},
+		{Max[int](), slices.Max[[]int]},
+		{sumMod(m), func(values []int) int { return ((bruteSum(values, 0, len(values)) % m) + m) % m }},
+	}
+	for _, aggregate := range aggregates {
+		for _, size := range []int{1, 5, 33, 100} {
+			values := io.GenerateRandomArray(size, -100, 100)
+			st := NewLazySegmentTree(values, aggregate.ops)
+
+			for op := 0; op < 1000; op++ {
+				lo := rng.Intn(size)
+				hi := lo + 1 + rng.Intn(size-lo)
+				v := rng.Intn(41) - 20
+				switch rng.Intn(4) {
+				case 0:
+					st.RangeAdd(lo, hi, v)
+					for i := lo; i < hi; i++ {
+						values[i] += v
+					}
+				case 1:
+					st.RangeAssign(lo, hi, v)
+					for i := lo; i < hi; i++ {
+						values[i] = v
+					}
+				default:
+					want := aggregate.brute(values[lo:hi])
+					if got, err := st.Query(lo, hi); err != nil || got != want {
+						t.Fatalf("%s of [%d, %d) in %v = %d, want %d", aggregate.ops.Name, lo, hi, values, got, want)
+					}
+				}
+			}
+			for i, v := range st.Values() {
+				if want := aggregate.brute(values[i : i+1]); v != want {
+					t.Fatalf("%s: expected element %d to be %d, got %d", aggregate.ops.Name, i, want, v)
+				}
+			}
+		}
+	}
+}
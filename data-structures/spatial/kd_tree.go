@@ -0,0 +1,432 @@
+package spatial
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// kdNode is a node in a KDTree. Points in the left subtree are below the
+// node on its axis and points in the right subtree are at or above it.
+type kdNode struct {
+	point Point
+	left  *kdNode
+	right *kdNode
+}
+
+// KDTree represents a k-d tree: a binary tree over points of a fixed
+// dimension that splits on each axis in turn. It answers nearest
+// neighbour, k-nearest and radius queries by skipping subtrees that lie
+// too far away. Duplicate points are kept.
+type KDTree struct {
+	root *kdNode
+	dims int
+	size int
+}
+
+// NewKDTree creates a new empty k-d tree for points with dims coordinates.
+// A dims below 1 is raised to 1.
+func NewKDTree(dims int) *KDTree {
+	return &KDTree{dims: max(dims, 1)}
+}
+
+// NewKDTreeFrom builds a balanced k-d tree from points by splitting at the
+// median of each axis, in O(n log² n). Every point must have dims
+// coordinates, with dims raised to 1 as in NewKDTree.
+func NewKDTreeFrom(dims int, points []Point) (*KDTree, error) {
+	t := NewKDTree(dims)
+	owned := make([]Point, len(points))
+	for i, p := range points {
+		if err := t.check(p); err != nil {
+			return nil, err
+		}
+		owned[i] = slices.Clone(p)
+	}
+	t.root = t.build(owned, 0)
+	t.size = len(owned)
+	return t, nil
+}
+
+func (t *KDTree) build(points []Point, depth int) *kdNode {
+	if len(points) == 0 {
+		return nil
+	}
+	axis := depth % t.dims
+	slices.SortFunc(points, func(a, b Point) int {
+		switch {
+		case a[axis] < b[axis]:
+			return -1
+		case a[axis] > b[axis]:
+			return 1
+		}
+		return 0
+	})
+
+	// Points equal to the median on this axis must go right
+	mid := len(points) / 2
+	for mid > 0 && points[mid-1][axis] == points[mid][axis] {
+		mid--
+	}
+	return &kdNode{
+		point: points[mid],
+		left:  t.build(points[:mid], depth+1),
+		right: t.build(points[mid+1:], depth+1),
+	}
+}
+
+func (t *KDTree) check(p Point) error {
+	if len(p) != t.dims {
+		return fmt.Errorf("%w: %d coordinates, want %d", ErrDimensionMismatch, len(p), t.dims)
+	}
+	return nil
+}
+
+// Dims returns the number of coordinates of every point
+func (t *KDTree) Dims() int {
+	return t.dims
+}
+
+// Insert adds a copy of p
+func (t *KDTree) Insert(p Point) error {
+	if err := t.check(p); err != nil {
+		return err
+	}
+	link := &t.root
+	for depth := 0; *link != nil; depth++ {
+		axis := depth % t.dims
+		if p[axis] < (*link).point[axis] {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	*link = &kdNode{point: slices.Clone(p)}
+	t.size++
+	return nil
+}
+
+// Contains checks if the tree holds p
+func (t *KDTree) Contains(p Point) bool {
+	if len(p) != t.dims {
+		return false
+	}
+	node := t.root
+	for depth := 0; node != nil; depth++ {
+		if node.point.Equal(p) {
+			return true
+		}
+		axis := depth % t.dims
+		if p[axis] < node.point[axis] {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return false
+}
+
+// Delete removes one copy of p and reports whether there was one
+func (t *KDTree) Delete(p Point) bool {
+	if len(p) != t.dims {
+		return false
+	}
+	var deleted bool
+	t.root = t.delete(t.root, p, 0, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *KDTree) delete(node *kdNode, p Point, depth int, deleted *bool) *kdNode {
+	if node == nil {
+		return nil
+	}
+	axis := depth % t.dims
+
+	if !node.point.Equal(p) {
+		if p[axis] < node.point[axis] {
+			node.left = t.delete(node.left, p, depth+1, deleted)
+		} else {
+			node.right = t.delete(node.right, p, depth+1, deleted)
+		}
+		return node
+	}
+
+	*deleted = true
+	// Replace the point with the minimum on this axis from the right
+	// subtree. Without a right subtree, take it from the left one and move
+	// that subtree to the right, as points equal on the axis belong there.
+	switch {
+	case node.right != nil:
+		node.point = t.findMin(node.right, axis, depth+1)
+		var ignored bool
+		node.right = t.delete(node.right, node.point, depth+1, &ignored)
+	case node.left != nil:
+		node.point = t.findMin(node.left, axis, depth+1)
+		var ignored bool
+		node.right = t.delete(node.left, node.point, depth+1, &ignored)
+		node.left = nil
+	default:
+		return nil
+	}
+	return node
+}
+
+// findMin returns a point with the smallest coordinate on axis below node
+func (t *KDTree) findMin(node *kdNode, axis, depth int) Point {
+	best := node.point
+	if depth%t.dims == axis {
+		// Only the left subtree can hold anything smaller
+		if node.left != nil {
+			if p := t.findMin(node.left, axis, depth+1); p[axis] < best[axis] {
+				best = p
+			}
+		}
+		return best
+	}
+	for _, child := range []*kdNode{node.left, node.right} {
+		if child != nil {
+			if p := t.findMin(child, axis, depth+1); p[axis] < best[axis] {
+				best = p
+			}
+		}
+	}
+	return best
+}
+
+// Neighbor is a point found by a proximity query and its distance from
+// the query point
+type Neighbor struct {
+	Point    Point
+	Distance float64
+}
+
+// Nearest returns the point closest to q. It reports false for an empty
+// tree or a point of the wrong dimension.
+func (t *KDTree) Nearest(q Point) (Neighbor, bool) {
+	neighbors := t.KNearest(q, 1)
+	if len(neighbors) == 0 {
+		return Neighbor{}, false
+	}
+	return neighbors[0], true
+}
+
+// neighborHeap is a max-heap on squared distance holding the best
+// candidates found so far
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int           { return len(h) }
+func (h neighborHeap) Less(i, j int) bool { return h[i].Distance > h[j].Distance }
+func (h neighborHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x any)        { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// KNearest returns the k points closest to q, nearest first
+func (t *KDTree) KNearest(q Point, k int) []Neighbor {
+	if len(q) != t.dims || k <= 0 {
+		return nil
+	}
+
+	// Distances stay squared until the end
+	best := make(neighborHeap, 0, k)
+	var search func(node *kdNode, depth int)
+	search = func(node *kdNode, depth int) {
+		if node == nil {
+			return
+		}
+		d := node.point.distanceSquared(q)
+		if len(best) < k {
+			heap.Push(&best, Neighbor{node.point, d})
+		} else if d < best[0].Distance {
+			best[0] = Neighbor{node.point, d}
+			heap.Fix(&best, 0)
+		}
+
+		axis := depth % t.dims
+		diff := q[axis] - node.point[axis]
+		near, far := node.left, node.right
+		if diff >= 0 {
+			near, far = far, near
+		}
+		search(near, depth+1)
+		// The far side can only help if the splitting plane is closer
+		// than the current k-th best
+		if len(best) < k || diff*diff < best[0].Distance {
+			search(far, depth+1)
+		}
+	}
+	search(t.root, 0)
+
+	result := make([]Neighbor, len(best))
+	for i := len(best) - 1; i >= 0; i-- {
+		n := heap.Pop(&best).(Neighbor)
+		result[i] = Neighbor{slices.Clone(n.Point), math.Sqrt(n.Distance)}
+	}
+	return result
+}
+
+// WithinRadius returns the points at most radius away from q, nearest
+// first
+func (t *KDTree) WithinRadius(q Point, radius float64) []Neighbor {
+	if len(q) != t.dims || radius < 0 {
+		return nil
+	}
+
+	var result []Neighbor
+	limit := radius * radius
+	var search func(node *kdNode, depth int)
+	search = func(node *kdNode, depth int) {
+		if node == nil {
+			return
+		}
+		if d := node.point.distanceSquared(q); d <= limit {
+			result = append(result, Neighbor{slices.Clone(node.point), math.Sqrt(d)})
+		}
+		axis := depth % t.dims
+		if q[axis]-radius < node.point[axis] {
+			search(node.left, depth+1)
+		}
+		if q[axis]+radius >= node.point[axis] {
+			search(node.right, depth+1)
+		}
+	}
+	search(t.root, 0)
+
+	slices.SortStableFunc(result, func(a, b Neighbor) int {
+		switch {
+		case a.Distance < b.Distance:
+			return -1
+		case a.Distance > b.Distance:
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+// Points returns copies of all points in preorder
+func (t *KDTree) Points() []Point {
+	points := make([]Point, 0, t.size)
+	var walk func(node *kdNode)
+	walk = func(node *kdNode) {
+		if node != nil {
+			points = append(points, slices.Clone(node.point))
+			walk(node.left)
+			walk(node.right)
+		}
+	}
+	walk(t.root)
+	return points
+}
+
+// Height returns the height of the tree, -1 for an empty tree
+func (t *KDTree) Height() int {
+	var height func(node *kdNode) int
+	height = func(node *kdNode) int {
+		if node == nil {
+			return -1
+		}
+		return max(height(node.left), height(node.right)) + 1
+	}
+	return height(t.root)
+}
+
+// Size returns the number of points
+func (t *KDTree) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *KDTree) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear removes all points
+func (t *KDTree) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// Validate checks that every point lies on the correct side of each
+// ancestor's splitting plane and the size. It returns the first violation
+// found, or nil.
+func (t *KDTree) Validate() error {
+	count := 0
+	// Each node checks its subtree against its own plane
+	var check func(node *kdNode, depth int) error
+	check = func(node *kdNode, depth int) error {
+		if node == nil {
+			return nil
+		}
+		count++
+		axis := depth % t.dims
+		var err error
+		walkKD(node.left, func(p Point) {
+			if err == nil && p[axis] >= node.point[axis] {
+				err = fmt.Errorf("point %s is left of %s on axis %d", p, node.point, axis)
+			}
+		})
+		walkKD(node.right, func(p Point) {
+			if err == nil && p[axis] < node.point[axis] {
+				err = fmt.Errorf("point %s is right of %s on axis %d", p, node.point, axis)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if err := check(node.left, depth+1); err != nil {
+			return err
+		}
+		return check(node.right, depth+1)
+	}
+
+	if err := check(t.root, 0); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("size %d does not match %d points", t.size, count)
+	}
+	return nil
+}
+
+func walkKD(node *kdNode, visit func(Point)) {
+	if node != nil {
+		visit(node.point)
+		walkKD(node.left, visit)
+		walkKD(node.right, visit)
+	}
+}
+
+// String returns a drawing of the tree with the splitting axis of each
+// node
+func (t *KDTree) String() string {
+	if t.root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("KDTree (%d dims):\n", t.dims))
+	var printTree func(node *kdNode, depth int, prefix string, isLast bool)
+	printTree = func(node *kdNode, depth int, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(fmt.Sprintf("%s axis %d\n", node.point, depth%t.dims))
+		children := []*kdNode{node.left, node.right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, depth+1, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.root, 0, "", true)
+	return result.String()
+}
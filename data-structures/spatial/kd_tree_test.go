@@ -0,0 +1,189 @@
+package spatial
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// randomPoints returns n points on a small integer grid, so duplicates
+// and ties on an axis are common
+func randomPoints(rng *rand.Rand, n, dims, size int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = make(Point, dims)
+		for j := range points[i] {
+			points[i][j] = float64(rng.Intn(size))
+		}
+	}
+	return points
+}
+
+// bruteNearest returns the distances from q to every point, sorted
+func bruteNearest(points []Point, q Point) []float64 {
+	distances := make([]float64, len(points))
+	for i, p := range points {
+		distances[i] = p.Distance(q)
+	}
+	sort.Float64s(distances)
+	return distances
+}
+
+func neighborDistances(neighbors []Neighbor) []float64 {
+	distances := make([]float64, len(neighbors))
+	for i, n := range neighbors {
+		distances[i] = n.Distance
+	}
+	return distances
+}
+
+func TestKDTree(t *testing.T) {
+	tree := NewKDTree(2)
+	if !tree.IsEmpty() || tree.Height() != -1 || tree.String() != "Empty tree" {
+		t.Error("Expected tree to be empty")
+	}
+	if _, found := tree.Nearest(Point{0, 0}); found {
+		t.Error("Expected no nearest point in an empty tree")
+	}
+
+	points := []Point{{2, 3}, {5, 4}, {9, 6}, {4, 7}, {8, 1}, {7, 2}}
+	tree, err := NewKDTreeFrom(2, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Size() != 6 || tree.Height() != 2 {
+		t.Errorf("Expected 6 points at height 2, got %d at %d\n%s", tree.Size(), tree.Height(), tree)
+	}
+	want := "KDTree (2 dims):\n" +
+		"└── (7, 2) axis 0\n" +
+		"    ├── (5, 4) axis 1\n" +
+		"    │   ├── (2, 3) axis 0\n" +
+		"    │   └── (4, 7) axis 0\n" +
+		"    └── (9, 6) axis 1\n" +
+		"        ├── (8, 1) axis 0\n"
+	if tree.String() != want {
+		t.Errorf("Unexpected drawing:\n%s", tree)
+	}
+
+	if n, _ := tree.Nearest(Point{9, 2}); !n.Point.Equal(Point{8, 1}) || n.Distance != math.Sqrt2 {
+		t.Errorf("Expected (8, 1) at distance √2, got %s at %v", n.Point, n.Distance)
+	}
+	if got := tree.KNearest(Point{5, 3}, 2); len(got) != 2 || !got[0].Point.Equal(Point{5, 4}) || !got[1].Point.Equal(Point{7, 2}) {
+		t.Errorf("Expected (5, 4) then (7, 2), got %v", got)
+	}
+	if got := tree.WithinRadius(Point{3, 6}, 3); len(got) != 2 || !got[0].Point.Equal(Point{4, 7}) || !got[1].Point.Equal(Point{5, 4}) {
+		t.Errorf("Expected (4, 7) then (5, 4) within 3, got %v", got)
+	}
+
+	// Results are copies
+	tree.Points()[0][0] = 100
+	if !tree.Contains(Point{7, 2}) {
+		t.Error("Expected Points to return copies")
+	}
+
+	if err := tree.Insert(Point{1, 2, 3}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewKDTreeFrom(2, []Point{{1, 2}, {3}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+
+	// Points need at least one coordinate to split on
+	zero := NewKDTree(0)
+	if zero.Dims() != 1 || !errors.Is(zero.Insert(Point{}), ErrDimensionMismatch) {
+		t.Errorf("Expected dims 0 to be raised to 1, got %d", zero.Dims())
+	}
+	if _, err := NewKDTreeFrom(-1, []Point{{}, {}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+
+	if !tree.Delete(Point{7, 2}) || tree.Delete(Point{7, 2}) || tree.Contains(Point{7, 2}) {
+		t.Error("Expected (7, 2) to be deleted once")
+	}
+	if err := tree.Validate(); err != nil || tree.Size() != 5 {
+		t.Errorf("Expected a valid tree of 5 points, got %v\n%s", err, tree)
+	}
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Error("Expected tree to be empty after clear")
+	}
+}
+
+func TestKDTreeAgainstBruteForce(t *testing.T) {
+	for _, dims := range []int{1, 2, 3} {
+		rng := rand.New(rand.NewSource(int64(dims)))
+		initial := randomPoints(rng, 200, dims, 20)
+		tree, err := NewKDTreeFrom(dims, initial)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reference := slices.Clone(initial)
+
+		for step := 0; step < 1500; step++ {
+			p := randomPoints(rng, 1, dims, 20)[0]
+			switch rng.Intn(4) {
+			case 0:
+				i := slices.IndexFunc(reference, p.Equal)
+				if tree.Delete(p) != (i >= 0) {
+					t.Fatalf("dims %d: Delete(%s) disagrees with reference", dims, p)
+				}
+				if i >= 0 {
+					reference = slices.Delete(reference, i, i+1)
+				}
+			case 1:
+				k := rng.Intn(8)
+				want := bruteNearest(reference, p)[:min(k, len(reference))]
+				if got := neighborDistances(tree.KNearest(p, k)); !slices.Equal(got, want) {
+					t.Fatalf("dims %d: KNearest(%s, %d) = %v, want %v", dims, p, k, got, want)
+				}
+				if n, found := tree.Nearest(p); found != (len(reference) > 0) || (found && n.Distance != bruteNearest(reference, p)[0]) {
+					t.Fatalf("dims %d: Nearest(%s) = %v", dims, p, n)
+				}
+			case 2:
+				radius := float64(rng.Intn(6))
+				var want []float64
+				for _, d := range bruteNearest(reference, p) {
+					if d <= radius {
+						want = append(want, d)
+					}
+				}
+				if got := neighborDistances(tree.WithinRadius(p, radius)); !slices.Equal(got, want) {
+					t.Fatalf("dims %d: WithinRadius(%s, %v) = %v, want %v", dims, p, radius, got, want)
+				}
+			default:
+				if err := tree.Insert(p); err != nil {
+					t.Fatal(err)
+				}
+				reference = append(reference, p)
+			}
+			if tree.Contains(p) != slices.ContainsFunc(reference, p.Equal) {
+				t.Fatalf("dims %d: Contains(%s) disagrees with reference", dims, p)
+			}
+		}
+
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("dims %d: %v", dims, err)
+		}
+		if tree.Size() != len(reference) || len(tree.Points()) != len(reference) {
+			t.Errorf("dims %d: expected %d points, got %d", dims, len(reference), tree.Size())
+		}
+	}
+}
+
+func TestKDTreeBulkLoadIsBalanced(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := make([]Point, 1<<10)
+	for i := range points {
+		points[i] = Point{rng.Float64(), rng.Float64()}
+	}
+	tree, _ := NewKDTreeFrom(2, points)
+	if h := tree.Height(); h != 10 {
+		t.Errorf("Expected height 10 for 1024 distinct points, got %d", h)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,81 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrDimensionMismatch is returned for a point with the wrong number of
+	// coordinates
+	ErrDimensionMismatch = errors.New("point has the wrong number of dimensions")
+	// ErrOutOfBounds is returned for a point outside a quadtree's bounds
+	ErrOutOfBounds = errors.New("point is outside the bounds")
+)
+
+// Point is a position with one coordinate per dimension
+type Point []float64
+
+// Equal checks if both points have the same coordinates
+func (p Point) Equal(q Point) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Distance returns the Euclidean distance between p and q
+func (p Point) Distance(q Point) float64 {
+	return math.Sqrt(p.distanceSquared(q))
+}
+
+func (p Point) distanceSquared(q Point) float64 {
+	sum := 0.0
+	for i := range p {
+		d := p[i] - q[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// String returns the point as (x, y, ...)
+func (p Point) String() string {
+	var result strings.Builder
+	result.WriteString("(")
+	for i, c := range p {
+		if i > 0 {
+			result.WriteString(", ")
+		}
+		result.WriteString(fmt.Sprint(c))
+	}
+	result.WriteString(")")
+	return result.String()
+}
+
+// Rect is an axis-aligned rectangle, including its edges
+type Rect struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+}
+
+// Contains checks if the 2D point p lies inside or on the edge of r
+func (r Rect) Contains(p Point) bool {
+	return len(p) == 2 && r.MinX <= p[0] && p[0] <= r.MaxX && r.MinY <= p[1] && p[1] <= r.MaxY
+}
+
+// Intersects checks if r and other share at least one point
+func (r Rect) Intersects(other Rect) bool {
+	return r.MinX <= other.MaxX && other.MinX <= r.MaxX && r.MinY <= other.MaxY && other.MinY <= r.MaxY
+}
+
+// String returns the rectangle as [minX, maxX] x [minY, maxY]
+func (r Rect) String() string {
+	return fmt.Sprintf("[%v, %v] x [%v, %v]", r.MinX, r.MaxX, r.MinY, r.MaxY)
+}
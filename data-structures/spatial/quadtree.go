@@ -0,0 +1,272 @@
+package spatial
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+const (
+	// DefaultQuadtreeCapacity is the number of points a leaf holds before
+	// it splits, used when none is given
+	DefaultQuadtreeCapacity = 4
+	// MaxQuadtreeDepth stops splitting, so many copies of one point cannot
+	// make the tree grow without end
+	MaxQuadtreeDepth = 24
+)
+
+// quadNode is a node in a Quadtree. A leaf holds points; an internal node
+// has four children covering the quadrants of its bounds.
+type quadNode struct {
+	bounds   Rect
+	points   []Point
+	children []*quadNode // nil for a leaf, otherwise SW, SE, NW, NE
+}
+
+func (n *quadNode) leaf() bool {
+	return n.children == nil
+}
+
+// quadrant returns the index of the child whose quadrant holds p. Points
+// on a dividing line go to the east or north side.
+func (n *quadNode) quadrant(p Point) int {
+	midX := (n.bounds.MinX + n.bounds.MaxX) / 2
+	midY := (n.bounds.MinY + n.bounds.MaxY) / 2
+	i := 0
+	if p[0] >= midX {
+		i++
+	}
+	if p[1] >= midY {
+		i += 2
+	}
+	return i
+}
+
+// split turns a leaf into an internal node and hands its points down
+func (n *quadNode) split() {
+	b := n.bounds
+	midX, midY := (b.MinX+b.MaxX)/2, (b.MinY+b.MaxY)/2
+	n.children = []*quadNode{
+		{bounds: Rect{b.MinX, b.MinY, midX, midY}},
+		{bounds: Rect{midX, b.MinY, b.MaxX, midY}},
+		{bounds: Rect{b.MinX, midY, midX, b.MaxY}},
+		{bounds: Rect{midX, midY, b.MaxX, b.MaxY}},
+	}
+	for _, p := range n.points {
+		child := n.children[n.quadrant(p)]
+		child.points = append(child.points, p)
+	}
+	n.points = nil
+}
+
+// Quadtree represents a point-region quadtree over a fixed rectangle.
+// Each leaf holds up to a fixed number of points and splits into four
+// equal quadrants when it overflows, so dense areas get finer cells.
+// Rectangle queries skip every cell that misses the rectangle. Duplicate
+// points are kept.
+type Quadtree struct {
+	root     *quadNode
+	capacity int
+	size     int
+}
+
+// NewQuadtree creates a new empty quadtree covering bounds whose leaves
+// hold up to capacity points. A capacity below 1 uses
+// DefaultQuadtreeCapacity.
+func NewQuadtree(bounds Rect, capacity int) *Quadtree {
+	if capacity < 1 {
+		capacity = DefaultQuadtreeCapacity
+	}
+	return &Quadtree{root: &quadNode{bounds: bounds}, capacity: capacity}
+}
+
+// NewQuadtreeFrom creates a quadtree covering bounds and inserts points.
+// It fails without inserting anything if a point is not 2D or lies
+// outside bounds.
+func NewQuadtreeFrom(bounds Rect, capacity int, points []Point) (*Quadtree, error) {
+	t := NewQuadtree(bounds, capacity)
+	for _, p := range points {
+		if err := t.check(p); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range points {
+		t.Insert(p)
+	}
+	return t, nil
+}
+
+func (t *Quadtree) check(p Point) error {
+	if len(p) != 2 {
+		return fmt.Errorf("%w: %d coordinates, want 2", ErrDimensionMismatch, len(p))
+	}
+	if !t.root.bounds.Contains(p) {
+		return fmt.Errorf("%w: %s is not in %s", ErrOutOfBounds, p, t.root.bounds)
+	}
+	return nil
+}
+
+// Bounds returns the rectangle the tree covers
+func (t *Quadtree) Bounds() Rect {
+	return t.root.bounds
+}
+
+// Insert adds a copy of p, which must lie within the bounds
+func (t *Quadtree) Insert(p Point) error {
+	if err := t.check(p); err != nil {
+		return err
+	}
+	node := t.root
+	for depth := 0; ; depth++ {
+		if node.leaf() {
+			if len(node.points) < t.capacity || depth == MaxQuadtreeDepth {
+				node.points = append(node.points, slices.Clone(p))
+				t.size++
+				return nil
+			}
+			node.split()
+		}
+		node = node.children[node.quadrant(p)]
+	}
+}
+
+// Contains checks if the tree holds p
+func (t *Quadtree) Contains(p Point) bool {
+	if t.check(p) != nil {
+		return false
+	}
+	node := t.root
+	for !node.leaf() {
+		node = node.children[node.quadrant(p)]
+	}
+	return slices.ContainsFunc(node.points, p.Equal)
+}
+
+// Delete removes one copy of p and reports whether there was one. Cells
+// whose quadrants together fit in one leaf are merged back.
+func (t *Quadtree) Delete(p Point) bool {
+	if t.check(p) != nil {
+		return false
+	}
+
+	path := []*quadNode{t.root}
+	for node := t.root; !node.leaf(); {
+		node = node.children[node.quadrant(p)]
+		path = append(path, node)
+	}
+	leaf := path[len(path)-1]
+	i := slices.IndexFunc(leaf.points, p.Equal)
+	if i < 0 {
+		return false
+	}
+	leaf.points = slices.Delete(leaf.points, i, i+1)
+	t.size--
+
+	for i := len(path) - 2; i >= 0; i-- {
+		if !path[i].collapse(t.capacity) {
+			break
+		}
+	}
+	return true
+}
+
+// collapse merges the children of n back into it if they are all leaves
+// holding at most capacity points between them
+func (n *quadNode) collapse(capacity int) bool {
+	total := 0
+	for _, child := range n.children {
+		if !child.leaf() {
+			return false
+		}
+		total += len(child.points)
+	}
+	if total > capacity {
+		return false
+	}
+	for _, child := range n.children {
+		n.points = append(n.points, child.points...)
+	}
+	n.children = nil
+	return true
+}
+
+// Query returns copies of the points inside or on the edge of r
+func (t *Quadtree) Query(r Rect) []Point {
+	var result []Point
+	var search func(node *quadNode)
+	search = func(node *quadNode) {
+		if !node.bounds.Intersects(r) {
+			return
+		}
+		if node.leaf() {
+			for _, p := range node.points {
+				if r.Contains(p) {
+					result = append(result, slices.Clone(p))
+				}
+			}
+			return
+		}
+		for _, child := range node.children {
+			search(child)
+		}
+	}
+	search(t.root)
+	return result
+}
+
+// Points returns copies of all points
+func (t *Quadtree) Points() []Point {
+	return t.Query(t.root.bounds)
+}
+
+// Height returns the depth of the deepest leaf
+func (t *Quadtree) Height() int {
+	var height func(node *quadNode) int
+	height = func(node *quadNode) int {
+		h := 0
+		for _, child := range node.children {
+			h = max(h, height(child)+1)
+		}
+		return h
+	}
+	return height(t.root)
+}
+
+// Size returns the number of points
+func (t *Quadtree) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *Quadtree) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all points, keeping the bounds
+func (t *Quadtree) Clear() {
+	t.root = &quadNode{bounds: t.root.bounds}
+	t.size = 0
+}
+
+// String returns a drawing of the tree with the bounds and points of each
+// cell
+func (t *Quadtree) String() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Quadtree (capacity %d):\n", t.capacity))
+	var printTree func(node *quadNode, prefix string, isLast bool)
+	printTree = func(node *quadNode, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(node.bounds.String())
+		if node.leaf() {
+			result.WriteString(fmt.Sprintf(": %v", node.points))
+		}
+		result.WriteString("\n")
+		for i, child := range node.children {
+			printTree(child, childPrefix, i == len(node.children)-1)
+		}
+	}
+	printTree(t.root, "", true)
+	return result.String()
+}
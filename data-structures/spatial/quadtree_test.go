@@ -0,0 +1,141 @@
+package spatial
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// sortPoints orders points by x, then y, for comparing results
+func sortPoints(points []Point) []Point {
+	slices.SortFunc(points, func(a, b Point) int {
+		return slices.Compare(a, b)
+	})
+	return points
+}
+
+func TestQuadtree(t *testing.T) {
+	bounds := Rect{0, 0, 8, 8}
+	tree := NewQuadtree(bounds, 2)
+	if !tree.IsEmpty() || tree.Height() != 0 || tree.Bounds() != bounds {
+		t.Error("Expected an empty quadtree")
+	}
+
+	for _, p := range []Point{{1, 1}, {6, 1}, {2, 2}} {
+		if err := tree.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "Quadtree (capacity 2):\n" +
+		"└── [0, 8] x [0, 8]\n" +
+		"    ├── [0, 4] x [0, 4]: [(1, 1) (2, 2)]\n" +
+		"    ├── [4, 8] x [0, 4]: [(6, 1)]\n" +
+		"    ├── [0, 4] x [4, 8]: []\n" +
+		"    └── [4, 8] x [4, 8]: []\n"
+	if tree.String() != want {
+		t.Errorf("Unexpected drawing:\n%s", tree)
+	}
+
+	// Points on the edge of the bounds or a query are included
+	if err := tree.Insert(Point{8, 8}); err != nil {
+		t.Fatal(err)
+	}
+	if got := sortPoints(tree.Query(Rect{2, 1, 8, 8})); len(got) != 3 || !got[0].Equal(Point{2, 2}) {
+		t.Errorf("Expected (2, 2), (6, 1) and (8, 8), got %v", got)
+	}
+
+	if err := tree.Insert(Point{9, 1}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+	if err := tree.Insert(Point{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewQuadtreeFrom(bounds, 2, []Point{{1, 1}, {-1, 1}}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+
+	// Deleting down to the capacity merges the quadrants again
+	if !tree.Delete(Point{6, 1}) || !tree.Delete(Point{8, 8}) || tree.Delete(Point{8, 8}) {
+		t.Error("Expected each point to be deleted once")
+	}
+	if tree.Height() != 0 || tree.Size() != 2 || !tree.Contains(Point{2, 2}) {
+		t.Errorf("Expected one leaf with 2 points, got\n%s", tree)
+	}
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Contains(Point{2, 2}) {
+		t.Error("Expected quadtree to be empty after clear")
+	}
+}
+
+func TestQuadtreeDuplicates(t *testing.T) {
+	// More copies of one point than a leaf holds stop splitting at the
+	// depth limit
+	tree := NewQuadtree(Rect{0, 0, 1, 1}, 1)
+	for i := 0; i < 5; i++ {
+		tree.Insert(Point{0.3, 0.3})
+	}
+	if tree.Size() != 5 || tree.Height() != MaxQuadtreeDepth {
+		t.Errorf("Expected 5 points at depth %d, got %d at %d", MaxQuadtreeDepth, tree.Size(), tree.Height())
+	}
+	for i := 0; i < 5; i++ {
+		if !tree.Delete(Point{0.3, 0.3}) {
+			t.Fatalf("Expected copy %d to be deleted", i)
+		}
+	}
+	if !tree.IsEmpty() || tree.Height() != 0 {
+		t.Errorf("Expected an empty leaf, got\n%s", tree)
+	}
+}
+
+func TestQuadtreeAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bounds := Rect{0, 0, 63, 63}
+	reference := randomPoints(rng, 300, 2, 64)
+	tree, err := NewQuadtreeFrom(bounds, 4, reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for step := 0; step < 2000; step++ {
+		p := randomPoints(rng, 1, 2, 64)[0]
+		switch rng.Intn(3) {
+		case 0:
+			i := slices.IndexFunc(reference, p.Equal)
+			if tree.Delete(p) != (i >= 0) {
+				t.Fatalf("Delete(%s) disagrees with reference", p)
+			}
+			if i >= 0 {
+				reference = slices.Delete(reference, i, i+1)
+			}
+		case 1:
+			q := randomPoints(rng, 1, 2, 64)[0]
+			r := Rect{min(p[0], q[0]), min(p[1], q[1]), max(p[0], q[0]), max(p[1], q[1])}
+			var want []Point
+			for _, p := range reference {
+				if r.Contains(p) {
+					want = append(want, p)
+				}
+			}
+			got := sortPoints(tree.Query(r))
+			if !slices.EqualFunc(got, sortPoints(want), Point.Equal) {
+				t.Fatalf("Query(%s) = %v, want %v", r, got, want)
+			}
+		default:
+			if err := tree.Insert(p); err != nil {
+				t.Fatal(err)
+			}
+			reference = append(reference, p)
+		}
+		if tree.Contains(p) != slices.ContainsFunc(reference, p.Equal) {
+			t.Fatalf("Contains(%s) disagrees with reference", p)
+		}
+	}
+
+	if tree.Size() != len(reference) {
+		t.Errorf("Expected %d points, got %d", len(reference), tree.Size())
+	}
+	if !slices.EqualFunc(sortPoints(tree.Points()), sortPoints(reference), Point.Equal) {
+		t.Error("Expected Points to match the reference")
+	}
+}
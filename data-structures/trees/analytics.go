@@ -0,0 +1,302 @@
+package trees
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BSTViolation is the error ValidateBST returns for a node whose value lies
+// outside the range its ancestors allow
+type BSTViolation struct {
+	Node *TreeNode
+	Lo   *int // exclusive lower bound, nil if unbounded
+	Hi   *int // exclusive upper bound, nil if unbounded
+}
+
+func (v *BSTViolation) Error() string {
+	var bounds []string
+	if v.Lo != nil {
+		bounds = append(bounds, fmt.Sprintf("> %d", *v.Lo))
+	}
+	if v.Hi != nil {
+		bounds = append(bounds, fmt.Sprintf("< %d", *v.Hi))
+	}
+	return fmt.Sprintf("node %d is out of order: must be %s", v.Node.Value, strings.Join(bounds, " and "))
+}
+
+// ValidateBST checks the search tree order, which can break when Root is
+// edited directly or the tree was parsed from an arbitrary shape. It
+// returns a *BSTViolation for the first offending node in preorder, or nil.
+func (bt *BinaryTree) ValidateBST() error {
+	var check func(node *TreeNode, lo, hi *int) error
+	check = func(node *TreeNode, lo, hi *int) error {
+		if node == nil {
+			return nil
+		}
+		if (lo != nil && node.Value <= *lo) || (hi != nil && node.Value >= *hi) {
+			return &BSTViolation{Node: node, Lo: lo, Hi: hi}
+		}
+		if err := check(node.Left, lo, &node.Value); err != nil {
+			return err
+		}
+		return check(node.Right, &node.Value, hi)
+	}
+	return check(bt.Root, nil, nil)
+}
+
+// IsValidBST checks if every node is within the range its ancestors allow
+func (bt *BinaryTree) IsValidBST() bool {
+	return bt.ValidateBST() == nil
+}
+
+// BalanceReport summarizes how far a tree is from perfectly balanced
+type BalanceReport struct {
+	Height          int       // edges on the longest root-to-leaf path, -1 when empty
+	MinLeafDepth    int       // edges on the shortest root-to-leaf path, -1 when empty
+	OptimalHeight   int       // height of a complete tree with as many nodes
+	MaxImbalance    int       // largest |height(left) - height(right)| of any node
+	MostUnbalanced  *TreeNode // a node with MaxImbalance, nil when empty
+	UnbalancedNodes int       // nodes whose subtrees differ in height by more than 1
+}
+
+// Balanced reports whether every node's subtrees differ in height by at most 1
+func (r BalanceReport) Balanced() bool {
+	return r.UnbalancedNodes == 0
+}
+
+// String returns a one-line summary of the report
+func (r BalanceReport) String() string {
+	return fmt.Sprintf("height %d (optimal %d), min leaf depth %d, max imbalance %d, %d unbalanced nodes",
+		r.Height, r.OptimalHeight, r.MinLeafDepth, r.MaxImbalance, r.UnbalancedNodes)
+}
+
+// Balance returns a BalanceReport for the tree
+func (bt *BinaryTree) Balance() BalanceReport {
+	report := BalanceReport{Height: -1, MinLeafDepth: -1, OptimalHeight: -1}
+
+	nodes := 0
+	// walk returns the height of the subtree rooted at node
+	var walk func(node *TreeNode, depth int) int
+	walk = func(node *TreeNode, depth int) int {
+		if node == nil {
+			return -1
+		}
+		nodes++
+		if node.Left == nil && node.Right == nil {
+			if report.MinLeafDepth < 0 || depth < report.MinLeafDepth {
+				report.MinLeafDepth = depth
+			}
+		}
+
+		left, right := walk(node.Left, depth+1), walk(node.Right, depth+1)
+		imbalance := max(left-right, right-left)
+		if imbalance > 1 {
+			report.UnbalancedNodes++
+		}
+		if report.MostUnbalanced == nil || imbalance > report.MaxImbalance {
+			report.MaxImbalance = imbalance
+			report.MostUnbalanced = node
+		}
+		return max(left, right) + 1
+	}
+
+	report.Height = walk(bt.Root, 0)
+	for capacity := 0; capacity < nodes; capacity = 2*capacity + 1 {
+		report.OptimalHeight++
+	}
+	return report
+}
+
+// IsBalanced checks if every node's subtrees differ in height by at most 1
+func (bt *BinaryTree) IsBalanced() bool {
+	return bt.Balance().Balanced()
+}
+
+// LowestCommonAncestor returns the deepest value that has both a and b in
+// its subtree, where a value counts as its own ancestor. It does not rely
+// on search order, so it works for any shape. It reports false if either
+// value is missing.
+func (bt *BinaryTree) LowestCommonAncestor(a, b int) (int, bool) {
+	foundA, foundB := false, false
+	// find returns the LCA if both values are below node, otherwise the
+	// node holding whichever value it saw
+	var find func(node *TreeNode) *TreeNode
+	find = func(node *TreeNode) *TreeNode {
+		if node == nil {
+			return nil
+		}
+		left, right := find(node.Left), find(node.Right)
+		isA, isB := node.Value == a, node.Value == b
+		foundA = foundA || isA
+		foundB = foundB || isB
+		if isA || isB || (left != nil && right != nil) {
+			return node
+		}
+		if left != nil {
+			return left
+		}
+		return right
+	}
+
+	lca := find(bt.Root)
+	if !foundA || !foundB {
+		return 0, false
+	}
+	return lca.Value, true
+}
+
+// Diameter returns the number of edges on the longest path between any two
+// nodes, or -1 for an empty tree
+func (bt *BinaryTree) Diameter() int {
+	diameter := -1
+	var height func(node *TreeNode) int
+	height = func(node *TreeNode) int {
+		if node == nil {
+			return -1
+		}
+		left, right := height(node.Left), height(node.Right)
+		diameter = max(diameter, left+right+2)
+		return max(left, right) + 1
+	}
+	height(bt.Root)
+	return diameter
+}
+
+// The path sums below add each node's value once, ignoring multiset copies.
+
+// MaxRootToLeafSum returns the largest sum of values on a path from the
+// root to a leaf. It reports false for an empty tree.
+func (bt *BinaryTree) MaxRootToLeafSum() (int, bool) {
+	if bt.Root == nil {
+		return 0, false
+	}
+	var best func(node *TreeNode) int
+	best = func(node *TreeNode) int {
+		switch {
+		case node.Left == nil && node.Right == nil:
+			return node.Value
+		case node.Left == nil:
+			return node.Value + best(node.Right)
+		case node.Right == nil:
+			return node.Value + best(node.Left)
+		}
+		return node.Value + max(best(node.Left), best(node.Right))
+	}
+	return best(bt.Root), true
+}
+
+// MaxPathSum returns the largest sum of values on any downward-then-upward
+// path between two nodes, which may be a single node. It reports false for
+// an empty tree.
+func (bt *BinaryTree) MaxPathSum() (int, bool) {
+	if bt.Root == nil {
+		return 0, false
+	}
+	result := bt.Root.Value
+	// gain returns the best sum of a path that starts at node and goes down
+	var gain func(node *TreeNode) int
+	gain = func(node *TreeNode) int {
+		if node == nil {
+			return 0
+		}
+		left, right := max(gain(node.Left), 0), max(gain(node.Right), 0)
+		result = max(result, node.Value+left+right)
+		return node.Value + max(left, right)
+	}
+	gain(bt.Root)
+	return result, true
+}
+
+// PathsWithSum returns every root-to-leaf path whose values add up to
+// target, left to right
+func (bt *BinaryTree) PathsWithSum(target int) [][]int {
+	var result [][]int
+	var path []int
+	var walk func(node *TreeNode, remaining int)
+	walk = func(node *TreeNode, remaining int) {
+		if node == nil {
+			return
+		}
+		path = append(path, node.Value)
+		remaining -= node.Value
+		if node.Left == nil && node.Right == nil && remaining == 0 {
+			result = append(result, append([]int(nil), path...))
+		}
+		walk(node.Left, remaining)
+		walk(node.Right, remaining)
+		path = path[:len(path)-1]
+	}
+	walk(bt.Root, target)
+	return result
+}
+
+// Mirror returns a copy of the tree with every left and right child
+// swapped. The copy holds its values in descending order, so it is not a
+// valid search tree unless it has at most one value.
+func (bt *BinaryTree) Mirror() *BinaryTree {
+	var mirror func(node *TreeNode) *TreeNode
+	mirror = func(node *TreeNode) *TreeNode {
+		if node == nil {
+			return nil
+		}
+		return &TreeNode{
+			Value: node.Value,
+			Left:  mirror(node.Right),
+			Right: mirror(node.Left),
+			count: node.count,
+			size:  node.size,
+		}
+	}
+	return &BinaryTree{Root: mirror(bt.Root), mode: bt.mode}
+}
+
+// IsMirrorOf checks if other has the shape and values of this tree
+// reflected left to right
+func (bt *BinaryTree) IsMirrorOf(other *BinaryTree) bool {
+	return isMirror(bt.Root, other.Root)
+}
+
+// IsSymmetric checks if the tree is its own mirror image
+func (bt *BinaryTree) IsSymmetric() bool {
+	return bt.Root == nil || isMirror(bt.Root.Left, bt.Root.Right)
+}
+
+func isMirror(a, b *TreeNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value && isMirror(a.Left, b.Right) && isMirror(a.Right, b.Left)
+}
+
+// Equal checks if other has the same shape and values as this tree
+func (bt *BinaryTree) Equal(other *BinaryTree) bool {
+	return sameTree(bt.Root, other.Root)
+}
+
+func sameTree(a, b *TreeNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value && sameTree(a.Left, b.Left) && sameTree(a.Right, b.Right)
+}
+
+// HasSubtree checks if some node's subtree, down to the leaves, has the
+// same shape and values as other. An empty tree is a subtree of any tree.
+func (bt *BinaryTree) HasSubtree(other *BinaryTree) bool {
+	if other.Root == nil {
+		return true
+	}
+	stack := []*TreeNode{bt.Root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			continue
+		}
+		if node.Value == other.Root.Value && sameTree(node, other.Root) {
+			return true
+		}
+		stack = append(stack, node.Right, node.Left)
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) *BinaryTree {
+	t.Helper()
+	bt, err := ParseLeetCode(s)
+	if err != nil {
+		t.Fatalf("ParseLeetCode(%q): %v", s, err)
+	}
+	return bt
+}
+
+func TestValidateBST(t *testing.T) {
+	bt := sampleTree()
+	if err := bt.ValidateBST(); err != nil || !bt.IsValidBST() {
+		t.Errorf("Expected a valid BST, got %v", err)
+	}
+
+	// 45 sits in 50's right subtree via a direct edit
+	bt.Root.Right.Left.Left = &TreeNode{Value: 45, count: 1, size: 1}
+	err := bt.ValidateBST()
+	var violation *BSTViolation
+	if !errors.As(err, &violation) || violation.Node.Value != 45 {
+		t.Fatalf("Expected a violation at 45, got %v", err)
+	}
+	if err.Error() != "node 45 is out of order: must be > 50 and < 60" {
+		t.Errorf("Unexpected message %q", err)
+	}
+
+	// The first violation in preorder is reported
+	bt = mustParse(t, "[5,1,4,null,null,3,6]")
+	if err := bt.ValidateBST(); !errors.As(err, &violation) || violation.Node.Value != 4 {
+		t.Errorf("Expected a violation at 4, got %v", err)
+	}
+	if mustParse(t, "[2,2]").IsValidBST() {
+		t.Error("Expected a duplicate child to break the order")
+	}
+	if !NewBinaryTree().IsValidBST() {
+		t.Error("Expected an empty tree to be valid")
+	}
+}
+
+func TestBalance(t *testing.T) {
+	report := sampleTree().Balance()
+	if !report.Balanced() || report.Height != 3 || report.OptimalHeight != 3 || report.MinLeafDepth != 2 {
+		t.Errorf("Unexpected report %s", report)
+	}
+
+	chain := degenerateTree(5)
+	report = chain.Balance()
+	if report.Balanced() || chain.IsBalanced() {
+		t.Error("Expected a chain to be unbalanced")
+	}
+	if report.MaxImbalance != 4 || report.MostUnbalanced != chain.Root || report.UnbalancedNodes != 3 {
+		t.Errorf("Unexpected report %s", report)
+	}
+	if report.String() != "height 4 (optimal 2), min leaf depth 4, max imbalance 4, 3 unbalanced nodes" {
+		t.Errorf("Unexpected summary %s", report)
+	}
+
+	empty := NewBinaryTree().Balance()
+	if !empty.Balanced() || empty.Height != -1 || empty.MostUnbalanced != nil {
+		t.Errorf("Unexpected report for an empty tree %s", empty)
+	}
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	bt := sampleTree()
+	tests := []struct{ a, b, want int }{
+		{20, 45, 30},
+		{45, 80, 50},
+		{60, 80, 70},
+		{40, 45, 40},
+		{50, 50, 50},
+	}
+	for _, tt := range tests {
+		if got, ok := bt.LowestCommonAncestor(tt.a, tt.b); !ok || got != tt.want {
+			t.Errorf("LowestCommonAncestor(%d, %d) = %d, %v, want %d", tt.a, tt.b, got, ok, tt.want)
+		}
+	}
+	if _, ok := bt.LowestCommonAncestor(20, 99); ok {
+		t.Error("Expected a missing value to fail")
+	}
+
+	// Not a search tree
+	bt = mustParse(t, "[3,5,1,6,2,0,8,null,null,7,4]")
+	if got, _ := bt.LowestCommonAncestor(7, 0); got != 3 {
+		t.Errorf("Expected 3, got %d", got)
+	}
+	if got, _ := bt.LowestCommonAncestor(6, 4); got != 5 {
+		t.Errorf("Expected 5, got %d", got)
+	}
+}
+
+func TestDiameterAndPathSums(t *testing.T) {
+	bt := sampleTree()
+	if d := bt.Diameter(); d != 5 {
+		t.Errorf("Expected diameter 5 (45 to 60 or 80), got %d", d)
+	}
+	if d := NewBinaryTree().Diameter(); d != -1 {
+		t.Errorf("Expected -1 for an empty tree, got %d", d)
+	}
+
+	if sum, ok := bt.MaxRootToLeafSum(); !ok || sum != 200 {
+		t.Errorf("Expected 50+70+80 = 200, got %d", sum)
+	}
+
+	bt = mustParse(t, "[-10,9,20,null,null,15,7]")
+	if sum, _ := bt.MaxPathSum(); sum != 42 {
+		t.Errorf("Expected 15+20+7 = 42, got %d", sum)
+	}
+	if sum, _ := bt.MaxRootToLeafSum(); sum != 25 {
+		t.Errorf("Expected -10+20+15 = 25, got %d", sum)
+	}
+	if sum, _ := mustParse(t, "[-3,-1,-2]").MaxPathSum(); sum != -1 {
+		t.Errorf("Expected the single node -1, got %d", sum)
+	}
+	if _, ok := NewBinaryTree().MaxPathSum(); ok {
+		t.Error("Expected no path sum for an empty tree")
+	}
+
+	bt = mustParse(t, "[5,4,8,11,null,13,4,7,2,null,null,5,1]")
+	if got := fmt.Sprint(bt.PathsWithSum(22)); got != "[[5 4 11 2] [5 8 4 5]]" {
+		t.Errorf("Unexpected paths %s", got)
+	}
+	if paths := bt.PathsWithSum(5); len(paths) != 0 {
+		t.Errorf("Expected no paths, got %v", paths)
+	}
+}
+
+func TestMirrorAndSubtree(t *testing.T) {
+	bt := sampleTree()
+	mirror := bt.Mirror()
+	if got := fmt.Sprint(mirror.InorderTraversal()); got != "[80 70 60 50 45 40 30 20]" {
+		t.Errorf("Unexpected mirror inorder %s", got)
+	}
+	if !bt.IsMirrorOf(mirror) || !mirror.IsMirrorOf(bt) || bt.IsMirrorOf(bt) {
+		t.Error("Expected the mirror to reflect the tree")
+	}
+	if !mirror.Mirror().Equal(bt) || bt.Root == mirror.Root.Left {
+		t.Error("Expected Mirror to copy and mirroring twice to restore the shape")
+	}
+	if mirror.IsValidBST() {
+		t.Error("Expected the mirror to break search order")
+	}
+
+	if !mustParse(t, "[1,2,2,3,4,4,3]").IsSymmetric() || mustParse(t, "[1,2,2,null,3,null,3]").IsSymmetric() {
+		t.Error("Unexpected symmetry")
+	}
+	if !NewBinaryTree().IsSymmetric() {
+		t.Error("Expected an empty tree to be symmetric")
+	}
+
+	if !bt.HasSubtree(mustParse(t, "[30,20,40,null,null,null,45]")) {
+		t.Error("Expected 30's subtree to match")
+	}
+	if bt.HasSubtree(mustParse(t, "[30,20,40]")) {
+		t.Error("Expected a partial subtree not to match")
+	}
+	if !bt.HasSubtree(NewBinaryTree()) || !bt.HasSubtree(bt) {
+		t.Error("Expected the empty tree and the tree itself to match")
+	}
+}
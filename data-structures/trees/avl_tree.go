@@ -0,0 +1,277 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// AVLNode represents a node in an AVL tree
+type AVLNode struct {
+	Value  int
+	Left   *AVLNode
+	Right  *AVLNode
+	height int // edges on the longest path down to a leaf
+}
+
+// AVLTree represents a self-balancing binary search tree of ints. The
+// heights of every node's subtrees differ by at most one, so the tree
+// stays O(log n) deep even for sorted input.
+type AVLTree struct {
+	Root *AVLNode
+	size int
+}
+
+// NewAVLTree creates a new empty AVL tree
+func NewAVLTree() *AVLTree {
+	return &AVLTree{}
+}
+
+func avlHeight(node *AVLNode) int {
+	if node == nil {
+		return -1
+	}
+	return node.height
+}
+
+func (n *AVLNode) update() {
+	n.height = max(avlHeight(n.Left), avlHeight(n.Right)) + 1
+}
+
+// BalanceFactor returns the height of the right subtree minus the height
+// of the left subtree. It is always -1, 0 or 1 in a valid AVL tree.
+func (n *AVLNode) BalanceFactor() int {
+	return avlHeight(n.Right) - avlHeight(n.Left)
+}
+
+func (n *AVLNode) rotateLeft() *AVLNode {
+	right := n.Right
+	n.Right = right.Left
+	right.Left = n
+	n.update()
+	right.update()
+	return right
+}
+
+func (n *AVLNode) rotateRight() *AVLNode {
+	left := n.Left
+	n.Left = left.Right
+	left.Right = n
+	n.update()
+	left.update()
+	return left
+}
+
+// rebalance restores the AVL property at node after one of its subtrees
+// changed height by one
+func (n *AVLNode) rebalance() *AVLNode {
+	n.update()
+	switch bf := n.BalanceFactor(); {
+	case bf > 1:
+		if n.Right.BalanceFactor() < 0 {
+			n.Right = n.Right.rotateRight() // right-left case
+		}
+		return n.rotateLeft()
+	case bf < -1:
+		if n.Left.BalanceFactor() > 0 {
+			n.Left = n.Left.rotateLeft() // left-right case
+		}
+		return n.rotateRight()
+	}
+	return n
+}
+
+// Insert inserts a value into the tree; duplicates are ignored
+func (t *AVLTree) Insert(value int) {
+	t.Root = t.insertRec(t.Root, value)
+}
+
+func (t *AVLTree) insertRec(node *AVLNode, value int) *AVLNode {
+	if node == nil {
+		t.size++
+		return &AVLNode{Value: value}
+	}
+
+	if value < node.Value {
+		node.Left = t.insertRec(node.Left, value)
+	} else if value > node.Value {
+		node.Right = t.insertRec(node.Right, value)
+	} else {
+		return node
+	}
+	return node.rebalance()
+}
+
+// Search searches for a value in the tree
+func (t *AVLTree) Search(value int) bool {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			return true
+		}
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Delete removes a value from the tree
+func (t *AVLTree) Delete(value int) {
+	t.Root = t.deleteRec(t.Root, value)
+}
+
+func (t *AVLTree) deleteRec(node *AVLNode, value int) *AVLNode {
+	if node == nil {
+		return nil
+	}
+
+	if value < node.Value {
+		node.Left = t.deleteRec(node.Left, value)
+	} else if value > node.Value {
+		node.Right = t.deleteRec(node.Right, value)
+	} else {
+		if node.Left == nil || node.Right == nil {
+			t.size--
+			if node.Left == nil {
+				return node.Right
+			}
+			return node.Left
+		}
+
+		// Node with two children: take over the inorder successor
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		node.Value = successor.Value
+		node.Right = t.deleteRec(node.Right, successor.Value)
+	}
+	return node.rebalance()
+}
+
+// InorderTraversal returns values in inorder traversal (left, root, right)
+func (t *AVLTree) InorderTraversal() []int {
+	var result []int
+	var walk func(node *AVLNode)
+	walk = func(node *AVLNode) {
+		if node != nil {
+			walk(node.Left)
+			result = append(result, node.Value)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PreorderTraversal returns values in preorder traversal (root, left, right)
+func (t *AVLTree) PreorderTraversal() []int {
+	var result []int
+	var walk func(node *AVLNode)
+	walk = func(node *AVLNode) {
+		if node != nil {
+			result = append(result, node.Value)
+			walk(node.Left)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PostorderTraversal returns values in postorder traversal (left, right, root)
+func (t *AVLTree) PostorderTraversal() []int {
+	var result []int
+	var walk func(node *AVLNode)
+	walk = func(node *AVLNode) {
+		if node != nil {
+			walk(node.Left)
+			walk(node.Right)
+			result = append(result, node.Value)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// Height returns the height of the tree in O(1)
+func (t *AVLTree) Height() int {
+	return avlHeight(t.Root)
+}
+
+// IsEmpty checks if the tree is empty
+func (t *AVLTree) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Size returns the number of nodes in the tree
+func (t *AVLTree) Size() int {
+	return t.size
+}
+
+// Validate checks the search tree order, the stored heights and the
+// balance factor of every node, and the node count. It returns the first
+// violation found, or nil.
+func (t *AVLTree) Validate() error {
+	count := 0
+	var check func(node *AVLNode, lo, hi *int) error
+	check = func(node *AVLNode, lo, hi *int) error {
+		if node == nil {
+			return nil
+		}
+		count++
+		if (lo != nil && node.Value <= *lo) || (hi != nil && node.Value >= *hi) {
+			return fmt.Errorf("node %d is out of order", node.Value)
+		}
+		if err := check(node.Left, lo, &node.Value); err != nil {
+			return err
+		}
+		if err := check(node.Right, &node.Value, hi); err != nil {
+			return err
+		}
+		if want := max(avlHeight(node.Left), avlHeight(node.Right)) + 1; node.height != want {
+			return fmt.Errorf("node %d has height %d, want %d", node.Value, node.height, want)
+		}
+		if bf := node.BalanceFactor(); bf < -1 || bf > 1 {
+			return fmt.Errorf("node %d has balance factor %d", node.Value, bf)
+		}
+		return nil
+	}
+
+	if err := check(t.Root, nil, nil); err != nil {
+		return err
+	}
+	if count != t.size {
+		return errors.New("size does not match the number of nodes")
+	}
+	return nil
+}
+
+// String returns a string representation of the tree with the balance
+// factor of each node
+func (t *AVLTree) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("AVL Tree:\n")
+	var printTree func(node *AVLNode, prefix string, isLast bool)
+	printTree = func(node *AVLNode, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(fmt.Sprintf("%d [%+d]\n", node.Value, node.BalanceFactor()))
+		children := []*AVLNode{node.Left, node.Right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.Root, "", true)
+	return result.String()
+}
@@ -2,42 +2,114 @@ package trees
 
 import (
 	"fmt"
+	"iter"
 	"strings"
 )
 
-// TreeNode represents a node in a binary tree
+// TreeNode represents a node in a binary tree. Nodes may be built and
+// linked by hand; a node that was never inserted holds one copy of Value.
 type TreeNode struct {
 	Value int
 	Left  *TreeNode
 	Right *TreeNode
+	count int // copies of Value, 0 meaning 1; always 1 in set mode
+	size  int // number of values in the subtree rooted here, 0 if unknown
 }
 
-// BinaryTree represents a binary tree data structure
+// copies returns how many copies of Value the node holds
+func (n *TreeNode) copies() int {
+	return max(n.count, 1)
+}
+
+// nodeSize returns the number of values in the subtree rooted at node.
+// Hand-built nodes have no size yet, so it is computed and cached.
+func nodeSize(node *TreeNode) int {
+	if node == nil {
+		return 0
+	}
+	if node.size == 0 {
+		node.update()
+	}
+	return node.size
+}
+
+// update recomputes the subtree size after a child or the count changed
+func (n *TreeNode) update() {
+	n.size = n.copies() + nodeSize(n.Left) + nodeSize(n.Right)
+}
+
+// Mode selects how a BinaryTree treats duplicate values
+type Mode int
+
+const (
+	// SetMode ignores a value that is already present
+	SetMode Mode = iota
+	// MultisetMode keeps a count per value, so repeats are not lost
+	MultisetMode
+)
+
+// String returns the name of the mode
+func (m Mode) String() string {
+	switch m {
+	case SetMode:
+		return "set"
+	case MultisetMode:
+		return "multiset"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// BinaryTree represents a binary search tree of ints.
+// For a sorted dictionary with any key and value type, use BST.
+//
+// BinaryTree does not wrap BST[int, struct{}]: its exported Root and
+// TreeNode links are part of its API, and its nodes carry the multiset
+// counts and subtree sizes that order statistics rely on. Changes to the
+// shared search and delete logic must be made in both types.
+//
+// Root may be set to hand-built nodes. After relinking the nodes of a
+// tree that is already in use, call Recount so subtree sizes are right.
 type BinaryTree struct {
 	Root *TreeNode
+	mode Mode
 }
 
-// NewBinaryTree creates a new empty binary tree
+// NewBinaryTree creates a new empty binary tree in set mode
 func NewBinaryTree() *BinaryTree {
 	return &BinaryTree{Root: nil}
 }
 
-// Insert inserts a value into the binary search tree
+// NewBinaryTreeWithMode creates a new empty binary tree in the given mode
+func NewBinaryTreeWithMode(mode Mode) *BinaryTree {
+	return &BinaryTree{mode: mode}
+}
+
+// Mode returns how the tree treats duplicate values
+func (bt *BinaryTree) Mode() Mode {
+	return bt.mode
+}
+
+// Insert inserts a value into the binary search tree. In set mode a value
+// that is already present is ignored; in multiset mode its count grows.
 func (bt *BinaryTree) Insert(value int) {
 	bt.Root = bt.insertRec(bt.Root, value)
 }
 
 func (bt *BinaryTree) insertRec(node *TreeNode, value int) *TreeNode {
 	if node == nil {
-		return &TreeNode{Value: value}
+		return &TreeNode{Value: value, count: 1, size: 1}
 	}
 
 	if value < node.Value {
 		node.Left = bt.insertRec(node.Left, value)
 	} else if value > node.Value {
 		node.Right = bt.insertRec(node.Right, value)
+	} else if bt.mode == MultisetMode {
+		node.count = node.copies() + 1
 	}
 
+	node.update()
 	return node
 }
 
@@ -62,106 +134,127 @@ func (bt *BinaryTree) searchRec(node *TreeNode, value int) bool {
 	return bt.searchRec(node.Right, value)
 }
 
-// Delete removes a value from the binary search tree
+// Delete removes a value from the binary search tree, including every
+// copy in multiset mode. It is the same as DeleteAll.
 func (bt *BinaryTree) Delete(value int) {
-	bt.Root = bt.deleteRec(bt.Root, value)
+	bt.DeleteAll(value)
+}
+
+// DeleteAll removes every copy of value and returns how many were removed
+func (bt *BinaryTree) DeleteAll(value int) int {
+	removed := 0
+	bt.Root = bt.deleteRec(bt.Root, value, false, &removed)
+	return removed
+}
+
+// DeleteOne removes a single copy of value. It reports false if value is
+// not in the tree.
+func (bt *BinaryTree) DeleteOne(value int) bool {
+	removed := 0
+	bt.Root = bt.deleteRec(bt.Root, value, true, &removed)
+	return removed > 0
 }
 
-func (bt *BinaryTree) deleteRec(node *TreeNode, value int) *TreeNode {
+func (bt *BinaryTree) deleteRec(node *TreeNode, value int, one bool, removed *int) *TreeNode {
 	if node == nil {
 		return node
 	}
 
 	if value < node.Value {
-		node.Left = bt.deleteRec(node.Left, value)
+		node.Left = bt.deleteRec(node.Left, value, one, removed)
 	} else if value > node.Value {
-		node.Right = bt.deleteRec(node.Right, value)
+		node.Right = bt.deleteRec(node.Right, value, one, removed)
 	} else {
 		// Node to be deleted found
+		if one && node.copies() > 1 {
+			node.count--
+			*removed = 1
+			node.update()
+			return node
+		}
+		*removed = node.copies()
+
 		if node.Left == nil {
 			return node.Right
 		} else if node.Right == nil {
 			return node.Left
 		}
 
-		// Node with two children: get inorder successor
-		node.Value = bt.minValue(node.Right)
-		node.Right = bt.deleteRec(node.Right, node.Value)
+		// Node with two children: take over the inorder successor and all
+		// of its copies, then remove it from the right subtree
+		successor := bt.minNode(node.Right)
+		node.Value, node.count = successor.Value, successor.count
+		var ignored int
+		node.Right = bt.deleteRec(node.Right, node.Value, false, &ignored)
 	}
 
+	node.update()
 	return node
 }
 
-func (bt *BinaryTree) minValue(node *TreeNode) int {
+func (bt *BinaryTree) minNode(node *TreeNode) *TreeNode {
 	current := node
 	for current.Left != nil {
 		current = current.Left
 	}
-	return current.Value
+	return current
+}
+
+// Count returns how many copies of value the tree holds: 0 or 1 in set
+// mode, any number in multiset mode
+func (bt *BinaryTree) Count(value int) int {
+	node := bt.Root
+	for node != nil && node.Value != value {
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	if node == nil {
+		return 0
+	}
+	return node.copies()
 }
 
 // InorderTraversal returns values in inorder traversal (left, root, right)
 func (bt *BinaryTree) InorderTraversal() []int {
-	var result []int
-	bt.inorderRec(bt.Root, &result)
-	return result
+	return collect(bt.Inorder(), bt.Size())
 }
 
-func (bt *BinaryTree) inorderRec(node *TreeNode, result *[]int) {
-	if node != nil {
-		bt.inorderRec(node.Left, result)
-		*result = append(*result, node.Value)
-		bt.inorderRec(node.Right, result)
+// appendCopies appends node.Value once per copy
+func appendCopies(result []int, node *TreeNode) []int {
+	for i := 0; i < node.copies(); i++ {
+		result = append(result, node.Value)
 	}
+	return result
 }
 
 // PreorderTraversal returns values in preorder traversal (root, left, right)
 func (bt *BinaryTree) PreorderTraversal() []int {
-	var result []int
-	bt.preorderRec(bt.Root, &result)
-	return result
-}
-
-func (bt *BinaryTree) preorderRec(node *TreeNode, result *[]int) {
-	if node != nil {
-		*result = append(*result, node.Value)
-		bt.preorderRec(node.Left, result)
-		bt.preorderRec(node.Right, result)
-	}
+	return collect(bt.Preorder(), bt.Size())
 }
 
 // PostorderTraversal returns values in postorder traversal (left, right, root)
 func (bt *BinaryTree) PostorderTraversal() []int {
-	var result []int
-	bt.postorderRec(bt.Root, &result)
-	return result
+	return collect(bt.Postorder(), bt.Size())
 }
 
-func (bt *BinaryTree) postorderRec(node *TreeNode, result *[]int) {
-	if node != nil {
-		bt.postorderRec(node.Left, result)
-		bt.postorderRec(node.Right, result)
-		*result = append(*result, node.Value)
+// collect gathers the values of seq into a slice, or nil if there are none
+func collect(seq iter.Seq[int], size int) []int {
+	if size == 0 {
+		return nil
+	}
+	result := make([]int, 0, size)
+	for value := range seq {
+		result = append(result, value)
 	}
+	return result
 }
 
-// Height returns the height of the tree
+// Height returns the height of the tree, counting levels iteratively
 func (bt *BinaryTree) Height() int {
-	return bt.heightRec(bt.Root)
-}
-
-func (bt *BinaryTree) heightRec(node *TreeNode) int {
-	if node == nil {
-		return -1
-	}
-
-	leftHeight := bt.heightRec(node.Left)
-	rightHeight := bt.heightRec(node.Right)
-
-	if leftHeight > rightHeight {
-		return leftHeight + 1
-	}
-	return rightHeight + 1
+	return len(bt.levels()) - 1
 }
 
 // IsEmpty checks if the tree is empty
@@ -169,16 +262,35 @@ func (bt *BinaryTree) IsEmpty() bool {
 	return bt.Root == nil
 }
 
-// Size returns the number of nodes in the tree
+// Size returns the number of values in the tree in O(1), counting every
+// copy in multiset mode
 func (bt *BinaryTree) Size() int {
-	return bt.sizeRec(bt.Root)
+	return nodeSize(bt.Root)
 }
 
-func (bt *BinaryTree) sizeRec(node *TreeNode) int {
-	if node == nil {
-		return 0
+// Recount recomputes every subtree size, for use after the nodes under
+// Root were built or relinked by hand. Nodes without a count get one copy.
+func (bt *BinaryTree) Recount() {
+	var recount func(node *TreeNode)
+	recount = func(node *TreeNode) {
+		if node == nil {
+			return
+		}
+		recount(node.Left)
+		recount(node.Right)
+		node.count = node.copies()
+		node.update()
 	}
-	return 1 + bt.sizeRec(node.Left) + bt.sizeRec(node.Right)
+	recount(bt.Root)
+}
+
+// DistinctCount returns the number of distinct values, one per node
+func (bt *BinaryTree) DistinctCount() int {
+	count := 0
+	for _, level := range bt.levels() {
+		count += len(level)
+	}
+	return count
 }
 
 // String returns a string representation of the tree
@@ -201,7 +313,11 @@ func (bt *BinaryTree) printTree(node *TreeNode, prefix string, isLast bool, resu
 		} else {
 			result.WriteString("├── ")
 		}
-		result.WriteString(fmt.Sprintf("%d\n", node.Value))
+		if node.copies() > 1 {
+			result.WriteString(fmt.Sprintf("%d (x%d)\n", node.Value, node.copies()))
+		} else {
+			result.WriteString(fmt.Sprintf("%d\n", node.Value))
+		}
 
 		children := []*TreeNode{node.Left, node.Right}
 		for i, child := range children {
@@ -0,0 +1,542 @@
+package trees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// bpNode is a B+ tree node. Internal nodes hold separator keys and one
+// more child than keys; leaves hold the key-value pairs and are linked to
+// their neighbours.
+type bpNode[K any, V any] struct {
+	keys     []K
+	values   []V             // leaves only
+	children []*bpNode[K, V] // internal nodes only
+	prev     *bpNode[K, V]   // leaves only
+	next     *bpNode[K, V]   // leaves only
+	leaf     bool
+}
+
+// BPlusTree represents a B+ tree that maps ordered keys to values. Values
+// live only in the leaves, which form a doubly linked list, so a range scan
+// walks from leaf to leaf instead of back up the tree. Every node other
+// than the root holds between t-1 and 2t-1 keys for a minimum degree t.
+type BPlusTree[K any, V any] struct {
+	root    *bpNode[K, V]
+	degree  int
+	compare func(a, b K) int
+	size    int
+}
+
+// NewBPlusTree creates a new empty B+ tree with the given minimum degree,
+// ordered by the natural order of K. Degrees below MinBTreeDegree are
+// raised to it.
+func NewBPlusTree[K cmp.Ordered, V any](minDegree int) *BPlusTree[K, V] {
+	return NewBPlusTreeFunc[K, V](minDegree, cmp.Compare[K])
+}
+
+// NewBPlusTreeFunc creates a new empty B+ tree with the given minimum
+// degree, ordered by compare
+func NewBPlusTreeFunc[K any, V any](minDegree int, compare func(a, b K) int) *BPlusTree[K, V] {
+	return &BPlusTree[K, V]{degree: max(minDegree, MinBTreeDegree), compare: compare}
+}
+
+// MinDegree returns the minimum degree t of the tree
+func (t *BPlusTree[K, V]) MinDegree() int {
+	return t.degree
+}
+
+// lowerBound returns the index of the first key not less than key
+func (t *BPlusTree[K, V]) lowerBound(keys []K, key K) int {
+	lo, hi := 0, len(keys)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.compare(keys[mid], key) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// upperBound returns the index of the first key greater than key, which is
+// also the child of an internal node that covers key
+func (t *BPlusTree[K, V]) upperBound(keys []K, key K) int {
+	lo, hi := 0, len(keys)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.compare(keys[mid], key) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// findLeaf returns the leaf that covers key
+func (t *BPlusTree[K, V]) findLeaf(key K) *bpNode[K, V] {
+	node := t.root
+	for node != nil && !node.leaf {
+		node = node.children[t.upperBound(node.keys, key)]
+	}
+	return node
+}
+
+// Search returns the value stored for key
+func (t *BPlusTree[K, V]) Search(key K) (V, bool) {
+	if leaf := t.findLeaf(key); leaf != nil {
+		if i := t.lowerBound(leaf.keys, key); i < len(leaf.keys) && t.compare(leaf.keys[i], key) == 0 {
+			return leaf.values[i], true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if the tree contains key
+func (t *BPlusTree[K, V]) Contains(key K) bool {
+	_, found := t.Search(key)
+	return found
+}
+
+// Insert inserts a key-value pair, or updates the value if the key exists
+func (t *BPlusTree[K, V]) Insert(key K, value V) {
+	if t.root == nil {
+		t.root = &bpNode[K, V]{leaf: true}
+	}
+	if separator, right := t.insert(t.root, key, value); right != nil {
+		t.root = &bpNode[K, V]{
+			keys:     []K{separator},
+			children: []*bpNode[K, V]{t.root, right},
+		}
+	}
+}
+
+// insert adds the pair below node. If node overflows it is split, and the
+// new right sibling is returned with the key that separates them.
+func (t *BPlusTree[K, V]) insert(node *bpNode[K, V], key K, value V) (K, *bpNode[K, V]) {
+	var noSplit K
+	if node.leaf {
+		i := t.lowerBound(node.keys, key)
+		if i < len(node.keys) && t.compare(node.keys[i], key) == 0 {
+			node.values[i] = value
+			return noSplit, nil
+		}
+		node.keys = insertAt(node.keys, i, key)
+		node.values = insertAt(node.values, i, value)
+		t.size++
+		if len(node.keys) < 2*t.degree {
+			return noSplit, nil
+		}
+		return t.splitLeaf(node)
+	}
+
+	i := t.upperBound(node.keys, key)
+	separator, right := t.insert(node.children[i], key, value)
+	if right == nil {
+		return noSplit, nil
+	}
+	node.keys = insertAt(node.keys, i, separator)
+	node.children = insertAt(node.children, i+1, right)
+	if len(node.keys) < 2*t.degree {
+		return noSplit, nil
+	}
+	return t.splitInternal(node)
+}
+
+// splitLeaf moves the upper half of an overflowing leaf into a new leaf.
+// The first key of the new leaf is copied up as the separator.
+func (t *BPlusTree[K, V]) splitLeaf(leaf *bpNode[K, V]) (K, *bpNode[K, V]) {
+	mid := t.degree
+	right := &bpNode[K, V]{
+		keys:   append([]K(nil), leaf.keys[mid:]...),
+		values: append([]V(nil), leaf.values[mid:]...),
+		prev:   leaf,
+		next:   leaf.next,
+		leaf:   true,
+	}
+	clear(leaf.keys[mid:])
+	clear(leaf.values[mid:])
+	leaf.keys, leaf.values = leaf.keys[:mid], leaf.values[:mid]
+	if leaf.next != nil {
+		leaf.next.prev = right
+	}
+	leaf.next = right
+	return right.keys[0], right
+}
+
+// splitInternal moves the upper half of an overflowing internal node into
+// a new node. The middle key moves up as the separator.
+func (t *BPlusTree[K, V]) splitInternal(node *bpNode[K, V]) (K, *bpNode[K, V]) {
+	mid := t.degree
+	separator := node.keys[mid]
+	right := &bpNode[K, V]{
+		keys:     append([]K(nil), node.keys[mid+1:]...),
+		children: append([]*bpNode[K, V](nil), node.children[mid+1:]...),
+	}
+	clear(node.keys[mid:])
+	clear(node.children[mid+1:])
+	node.keys, node.children = node.keys[:mid], node.children[:mid+1]
+	return separator, right
+}
+
+// Delete removes key and reports whether it was present
+func (t *BPlusTree[K, V]) Delete(key K) bool {
+	if t.root == nil || !t.delete(t.root, key) {
+		return false
+	}
+	t.size--
+	if len(t.root.keys) == 0 {
+		if t.root.leaf {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	return true
+}
+
+// delete removes key below node and rebalances any child that underflowed
+func (t *BPlusTree[K, V]) delete(node *bpNode[K, V], key K) bool {
+	if node.leaf {
+		i := t.lowerBound(node.keys, key)
+		if i == len(node.keys) || t.compare(node.keys[i], key) != 0 {
+			return false
+		}
+		node.keys = removeAt(node.keys, i)
+		node.values = removeAt(node.values, i)
+		return true
+	}
+
+	i := t.upperBound(node.keys, key)
+	if !t.delete(node.children[i], key) {
+		return false
+	}
+	if len(node.children[i].keys) < t.degree-1 {
+		t.rebalance(node, i)
+	}
+	return true
+}
+
+// rebalance tops up the under-full child i of node by borrowing from a
+// sibling that can spare a key, or else merging with a sibling
+func (t *BPlusTree[K, V]) rebalance(node *bpNode[K, V], i int) {
+	child := node.children[i]
+
+	if i > 0 && len(node.children[i-1].keys) >= t.degree {
+		left := node.children[i-1]
+		last := len(left.keys) - 1
+		if child.leaf {
+			child.keys = insertAt(child.keys, 0, left.keys[last])
+			child.values = insertAt(child.values, 0, left.values[last])
+			left.values = removeAt(left.values, last)
+			node.keys[i-1] = child.keys[0]
+		} else {
+			// Rotate right through the separator
+			child.keys = insertAt(child.keys, 0, node.keys[i-1])
+			child.children = insertAt(child.children, 0, left.children[last+1])
+			node.keys[i-1] = left.keys[last]
+			left.children = removeAt(left.children, last+1)
+		}
+		left.keys = removeAt(left.keys, last)
+		return
+	}
+
+	if i < len(node.keys) && len(node.children[i+1].keys) >= t.degree {
+		right := node.children[i+1]
+		if child.leaf {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.keys = removeAt(right.keys, 0)
+			right.values = removeAt(right.values, 0)
+			node.keys[i] = right.keys[0]
+		} else {
+			// Rotate left through the separator
+			child.keys = append(child.keys, node.keys[i])
+			child.children = append(child.children, right.children[0])
+			node.keys[i] = right.keys[0]
+			right.keys = removeAt(right.keys, 0)
+			right.children = removeAt(right.children, 0)
+		}
+		return
+	}
+
+	if i > 0 {
+		t.merge(node, i-1)
+	} else {
+		t.merge(node, i)
+	}
+}
+
+// merge joins child i+1 of node into child i and drops their separator
+func (t *BPlusTree[K, V]) merge(node *bpNode[K, V], i int) {
+	left, right := node.children[i], node.children[i+1]
+	if left.leaf {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+	} else {
+		left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	node.keys = removeAt(node.keys, i)
+	node.children = removeAt(node.children, i+1)
+}
+
+// firstLeaf returns the leftmost leaf, or nil for an empty tree
+func (t *BPlusTree[K, V]) firstLeaf() *bpNode[K, V] {
+	node := t.root
+	for node != nil && !node.leaf {
+		node = node.children[0]
+	}
+	return node
+}
+
+// lastLeaf returns the rightmost leaf, or nil for an empty tree
+func (t *BPlusTree[K, V]) lastLeaf() *bpNode[K, V] {
+	node := t.root
+	for node != nil && !node.leaf {
+		node = node.children[len(node.children)-1]
+	}
+	return node
+}
+
+// Min returns the smallest key and its value
+func (t *BPlusTree[K, V]) Min() (K, V, bool) {
+	leaf := t.firstLeaf()
+	if leaf == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return leaf.keys[0], leaf.values[0], true
+}
+
+// Max returns the largest key and its value
+func (t *BPlusTree[K, V]) Max() (K, V, bool) {
+	leaf := t.lastLeaf()
+	if leaf == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	last := len(leaf.keys) - 1
+	return leaf.keys[last], leaf.values[last], true
+}
+
+// forward yields pairs from position i of leaf onwards while keep allows
+func forward[K any, V any](leaf *bpNode[K, V], i int, keep func(K) bool, yield func(K, V) bool) {
+	for ; leaf != nil; leaf, i = leaf.next, 0 {
+		for ; i < len(leaf.keys); i++ {
+			if !keep(leaf.keys[i]) || !yield(leaf.keys[i], leaf.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// backward yields pairs from position i of leaf backwards while keep allows
+func backward[K any, V any](leaf *bpNode[K, V], i int, keep func(K) bool, yield func(K, V) bool) {
+	for leaf != nil {
+		for ; i >= 0; i-- {
+			if !keep(leaf.keys[i]) || !yield(leaf.keys[i], leaf.values[i]) {
+				return
+			}
+		}
+		if leaf = leaf.prev; leaf != nil {
+			i = len(leaf.keys) - 1
+		}
+	}
+}
+
+func always[K any](K) bool { return true }
+
+// All returns an iterator over the key-value pairs in ascending key order
+func (t *BPlusTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		forward(t.firstLeaf(), 0, always[K], yield)
+	}
+}
+
+// Backward returns an iterator over the key-value pairs in descending key
+// order
+func (t *BPlusTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.lastLeaf()
+		if leaf != nil {
+			backward(leaf, len(leaf.keys)-1, always[K], yield)
+		}
+	}
+}
+
+// Ascend returns an iterator over the pairs with lo <= key <= hi in
+// ascending order. It descends once to the leaf holding lo and then
+// follows the leaf links.
+func (t *BPlusTree[K, V]) Ascend(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.findLeaf(lo)
+		if leaf == nil {
+			return
+		}
+		keep := func(key K) bool { return t.compare(key, hi) <= 0 }
+		forward(leaf, t.lowerBound(leaf.keys, lo), keep, yield)
+	}
+}
+
+// Descend returns an iterator over the pairs with hi >= key >= lo in
+// descending order
+func (t *BPlusTree[K, V]) Descend(hi, lo K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := t.findLeaf(hi)
+		if leaf == nil {
+			return
+		}
+		keep := func(key K) bool { return t.compare(key, lo) >= 0 }
+		backward(leaf, t.upperBound(leaf.keys, hi)-1, keep, yield)
+	}
+}
+
+// Keys returns all keys in ascending order
+func (t *BPlusTree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	for key := range t.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Height returns the number of edges from the root to a leaf, -1 for an
+// empty tree
+func (t *BPlusTree[K, V]) Height() int {
+	height := -1
+	for node := t.root; node != nil; height++ {
+		if node.leaf {
+			node = nil
+		} else {
+			node = node.children[0]
+		}
+	}
+	return height
+}
+
+// Size returns the number of key-value pairs
+func (t *BPlusTree[K, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *BPlusTree[K, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear removes all key-value pairs
+func (t *BPlusTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// Validate checks the key counts of every node, that separators bound
+// their subtrees, that all leaves are at the same depth, the leaf links
+// and the size. It returns the first violation found, or nil.
+func (t *BPlusTree[K, V]) Validate() error {
+	var leaves []*bpNode[K, V]
+	leafDepth := -1
+	// lo is an inclusive bound and hi an exclusive one, as separators are
+	// copies of the first key of their right subtree
+	var check func(node *bpNode[K, V], depth int, lo, hi *K) error
+	check = func(node *bpNode[K, V], depth int, lo, hi *K) error {
+		if n := len(node.keys); n > 2*t.degree-1 || (node != t.root && n < t.degree-1) || n == 0 {
+			return fmt.Errorf("node at depth %d has %d keys", depth, n)
+		}
+		for i, key := range node.keys {
+			if (lo != nil && t.compare(key, *lo) < 0) || (hi != nil && t.compare(key, *hi) >= 0) ||
+				(i > 0 && t.compare(node.keys[i-1], key) >= 0) {
+				return fmt.Errorf("key %v is out of order", key)
+			}
+		}
+
+		if node.leaf {
+			if len(node.values) != len(node.keys) {
+				return fmt.Errorf("leaf at depth %d has %d keys and %d values", depth, len(node.keys), len(node.values))
+			}
+			if leafDepth >= 0 && depth != leafDepth {
+				return fmt.Errorf("leaves at depths %d and %d", leafDepth, depth)
+			}
+			leafDepth = depth
+			leaves = append(leaves, node)
+			return nil
+		}
+		if len(node.children) != len(node.keys)+1 {
+			return fmt.Errorf("node at depth %d has %d keys and %d children", depth, len(node.keys), len(node.children))
+		}
+		for i, child := range node.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHi = &node.keys[i]
+			}
+			if err := check(child, depth+1, childLo, childHi); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if t.root != nil {
+		if err := check(t.root, 0, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	count := 0
+	for i, leaf := range leaves {
+		var prev, next *bpNode[K, V]
+		if i > 0 {
+			prev = leaves[i-1]
+		}
+		if i < len(leaves)-1 {
+			next = leaves[i+1]
+		}
+		if leaf.prev != prev || leaf.next != next {
+			return fmt.Errorf("leaf %d is linked out of order", i)
+		}
+		count += len(leaf.keys)
+	}
+	if count != t.size {
+		return errors.New("size does not match the number of keys")
+	}
+	return nil
+}
+
+// String returns a drawing of the tree with the keys of each node
+func (t *BPlusTree[K, V]) String() string {
+	if t.root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("B+ Tree (t=%d):\n", t.degree))
+	t.printTree(t.root, "", true, &result)
+	return result.String()
+}
+
+func (t *BPlusTree[K, V]) printTree(node *bpNode[K, V], prefix string, isLast bool, result *strings.Builder) {
+	childPrefix := treeprint.Branch(result, prefix, isLast)
+	result.WriteString(fmt.Sprintf("%v\n", node.keys))
+
+	for i, child := range node.children {
+		t.printTree(child, childPrefix, i == len(node.children)-1, result)
+	}
+}
@@ -0,0 +1,253 @@
+package trees
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// BSTNode represents a node in a generic binary search tree
+type BSTNode[K any, V any] struct {
+	Key   K
+	Value V
+	Left  *BSTNode[K, V]
+	Right *BSTNode[K, V]
+}
+
+// BST represents a binary search tree that maps ordered keys to values.
+// Keys are ordered by a comparator, so BST works as a sorted dictionary
+// for any key type. BinaryTree is a separate int implementation; see its
+// doc comment for why.
+type BST[K any, V any] struct {
+	Root    *BSTNode[K, V]
+	compare func(a, b K) int
+	size    int
+}
+
+// NewBST creates a new empty binary search tree ordered by the natural
+// order of K
+func NewBST[K cmp.Ordered, V any]() *BST[K, V] {
+	return NewBSTFunc[K, V](cmp.Compare[K])
+}
+
+// NewBSTFunc creates a new empty binary search tree ordered by compare,
+// which returns a negative number when a < b, zero when a == b and a
+// positive number when a > b
+func NewBSTFunc[K any, V any](compare func(a, b K) int) *BST[K, V] {
+	return &BST[K, V]{compare: compare}
+}
+
+// Put inserts a key-value pair, or updates the value if the key exists
+func (t *BST[K, V]) Put(key K, value V) {
+	link := &t.Root
+	for *link != nil {
+		switch c := t.compare(key, (*link).Key); {
+		case c < 0:
+			link = &(*link).Left
+		case c > 0:
+			link = &(*link).Right
+		default:
+			(*link).Value = value
+			return
+		}
+	}
+	*link = &BSTNode[K, V]{Key: key, Value: value}
+	t.size++
+}
+
+// find returns the node holding key, or nil
+func (t *BST[K, V]) find(key K) *BSTNode[K, V] {
+	node := t.Root
+	for node != nil {
+		switch c := t.compare(key, node.Key); {
+		case c < 0:
+			node = node.Left
+		case c > 0:
+			node = node.Right
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// Get retrieves the value associated with the given key
+func (t *BST[K, V]) Get(key K) (V, bool) {
+	if node := t.find(key); node != nil {
+		return node.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if the tree contains the given key
+func (t *BST[K, V]) Contains(key K) bool {
+	return t.find(key) != nil
+}
+
+// Delete removes the key-value pair with the given key
+func (t *BST[K, V]) Delete(key K) bool {
+	var deleted bool
+	t.Root = t.deleteRec(t.Root, key, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *BST[K, V]) deleteRec(node *BSTNode[K, V], key K, deleted *bool) *BSTNode[K, V] {
+	if node == nil {
+		return nil
+	}
+
+	if c := t.compare(key, node.Key); c < 0 {
+		node.Left = t.deleteRec(node.Left, key, deleted)
+	} else if c > 0 {
+		node.Right = t.deleteRec(node.Right, key, deleted)
+	} else {
+		// Node to be deleted found
+		*deleted = true
+		if node.Left == nil {
+			return node.Right
+		} else if node.Right == nil {
+			return node.Left
+		}
+
+		// Node with two children: take over the inorder successor
+		successor := minNode(node.Right)
+		node.Key, node.Value = successor.Key, successor.Value
+		var ignored bool
+		node.Right = t.deleteRec(node.Right, successor.Key, &ignored)
+	}
+
+	return node
+}
+
+func minNode[K any, V any](node *BSTNode[K, V]) *BSTNode[K, V] {
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+func maxNode[K any, V any](node *BSTNode[K, V]) *BSTNode[K, V] {
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// Min returns the smallest key and its value
+func (t *BST[K, V]) Min() (K, V, bool) {
+	if t.Root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := minNode(t.Root)
+	return node.Key, node.Value, true
+}
+
+// Max returns the largest key and its value
+func (t *BST[K, V]) Max() (K, V, bool) {
+	if t.Root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := maxNode(t.Root)
+	return node.Key, node.Value, true
+}
+
+// All returns an iterator over the key-value pairs in ascending key order
+func (t *BST[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*BSTNode[K, V]
+		node := t.Root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.Key, node.Value) {
+				return
+			}
+			node = node.Right
+		}
+	}
+}
+
+// Keys returns all keys in ascending order
+func (t *BST[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	for key := range t.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values in ascending key order
+func (t *BST[K, V]) Values() []V {
+	values := make([]V, 0, t.size)
+	for _, value := range t.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Height returns the height of the tree, -1 for an empty tree
+func (t *BST[K, V]) Height() int {
+	return bstHeight(t.Root)
+}
+
+func bstHeight[K any, V any](node *BSTNode[K, V]) int {
+	if node == nil {
+		return -1
+	}
+	return max(bstHeight(node.Left), bstHeight(node.Right)) + 1
+}
+
+// Size returns the number of key-value pairs
+func (t *BST[K, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *BST[K, V]) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Clear removes all key-value pairs
+func (t *BST[K, V]) Clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+// String returns a string representation of the tree
+func (t *BST[K, V]) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("BST:\n")
+	t.printTree(t.Root, "", true, &result)
+	return result.String()
+}
+
+func (t *BST[K, V]) printTree(node *BSTNode[K, V], prefix string, isLast bool, result *strings.Builder) {
+	childPrefix := treeprint.Branch(result, prefix, isLast)
+	result.WriteString(fmt.Sprintf("%v: %v\n", node.Key, node.Value))
+
+	children := []*BSTNode[K, V]{node.Left, node.Right}
+	for i, child := range children {
+		if child != nil {
+			t.printTree(child, childPrefix, i == len(children)-1, result)
+		}
+	}
+}
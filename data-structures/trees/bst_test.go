@@ -0,0 +1,127 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBST(t *testing.T) {
+	tree := NewBST[string, int]()
+	if !tree.IsEmpty() || tree.String() != "Empty tree" {
+		t.Error("Expected tree to be empty")
+	}
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Expected Min of an empty tree to fail")
+	}
+
+	for i, key := range []string{"m", "c", "x", "a", "e", "z"} {
+		tree.Put(key, i)
+	}
+	tree.Put("e", 40)
+	if tree.Size() != 6 {
+		t.Errorf("Expected size 6, got %d", tree.Size())
+	}
+	if val, found := tree.Get("e"); !found || val != 40 {
+		t.Errorf("Expected (40, true), got (%d, %v)", val, found)
+	}
+	if _, found := tree.Get("b"); found {
+		t.Error("Expected b to be missing")
+	}
+
+	if got := fmt.Sprint(tree.Keys()); got != "[a c e m x z]" {
+		t.Errorf("Expected sorted keys, got %s", got)
+	}
+	if got := fmt.Sprint(tree.Values()); got != "[3 1 40 0 2 5]" {
+		t.Errorf("Expected values in key order, got %s", got)
+	}
+	if key, val, _ := tree.Min(); key != "a" || val != 3 {
+		t.Errorf("Expected min (a, 3), got (%s, %d)", key, val)
+	}
+	if key, _, _ := tree.Max(); key != "z" {
+		t.Errorf("Expected max z, got %s", key)
+	}
+
+	// Delete a node with two children, a leaf and a missing key
+	if !tree.Delete("m") || !tree.Delete("z") || tree.Delete("q") {
+		t.Error("Expected to delete m and z only")
+	}
+	if got := fmt.Sprint(tree.Keys()); got != "[a c e x]" || tree.Size() != 4 {
+		t.Errorf("Expected [a c e x], got %s", got)
+	}
+	if !strings.HasPrefix(tree.String(), "BST:\n└── x: 2") {
+		t.Errorf("Unexpected tree:\n%s", tree)
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Error("Expected tree to be empty after clear")
+	}
+}
+
+func TestBSTFunc(t *testing.T) {
+	type version struct{ major, minor int }
+	byVersion := func(a, b version) int {
+		if a.major != b.major {
+			return a.major - b.major
+		}
+		return a.minor - b.minor
+	}
+
+	tree := NewBSTFunc[version, string](byVersion)
+	tree.Put(version{1, 10}, "b")
+	tree.Put(version{2, 0}, "c")
+	tree.Put(version{1, 2}, "a")
+	var order []string
+	for _, name := range tree.All() {
+		order = append(order, name)
+	}
+	if fmt.Sprint(order) != "[a b c]" {
+		t.Errorf("Expected [a b c], got %v", order)
+	}
+
+	// Descending order through a reversed comparator
+	desc := NewBSTFunc[int, struct{}](func(a, b int) int { return b - a })
+	for _, v := range []int{3, 1, 2} {
+		desc.Put(v, struct{}{})
+	}
+	if fmt.Sprint(desc.Keys()) != "[3 2 1]" {
+		t.Errorf("Expected [3 2 1], got %v", desc.Keys())
+	}
+}
+
+func TestBSTAgainstMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewBST[int, int]()
+	reference := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(300)
+		if rng.Intn(3) == 0 {
+			_, want := reference[key]
+			if got := tree.Delete(key); got != want {
+				t.Fatalf("Delete(%d) = %v, want %v", key, got, want)
+			}
+			delete(reference, key)
+		} else {
+			tree.Put(key, i)
+			reference[key] = i
+		}
+	}
+
+	keys := make([]int, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	if fmt.Sprint(tree.Keys()) != fmt.Sprint(keys) || tree.Size() != len(reference) {
+		t.Fatalf("Expected keys %v, got %v", keys, tree.Keys())
+	}
+	for key, want := range reference {
+		if got, _ := tree.Get(key); got != want {
+			t.Errorf("Get(%d) = %d, want %d", key, got, want)
+		}
+	}
+}
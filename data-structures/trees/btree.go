@@ -0,0 +1,531 @@
+package trees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// MinBTreeDegree is the smallest minimum degree a B-tree can have; with it
+// every node holds one to three keys
+const MinBTreeDegree = 2
+
+// bItem is a key-value pair stored in a B-tree node
+type bItem[K any, V any] struct {
+	key   K
+	value V
+}
+
+// bNode is a B-tree node. A leaf has no children; an internal node has one
+// more child than it has items.
+type bNode[K any, V any] struct {
+	items    []bItem[K, V]
+	children []*bNode[K, V]
+}
+
+func (n *bNode[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// BTree represents a B-tree that maps ordered keys to values. Every node
+// other than the root holds between t-1 and 2t-1 keys for a minimum degree
+// t, and all leaves are at the same depth, so the height stays O(log_t n)
+// and each lookup touches few nodes, as an on-disk index would.
+type BTree[K any, V any] struct {
+	root    *bNode[K, V]
+	degree  int
+	compare func(a, b K) int
+	size    int
+}
+
+// NewBTree creates a new empty B-tree with the given minimum degree,
+// ordered by the natural order of K. Degrees below MinBTreeDegree are
+// raised to it.
+func NewBTree[K cmp.Ordered, V any](minDegree int) *BTree[K, V] {
+	return NewBTreeFunc[K, V](minDegree, cmp.Compare[K])
+}
+
+// NewBTreeFunc creates a new empty B-tree with the given minimum degree,
+// ordered by compare
+func NewBTreeFunc[K any, V any](minDegree int, compare func(a, b K) int) *BTree[K, V] {
+	return &BTree[K, V]{degree: max(minDegree, MinBTreeDegree), compare: compare}
+}
+
+// MinDegree returns the minimum degree t of the tree
+func (t *BTree[K, V]) MinDegree() int {
+	return t.degree
+}
+
+// maxItems is the number of items a node can hold
+func (t *BTree[K, V]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+// search returns the index of the first item in n whose key is not less
+// than key, and whether that item's key equals key
+func (t *BTree[K, V]) search(n *bNode[K, V], key K) (int, bool) {
+	lo, hi := 0, len(n.items)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.compare(n.items[mid].key, key) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(n.items) && t.compare(n.items[lo].key, key) == 0
+}
+
+// Search returns the value stored for key
+func (t *BTree[K, V]) Search(key K) (V, bool) {
+	node := t.root
+	for node != nil {
+		i, found := t.search(node, key)
+		if found {
+			return node.items[i].value, true
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if the tree contains key
+func (t *BTree[K, V]) Contains(key K) bool {
+	_, found := t.Search(key)
+	return found
+}
+
+// Insert inserts a key-value pair, or updates the value if the key exists.
+// Full nodes are split on the way down, so a single pass suffices.
+func (t *BTree[K, V]) Insert(key K, value V) {
+	if t.root == nil {
+		t.root = &bNode[K, V]{}
+	}
+	if len(t.root.items) == t.maxItems() {
+		t.root = &bNode[K, V]{children: []*bNode[K, V]{t.root}}
+		t.splitChild(t.root, 0)
+	}
+
+	node := t.root
+	for {
+		i, found := t.search(node, key)
+		if found {
+			node.items[i].value = value
+			return
+		}
+		if node.leaf() {
+			node.items = insertAt(node.items, i, bItem[K, V]{key, value})
+			t.size++
+			return
+		}
+		if len(node.children[i].items) == t.maxItems() {
+			t.splitChild(node, i)
+			switch c := t.compare(key, node.items[i].key); {
+			case c == 0:
+				node.items[i].value = value
+				return
+			case c > 0:
+				i++
+			}
+		}
+		node = node.children[i]
+	}
+}
+
+// splitChild splits the full child i of parent around its median item,
+// which moves up into parent
+func (t *BTree[K, V]) splitChild(parent *bNode[K, V], i int) {
+	child := parent.children[i]
+	mid := t.degree - 1
+	right := &bNode[K, V]{items: append([]bItem[K, V](nil), child.items[mid+1:]...)}
+	if !child.leaf() {
+		right.children = append([]*bNode[K, V](nil), child.children[mid+1:]...)
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+	median := child.items[mid]
+	clear(child.items[mid:])
+	child.items = child.items[:mid]
+
+	parent.items = insertAt(parent.items, i, median)
+	parent.children = insertAt(parent.children, i+1, right)
+}
+
+// Delete removes key and reports whether it was present. Nodes on the way
+// down are topped up to at least t keys first, so removing from a leaf
+// never leaves it under-full.
+func (t *BTree[K, V]) Delete(key K) bool {
+	if t.root == nil {
+		return false
+	}
+	deleted := t.delete(t.root, key)
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *BTree[K, V]) delete(node *bNode[K, V], key K) bool {
+	for {
+		i, found := t.search(node, key)
+		if node.leaf() {
+			if found {
+				node.items = removeAt(node.items, i)
+			}
+			return found
+		}
+
+		if found {
+			switch {
+			case len(node.children[i].items) >= t.degree:
+				// Replace with the predecessor and delete that instead
+				pred := node.children[i]
+				for !pred.leaf() {
+					pred = pred.children[len(pred.children)-1]
+				}
+				node.items[i] = pred.items[len(pred.items)-1]
+				t.delete(node.children[i], node.items[i].key)
+				return true
+			case len(node.children[i+1].items) >= t.degree:
+				// Replace with the successor and delete that instead
+				succ := node.children[i+1]
+				for !succ.leaf() {
+					succ = succ.children[0]
+				}
+				node.items[i] = succ.items[0]
+				t.delete(node.children[i+1], node.items[i].key)
+				return true
+			default:
+				// Both neighbours are minimal, so merge them around key
+				t.merge(node, i)
+				node = node.children[i]
+				continue
+			}
+		}
+
+		node = node.children[t.fill(node, i)]
+	}
+}
+
+// fill makes sure child i of node has at least t items by borrowing from a
+// sibling or merging with one. It returns the index of the child that now
+// covers the original child's keys.
+func (t *BTree[K, V]) fill(node *bNode[K, V], i int) int {
+	child := node.children[i]
+	if len(child.items) >= t.degree {
+		return i
+	}
+
+	if i > 0 && len(node.children[i-1].items) >= t.degree {
+		// Rotate right through the separator
+		left := node.children[i-1]
+		child.items = insertAt(child.items, 0, node.items[i-1])
+		node.items[i-1] = left.items[len(left.items)-1]
+		left.items = removeAt(left.items, len(left.items)-1)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+		return i
+	}
+
+	if i < len(node.items) && len(node.children[i+1].items) >= t.degree {
+		// Rotate left through the separator
+		right := node.children[i+1]
+		child.items = append(child.items, node.items[i])
+		node.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+		return i
+	}
+
+	if i < len(node.items) {
+		t.merge(node, i)
+		return i
+	}
+	t.merge(node, i-1)
+	return i - 1
+}
+
+// merge joins child i, item i and child i+1 of node into child i
+func (t *BTree[K, V]) merge(node *bNode[K, V], i int) {
+	left, right := node.children[i], node.children[i+1]
+	left.items = append(append(left.items, node.items[i]), right.items...)
+	left.children = append(left.children, right.children...)
+	node.items = removeAt(node.items, i)
+	node.children = removeAt(node.children, i+1)
+}
+
+// Min returns the smallest key and its value
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := t.root
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	return node.items[0].key, node.items[0].value, true
+}
+
+// Max returns the largest key and its value
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := t.root
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+	item := node.items[len(node.items)-1]
+	return item.key, item.value, true
+}
+
+// All returns an iterator over the key-value pairs in ascending key order
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// Ascend returns an iterator over the pairs with lo <= key <= hi in
+// ascending order. Subtrees outside the range are skipped.
+func (t *BTree[K, V]) Ascend(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the items of node within the optional bounds and reports
+// whether to continue
+func (t *BTree[K, V]) ascend(node *bNode[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	start := 0
+	if lo != nil {
+		start, _ = t.search(node, *lo)
+	}
+	for i := start; i <= len(node.items); i++ {
+		if !node.leaf() && !t.ascend(node.children[i], lo, hi, yield) {
+			return false
+		}
+		if i == len(node.items) {
+			break
+		}
+		item := node.items[i]
+		if hi != nil && t.compare(item.key, *hi) > 0 {
+			return false
+		}
+		if !yield(item.key, item.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Backward returns an iterator over the key-value pairs in descending key
+// order
+func (t *BTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.descend(t.root, nil, nil, yield)
+	}
+}
+
+// Descend returns an iterator over the pairs with hi >= key >= lo in
+// descending order
+func (t *BTree[K, V]) Descend(hi, lo K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.descend(t.root, &hi, &lo, yield)
+	}
+}
+
+func (t *BTree[K, V]) descend(node *bNode[K, V], hi, lo *K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	// Start at the last item not greater than hi
+	end := len(node.items) - 1
+	if hi != nil {
+		i, found := t.search(node, *hi)
+		end = i - 1
+		if found {
+			end = i
+		}
+	}
+	for i := end; i >= -1; i-- {
+		if !node.leaf() && !t.descend(node.children[i+1], hi, lo, yield) {
+			return false
+		}
+		if i < 0 {
+			break
+		}
+		item := node.items[i]
+		if lo != nil && t.compare(item.key, *lo) < 0 {
+			return false
+		}
+		if !yield(item.key, item.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns all keys in ascending order
+func (t *BTree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	for key := range t.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Height returns the number of edges from the root to a leaf, -1 for an
+// empty tree
+func (t *BTree[K, V]) Height() int {
+	height := -1
+	for node := t.root; node != nil; height++ {
+		if node.leaf() {
+			node = nil
+		} else {
+			node = node.children[0]
+		}
+	}
+	return height
+}
+
+// Size returns the number of key-value pairs
+func (t *BTree[K, V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *BTree[K, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear removes all key-value pairs
+func (t *BTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// Validate checks the key counts of every node, the key order, that all
+// leaves are at the same depth and the size. It returns the first
+// violation found, or nil.
+func (t *BTree[K, V]) Validate() error {
+	count := 0
+	leafDepth := -1
+	var check func(node *bNode[K, V], depth int, lo, hi *K) error
+	check = func(node *bNode[K, V], depth int, lo, hi *K) error {
+		if n := len(node.items); n > t.maxItems() || (node != t.root && n < t.degree-1) || n == 0 {
+			return fmt.Errorf("node at depth %d has %d keys", depth, n)
+		}
+		if !node.leaf() && len(node.children) != len(node.items)+1 {
+			return fmt.Errorf("node at depth %d has %d keys and %d children", depth, len(node.items), len(node.children))
+		}
+		for i, item := range node.items {
+			if (lo != nil && t.compare(item.key, *lo) <= 0) || (hi != nil && t.compare(item.key, *hi) >= 0) ||
+				(i > 0 && t.compare(node.items[i-1].key, item.key) >= 0) {
+				return fmt.Errorf("key %v is out of order", item.key)
+			}
+		}
+		count += len(node.items)
+
+		if node.leaf() {
+			if leafDepth >= 0 && depth != leafDepth {
+				return fmt.Errorf("leaves at depths %d and %d", leafDepth, depth)
+			}
+			leafDepth = depth
+			return nil
+		}
+		for i, child := range node.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.items[i-1].key
+			}
+			if i < len(node.items) {
+				childHi = &node.items[i].key
+			}
+			if err := check(child, depth+1, childLo, childHi); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if t.root != nil {
+		if err := check(t.root, 0, nil, nil); err != nil {
+			return err
+		}
+	}
+	if count != t.size {
+		return errors.New("size does not match the number of keys")
+	}
+	return nil
+}
+
+// String returns a drawing of the tree with the keys of each node
+func (t *BTree[K, V]) String() string {
+	if t.root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("B-Tree (t=%d):\n", t.degree))
+	t.printTree(t.root, "", true, &result)
+	return result.String()
+}
+
+func (t *BTree[K, V]) printTree(node *bNode[K, V], prefix string, isLast bool, result *strings.Builder) {
+	childPrefix := treeprint.Branch(result, prefix, isLast)
+	keys := make([]K, len(node.items))
+	for i, item := range node.items {
+		keys[i] = item.key
+	}
+	result.WriteString(fmt.Sprintf("%v\n", keys))
+
+	for i, child := range node.children {
+		t.printTree(child, childPrefix, i == len(node.children)-1, result)
+	}
+}
+
+// insertAt inserts v at index i, reusing the slice's capacity when it can
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// removeAt removes the element at index i, clearing the freed slot so it
+// does not keep a value reachable
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
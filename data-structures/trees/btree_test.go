@@ -0,0 +1,248 @@
+package trees
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// index is the API shared by BTree and BPlusTree
+type index interface {
+	Insert(key, value int)
+	Delete(key int) bool
+	Search(key int) (int, bool)
+	Min() (int, int, bool)
+	Max() (int, int, bool)
+	All() iter.Seq2[int, int]
+	Backward() iter.Seq2[int, int]
+	Ascend(lo, hi int) iter.Seq2[int, int]
+	Descend(hi, lo int) iter.Seq2[int, int]
+	Keys() []int
+	Height() int
+	Size() int
+	IsEmpty() bool
+	Validate() error
+	String() string
+}
+
+var indexVariants = []struct {
+	name string
+	new  func(minDegree int) index
+}{
+	{"BTree", func(t int) index { return NewBTree[int, int](t) }},
+	{"BPlusTree", func(t int) index { return NewBPlusTree[int, int](t) }},
+}
+
+func keysOf(seq iter.Seq2[int, int]) []int {
+	var keys []int
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestIndexBasics(t *testing.T) {
+	for _, variant := range indexVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			tree := variant.new(2)
+			if !tree.IsEmpty() || tree.String() != "Empty tree" || tree.Height() != -1 {
+				t.Error("Expected tree to be empty")
+			}
+			if _, _, ok := tree.Min(); ok {
+				t.Error("Expected Min of an empty tree to fail")
+			}
+
+			for i := 1; i <= 20; i++ {
+				tree.Insert(i*10, i)
+			}
+			tree.Insert(50, 500)
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if tree.Size() != 20 || tree.Height() < 2 {
+				t.Errorf("Expected 20 keys over several levels, got %d keys and height %d", tree.Size(), tree.Height())
+			}
+			if val, found := tree.Search(50); !found || val != 500 {
+				t.Errorf("Expected (500, true), got (%d, %v)", val, found)
+			}
+			if _, found := tree.Search(55); found {
+				t.Error("Expected 55 to be missing")
+			}
+			if key, _, _ := tree.Min(); key != 10 {
+				t.Errorf("Expected min 10, got %d", key)
+			}
+			if key, val, _ := tree.Max(); key != 200 || val != 20 {
+				t.Errorf("Expected max (200, 20), got (%d, %d)", key, val)
+			}
+
+			if got := fmt.Sprint(keysOf(tree.Ascend(35, 90))); got != "[40 50 60 70 80 90]" {
+				t.Errorf("Unexpected ascending range %s", got)
+			}
+			if got := fmt.Sprint(keysOf(tree.Descend(90, 35))); got != "[90 80 70 60 50 40]" {
+				t.Errorf("Unexpected descending range %s", got)
+			}
+			if got := keysOf(tree.Ascend(201, 300)); len(got) != 0 {
+				t.Errorf("Expected an empty range, got %v", got)
+			}
+			if got := keysOf(tree.Descend(5, 0)); len(got) != 0 {
+				t.Errorf("Expected an empty range, got %v", got)
+			}
+			backward := keysOf(tree.Backward())
+			slices.Reverse(backward)
+			if !slices.Equal(backward, tree.Keys()) {
+				t.Errorf("Expected Backward to reverse All, got %v", backward)
+			}
+
+			// Early termination
+			seen := 0
+			for range tree.Ascend(0, 1000) {
+				seen++
+				if seen == 3 {
+					break
+				}
+			}
+			if seen != 3 {
+				t.Errorf("Expected iteration to stop at 3, got %d", seen)
+			}
+
+			for i := 1; i <= 20; i++ {
+				if !tree.Delete(i * 10) {
+					t.Fatalf("Expected to delete %d", i*10)
+				}
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("After deleting %d: %v", i*10, err)
+				}
+			}
+			if !tree.IsEmpty() || tree.Delete(10) {
+				t.Error("Expected tree to be empty")
+			}
+		})
+	}
+}
+
+func TestIndexAgainstMap(t *testing.T) {
+	for _, variant := range indexVariants {
+		for _, degree := range []int{2, 3, 5} {
+			t.Run(fmt.Sprintf("%s/t=%d", variant.name, degree), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(degree)))
+				tree := variant.new(degree)
+				reference := make(map[int]int)
+
+				for i := 0; i < 4000; i++ {
+					key := rng.Intn(500)
+					if rng.Intn(3) == 0 {
+						_, want := reference[key]
+						if got := tree.Delete(key); got != want {
+							t.Fatalf("Delete(%d) = %v, want %v", key, got, want)
+						}
+						delete(reference, key)
+					} else {
+						tree.Insert(key, i)
+						reference[key] = i
+					}
+					if i%100 == 0 {
+						if err := tree.Validate(); err != nil {
+							t.Fatalf("After %d operations: %v", i, err)
+						}
+					}
+				}
+				if err := tree.Validate(); err != nil {
+					t.Fatal(err)
+				}
+
+				keys := make([]int, 0, len(reference))
+				for key := range reference {
+					keys = append(keys, key)
+				}
+				slices.Sort(keys)
+				if !slices.Equal(tree.Keys(), keys) || tree.Size() != len(reference) {
+					t.Fatalf("Expected %d keys, got %d", len(keys), tree.Size())
+				}
+				for key, want := range reference {
+					if got, found := tree.Search(key); !found || got != want {
+						t.Fatalf("Search(%d) = (%d, %v), want %d", key, got, found, want)
+					}
+				}
+
+				for j := 0; j < 50; j++ {
+					lo := rng.Intn(520) - 10
+					hi := lo + rng.Intn(100)
+					var want []int
+					for _, key := range keys {
+						if key >= lo && key <= hi {
+							want = append(want, key)
+						}
+					}
+					if got := keysOf(tree.Ascend(lo, hi)); !slices.Equal(got, want) {
+						t.Fatalf("Ascend(%d, %d) = %v, want %v", lo, hi, got, want)
+					}
+					slices.Reverse(want)
+					if got := keysOf(tree.Descend(hi, lo)); !slices.Equal(got, want) {
+						t.Fatalf("Descend(%d, %d) = %v, want %v", hi, lo, got, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestBTreeString(t *testing.T) {
+	tree := NewBTree[int, string](2)
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(key, "")
+	}
+	want := "B-Tree (t=2):\n" +
+		"└── [20]\n" +
+		"    ├── [10]\n" +
+		"    └── [30 40 50]\n"
+	if tree.String() != want {
+		t.Errorf("Unexpected tree:\n%s", tree)
+	}
+	if tree.MinDegree() != 2 || NewBTree[int, int](0).MinDegree() != MinBTreeDegree {
+		t.Error("Expected degrees below the minimum to be raised")
+	}
+}
+
+func TestBPlusTreeString(t *testing.T) {
+	tree := NewBPlusTree[int, string](2)
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(key, "")
+	}
+	want := "B+ Tree (t=2):\n" +
+		"└── [30]\n" +
+		"    ├── [10 20]\n" +
+		"    └── [30 40 50]\n"
+	if tree.String() != want {
+		t.Errorf("Unexpected tree:\n%s", tree)
+	}
+}
+
+func TestBTreeFunc(t *testing.T) {
+	// Descending order through a reversed comparator
+	tree := NewBPlusTreeFunc[string, int](3, func(a, b string) int {
+		switch {
+		case a > b:
+			return -1
+		case a < b:
+			return 1
+		}
+		return 0
+	})
+	for i, key := range []string{"b", "d", "a", "c"} {
+		tree.Insert(key, i)
+	}
+	if got := fmt.Sprint(tree.Keys()); got != "[d c b a]" {
+		t.Errorf("Expected [d c b a], got %s", got)
+	}
+
+	btree := NewBTreeFunc[string, int](3, func(a, b string) int { return len(a) - len(b) })
+	btree.Insert("aaa", 3)
+	btree.Insert("b", 1)
+	btree.Insert("cc", 2)
+	btree.Insert("dd", 4)
+	if got, _ := btree.Search("xx"); got != 4 || btree.Size() != 3 {
+		t.Errorf("Expected keys of equal length to collide, got %d and size %d", got, btree.Size())
+	}
+}
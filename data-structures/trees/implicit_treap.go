@@ -0,0 +1,258 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+)
+
+// ErrIndexOutOfRange is returned for a position outside an ImplicitTreap
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// implicitNode is a node in an ImplicitTreap. Its position is not stored
+// but given by the sizes of the subtrees to its left.
+type implicitNode[T any] struct {
+	value    T
+	priority uint64
+	left     *implicitNode[T]
+	right    *implicitNode[T]
+	size     int
+	reversed bool // the subtree's order is pending a reversal
+}
+
+func implicitSize[T any](node *implicitNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func (n *implicitNode[T]) update() {
+	n.size = 1 + implicitSize(n.left) + implicitSize(n.right)
+}
+
+// push hands a pending reversal to the children
+func (n *implicitNode[T]) push() {
+	if !n.reversed {
+		return
+	}
+	n.left, n.right = n.right, n.left
+	for _, child := range []*implicitNode[T]{n.left, n.right} {
+		if child != nil {
+			child.reversed = !child.reversed
+		}
+	}
+	n.reversed = false
+}
+
+// implicitSplit splits node into its first k elements and the rest
+func implicitSplit[T any](node *implicitNode[T], k int) (*implicitNode[T], *implicitNode[T]) {
+	if node == nil {
+		return nil, nil
+	}
+	node.push()
+	if implicitSize(node.left) < k {
+		left, right := implicitSplit(node.right, k-implicitSize(node.left)-1)
+		node.right = left
+		node.update()
+		return node, right
+	}
+	left, right := implicitSplit(node.left, k)
+	node.left = right
+	node.update()
+	return left, node
+}
+
+// implicitMerge concatenates two sequences
+func implicitMerge[T any](left, right *implicitNode[T]) *implicitNode[T] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.push()
+		left.right = implicitMerge(left.right, right)
+		left.update()
+		return left
+	}
+	right.push()
+	right.left = implicitMerge(left, right.left)
+	right.update()
+	return right
+}
+
+// ImplicitTreap represents a sequence stored in a treap keyed by position
+// instead of by value, like a rope. Inserting or deleting at any index,
+// reversing a range, and splitting or joining sequences all take expected
+// O(log n). The zero value is an empty sequence.
+type ImplicitTreap[T any] struct {
+	root *implicitNode[T]
+	rng  *rand.Rand // created on first use when nil
+}
+
+// random returns the priority source, seeding one for a zero-value treap
+func (t *ImplicitTreap[T]) random() *rand.Rand {
+	if t.rng == nil {
+		t.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return t.rng
+}
+
+// NewImplicitTreap creates a new sequence holding values, in order
+func NewImplicitTreap[T any](values ...T) *ImplicitTreap[T] {
+	t := &ImplicitTreap[T]{rng: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+	for _, value := range values {
+		t.Append(value)
+	}
+	return t
+}
+
+// Len returns the number of elements
+func (t *ImplicitTreap[T]) Len() int {
+	return implicitSize(t.root)
+}
+
+func (t *ImplicitTreap[T]) newNode(value T) *implicitNode[T] {
+	return &implicitNode[T]{value: value, priority: t.random().Uint64(), size: 1}
+}
+
+// Append adds value at the end
+func (t *ImplicitTreap[T]) Append(value T) {
+	t.root = implicitMerge(t.root, t.newNode(value))
+}
+
+// Insert places value at index, shifting later elements right. An index
+// equal to Len appends.
+func (t *ImplicitTreap[T]) Insert(index int, value T) error {
+	if index < 0 || index > t.Len() {
+		return fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, index, t.Len())
+	}
+	left, right := implicitSplit(t.root, index)
+	t.root = implicitMerge(implicitMerge(left, t.newNode(value)), right)
+	return nil
+}
+
+// Delete removes and returns the element at index
+func (t *ImplicitTreap[T]) Delete(index int) (T, error) {
+	if index < 0 || index >= t.Len() {
+		var zero T
+		return zero, fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, index, t.Len())
+	}
+	left, rest := implicitSplit(t.root, index)
+	middle, right := implicitSplit(rest, 1)
+	t.root = implicitMerge(left, right)
+	return middle.value, nil
+}
+
+// find returns the node at index, applying pending reversals on the way
+func (t *ImplicitTreap[T]) find(index int) (*implicitNode[T], error) {
+	if index < 0 || index >= t.Len() {
+		return nil, fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, index, t.Len())
+	}
+	node := t.root
+	for {
+		node.push()
+		switch leftSize := implicitSize(node.left); {
+		case index < leftSize:
+			node = node.left
+		case index > leftSize:
+			index -= leftSize + 1
+			node = node.right
+		default:
+			return node, nil
+		}
+	}
+}
+
+// Get returns the element at index
+func (t *ImplicitTreap[T]) Get(index int) (T, error) {
+	node, err := t.find(index)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return node.value, nil
+}
+
+// Set replaces the element at index
+func (t *ImplicitTreap[T]) Set(index int, value T) error {
+	node, err := t.find(index)
+	if err != nil {
+		return err
+	}
+	node.value = value
+	return nil
+}
+
+// Reverse reverses the order of the elements in [lo, hi). The reversal is
+// recorded on one subtree and applied lazily.
+func (t *ImplicitTreap[T]) Reverse(lo, hi int) error {
+	if lo < 0 || hi > t.Len() || lo > hi {
+		return fmt.Errorf("%w: [%d, %d) with length %d", ErrIndexOutOfRange, lo, hi, t.Len())
+	}
+	left, rest := implicitSplit(t.root, lo)
+	middle, right := implicitSplit(rest, hi-lo)
+	if middle != nil {
+		middle.reversed = !middle.reversed
+	}
+	t.root = implicitMerge(implicitMerge(left, middle), right)
+	return nil
+}
+
+// Split moves the elements from index on into a new sequence, which it
+// returns, and keeps the first index elements
+func (t *ImplicitTreap[T]) Split(index int) (*ImplicitTreap[T], error) {
+	if index < 0 || index > t.Len() {
+		return nil, fmt.Errorf("%w: %d with length %d", ErrIndexOutOfRange, index, t.Len())
+	}
+	var right *implicitNode[T]
+	t.root, right = implicitSplit(t.root, index)
+	return &ImplicitTreap[T]{root: right, rng: rand.New(rand.NewPCG(t.random().Uint64(), t.random().Uint64()))}, nil
+}
+
+// Join appends the elements of other, leaving other empty. Joining a
+// sequence to itself leaves it unchanged.
+func (t *ImplicitTreap[T]) Join(other *ImplicitTreap[T]) {
+	if other == t {
+		return
+	}
+	t.root = implicitMerge(t.root, other.root)
+	other.root = nil
+}
+
+// All returns an iterator over the elements in order
+func (t *ImplicitTreap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var walk func(node *implicitNode[T]) bool
+		walk = func(node *implicitNode[T]) bool {
+			if node == nil {
+				return true
+			}
+			node.push()
+			return walk(node.left) && yield(node.value) && walk(node.right)
+		}
+		walk(t.root)
+	}
+}
+
+// Values returns the elements in order
+func (t *ImplicitTreap[T]) Values() []T {
+	values := make([]T, 0, t.Len())
+	for value := range t.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// IsEmpty checks if the sequence is empty
+func (t *ImplicitTreap[T]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// String returns a string representation of the elements
+func (t *ImplicitTreap[T]) String() string {
+	return fmt.Sprintf("ImplicitTreap: %v", t.Values())
+}
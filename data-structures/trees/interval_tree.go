@@ -0,0 +1,400 @@
+package trees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// ErrInvalidInterval is returned for an interval whose Lo is above its Hi
+var ErrInvalidInterval = errors.New("interval lo is greater than hi")
+
+// Interval is the closed range of ints [Lo, Hi]
+type Interval struct {
+	Lo, Hi int
+}
+
+// Overlaps checks if the intervals share at least one point
+func (iv Interval) Overlaps(other Interval) bool {
+	return iv.Lo <= other.Hi && other.Lo <= iv.Hi
+}
+
+// Contains checks if point lies within the interval
+func (iv Interval) Contains(point int) bool {
+	return iv.Lo <= point && point <= iv.Hi
+}
+
+// String returns the interval as [lo, hi]
+func (iv Interval) String() string {
+	return fmt.Sprintf("[%d, %d]", iv.Lo, iv.Hi)
+}
+
+// compareIntervals orders intervals by Lo, then by Hi
+func compareIntervals(a, b Interval) int {
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Hi, b.Hi)
+}
+
+// IntervalNode represents a node in an interval tree
+type IntervalNode[V any] struct {
+	Interval Interval
+	Value    V
+	Left     *IntervalNode[V]
+	Right    *IntervalNode[V]
+	height   int // edges on the longest path down to a leaf
+	maxHi    int // largest Hi in the subtree rooted here
+}
+
+// MaxHi returns the largest Hi of any interval in the node's subtree
+func (n *IntervalNode[V]) MaxHi() int {
+	return n.maxHi
+}
+
+func intervalHeight[V any](node *IntervalNode[V]) int {
+	if node == nil {
+		return -1
+	}
+	return node.height
+}
+
+func (n *IntervalNode[V]) update() {
+	n.height = max(intervalHeight(n.Left), intervalHeight(n.Right)) + 1
+	n.maxHi = n.Interval.Hi
+	if n.Left != nil {
+		n.maxHi = max(n.maxHi, n.Left.maxHi)
+	}
+	if n.Right != nil {
+		n.maxHi = max(n.maxHi, n.Right.maxHi)
+	}
+}
+
+func (n *IntervalNode[V]) balanceFactor() int {
+	return intervalHeight(n.Right) - intervalHeight(n.Left)
+}
+
+func (n *IntervalNode[V]) rotateLeft() *IntervalNode[V] {
+	right := n.Right
+	n.Right = right.Left
+	right.Left = n
+	n.update()
+	right.update()
+	return right
+}
+
+func (n *IntervalNode[V]) rotateRight() *IntervalNode[V] {
+	left := n.Left
+	n.Left = left.Right
+	left.Right = n
+	n.update()
+	left.update()
+	return left
+}
+
+// rebalance restores the AVL property at node and its max endpoint
+func (n *IntervalNode[V]) rebalance() *IntervalNode[V] {
+	n.update()
+	switch bf := n.balanceFactor(); {
+	case bf > 1:
+		if n.Right.balanceFactor() < 0 {
+			n.Right = n.Right.rotateRight()
+		}
+		return n.rotateLeft()
+	case bf < -1:
+		if n.Left.balanceFactor() > 0 {
+			n.Left = n.Left.rotateLeft()
+		}
+		return n.rotateRight()
+	}
+	return n
+}
+
+// IntervalTree represents a set of closed int intervals with a payload
+// each. It is an AVL tree ordered by Lo and then Hi, where every node also
+// records the largest Hi below it. That lets overlap queries skip whole
+// subtrees, so they take O(log n + k) for k results.
+type IntervalTree[V any] struct {
+	Root *IntervalNode[V]
+	size int
+}
+
+// NewIntervalTree creates a new empty interval tree
+func NewIntervalTree[V any]() *IntervalTree[V] {
+	return &IntervalTree[V]{}
+}
+
+// Insert adds the interval [lo, hi] with a payload, or replaces the
+// payload if the interval is present
+func (t *IntervalTree[V]) Insert(lo, hi int, value V) error {
+	if lo > hi {
+		return fmt.Errorf("%w: [%d, %d]", ErrInvalidInterval, lo, hi)
+	}
+	t.Root = t.insertRec(t.Root, Interval{lo, hi}, value)
+	return nil
+}
+
+func (t *IntervalTree[V]) insertRec(node *IntervalNode[V], iv Interval, value V) *IntervalNode[V] {
+	if node == nil {
+		t.size++
+		return &IntervalNode[V]{Interval: iv, Value: value, maxHi: iv.Hi}
+	}
+
+	switch c := compareIntervals(iv, node.Interval); {
+	case c < 0:
+		node.Left = t.insertRec(node.Left, iv, value)
+	case c > 0:
+		node.Right = t.insertRec(node.Right, iv, value)
+	default:
+		node.Value = value
+		return node
+	}
+	return node.rebalance()
+}
+
+// Get returns the payload of the interval [lo, hi]
+func (t *IntervalTree[V]) Get(lo, hi int) (V, bool) {
+	iv := Interval{lo, hi}
+	node := t.Root
+	for node != nil {
+		switch c := compareIntervals(iv, node.Interval); {
+		case c < 0:
+			node = node.Left
+		case c > 0:
+			node = node.Right
+		default:
+			return node.Value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the interval [lo, hi] and reports whether it was present
+func (t *IntervalTree[V]) Delete(lo, hi int) bool {
+	var deleted bool
+	t.Root = t.deleteRec(t.Root, Interval{lo, hi}, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+func (t *IntervalTree[V]) deleteRec(node *IntervalNode[V], iv Interval, deleted *bool) *IntervalNode[V] {
+	if node == nil {
+		return nil
+	}
+
+	switch c := compareIntervals(iv, node.Interval); {
+	case c < 0:
+		node.Left = t.deleteRec(node.Left, iv, deleted)
+	case c > 0:
+		node.Right = t.deleteRec(node.Right, iv, deleted)
+	default:
+		*deleted = true
+		if node.Left == nil {
+			return node.Right
+		} else if node.Right == nil {
+			return node.Left
+		}
+
+		// Node with two children: take over the inorder successor
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		node.Interval, node.Value = successor.Interval, successor.Value
+		var ignored bool
+		node.Right = t.deleteRec(node.Right, successor.Interval, &ignored)
+	}
+	return node.rebalance()
+}
+
+// IntervalEntry is an interval with its payload
+type IntervalEntry[V any] struct {
+	Interval Interval
+	Value    V
+}
+
+// overlapping yields the entries below node that overlap query, in order,
+// and reports whether to continue
+func (t *IntervalTree[V]) overlapping(node *IntervalNode[V], query Interval, yield func(Interval, V) bool) bool {
+	// Nothing below ends at or after query.Lo
+	if node == nil || node.maxHi < query.Lo {
+		return true
+	}
+	if !t.overlapping(node.Left, query, yield) {
+		return false
+	}
+	// This node and everything to its right start after query.Hi
+	if node.Interval.Lo > query.Hi {
+		return true
+	}
+	if node.Interval.Overlaps(query) && !yield(node.Interval, node.Value) {
+		return false
+	}
+	return t.overlapping(node.Right, query, yield)
+}
+
+// Overlapping returns an iterator over the intervals that share at least
+// one point with [lo, hi], ordered by Lo and then Hi
+func (t *IntervalTree[V]) Overlapping(lo, hi int) iter.Seq2[Interval, V] {
+	return func(yield func(Interval, V) bool) {
+		t.overlapping(t.Root, Interval{lo, hi}, yield)
+	}
+}
+
+// Stabbing returns an iterator over the intervals that contain point
+func (t *IntervalTree[V]) Stabbing(point int) iter.Seq2[Interval, V] {
+	return t.Overlapping(point, point)
+}
+
+// AnyOverlap returns some interval overlapping [lo, hi], which is enough
+// to detect a conflict. It takes O(log n).
+func (t *IntervalTree[V]) AnyOverlap(lo, hi int) (IntervalEntry[V], bool) {
+	query := Interval{lo, hi}
+	node := t.Root
+	for node != nil {
+		if node.Interval.Overlaps(query) {
+			return IntervalEntry[V]{node.Interval, node.Value}, true
+		}
+		// If the left subtree reaches lo, either it holds an overlap or
+		// every interval in it starts after hi, and so does the right
+		if node.Left != nil && node.Left.maxHi >= lo {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return IntervalEntry[V]{}, false
+}
+
+// All returns an iterator over the intervals ordered by Lo and then Hi
+func (t *IntervalTree[V]) All() iter.Seq2[Interval, V] {
+	return func(yield func(Interval, V) bool) {
+		var stack []*IntervalNode[V]
+		node := t.Root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.Interval, node.Value) {
+				return
+			}
+			node = node.Right
+		}
+	}
+}
+
+// Merged returns the union of the intervals as a sorted list of disjoint
+// intervals. Intervals that overlap or are adjacent, such as [1, 3] and
+// [4, 6], are merged, since together they cover every int between.
+func (t *IntervalTree[V]) Merged() []Interval {
+	var merged []Interval
+	for iv := range t.All() {
+		// Lo-1 rather than Hi+1, which overflows at math.MaxInt; Lo-1 only
+		// wraps when Lo is math.MinInt, and then Lo <= Hi already holds
+		if last := len(merged) - 1; last >= 0 && (iv.Lo <= merged[last].Hi || iv.Lo-1 == merged[last].Hi) {
+			merged[last].Hi = max(merged[last].Hi, iv.Hi)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// Height returns the height of the tree in O(1)
+func (t *IntervalTree[V]) Height() int {
+	return intervalHeight(t.Root)
+}
+
+// Size returns the number of intervals
+func (t *IntervalTree[V]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty
+func (t *IntervalTree[V]) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Clear removes all intervals
+func (t *IntervalTree[V]) Clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+// Validate checks the search tree order, the stored heights, balance
+// factors and max endpoints, and the size. It returns the first violation
+// found, or nil.
+func (t *IntervalTree[V]) Validate() error {
+	count := 0
+	var check func(node *IntervalNode[V], lo, hi *Interval) error
+	check = func(node *IntervalNode[V], lo, hi *Interval) error {
+		if node == nil {
+			return nil
+		}
+		count++
+		if (lo != nil && compareIntervals(node.Interval, *lo) <= 0) || (hi != nil && compareIntervals(node.Interval, *hi) >= 0) {
+			return fmt.Errorf("interval %s is out of order", node.Interval)
+		}
+		if err := check(node.Left, lo, &node.Interval); err != nil {
+			return err
+		}
+		if err := check(node.Right, &node.Interval, hi); err != nil {
+			return err
+		}
+
+		want := *node
+		want.update()
+		if node.height != want.height {
+			return fmt.Errorf("interval %s has height %d, want %d", node.Interval, node.height, want.height)
+		}
+		if node.maxHi != want.maxHi {
+			return fmt.Errorf("interval %s has max endpoint %d, want %d", node.Interval, node.maxHi, want.maxHi)
+		}
+		if bf := node.balanceFactor(); bf < -1 || bf > 1 {
+			return fmt.Errorf("interval %s has balance factor %d", node.Interval, bf)
+		}
+		return nil
+	}
+
+	if err := check(t.Root, nil, nil); err != nil {
+		return err
+	}
+	if count != t.size {
+		return errors.New("size does not match the number of nodes")
+	}
+	return nil
+}
+
+// String returns a string representation of the tree with the max
+// endpoint of each subtree
+func (t *IntervalTree[V]) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("Interval Tree:\n")
+	var printTree func(node *IntervalNode[V], prefix string, isLast bool)
+	printTree = func(node *IntervalNode[V], prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(fmt.Sprintf("%s max %d: %v\n", node.Interval, node.maxHi, node.Value))
+		children := []*IntervalNode[V]{node.Left, node.Right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.Root, "", true)
+	return result.String()
+}
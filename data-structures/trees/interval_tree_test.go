@@ -0,0 +1,191 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func entriesOf[V any](seq iter.Seq2[Interval, V]) []Interval {
+	var result []Interval
+	for iv := range seq {
+		result = append(result, iv)
+	}
+	return result
+}
+
+func TestIntervalTree(t *testing.T) {
+	tree := NewIntervalTree[string]()
+	if !tree.IsEmpty() || tree.String() != "Empty tree" {
+		t.Error("Expected tree to be empty")
+	}
+
+	bookings := []struct {
+		lo, hi int
+		name   string
+	}{
+		{9, 10, "standup"}, {13, 15, "review"}, {11, 12, "lunch"}, {14, 17, "workshop"}, {20, 21, "dinner"},
+	}
+	for _, b := range bookings {
+		if err := tree.Insert(b.lo, b.hi, b.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tree.Insert(9, 10, "daily")
+	if tree.Size() != 5 {
+		t.Errorf("Expected 5 intervals, got %d", tree.Size())
+	}
+	if name, found := tree.Get(9, 10); !found || name != "daily" {
+		t.Errorf("Expected (daily, true), got (%s, %v)", name, found)
+	}
+	if err := tree.Insert(5, 4, ""); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+
+	if got := fmt.Sprint(entriesOf(tree.Overlapping(12, 14))); got != "[[11, 12] [13, 15] [14, 17]]" {
+		t.Errorf("Unexpected overlaps %s", got)
+	}
+	if got := fmt.Sprint(entriesOf(tree.Stabbing(15))); got != "[[13, 15] [14, 17]]" {
+		t.Errorf("Unexpected stabbing result %s", got)
+	}
+	if got := entriesOf(tree.Overlapping(18, 19)); len(got) != 0 {
+		t.Errorf("Expected no overlaps, got %v", got)
+	}
+	if e, found := tree.AnyOverlap(16, 18); !found || e.Value != "workshop" {
+		t.Errorf("Expected a conflict with the workshop, got %v, %v", e, found)
+	}
+	if _, found := tree.AnyOverlap(18, 19); found {
+		t.Error("Expected no conflict")
+	}
+	if got := fmt.Sprint(tree.Merged()); got != "[[9, 17] [20, 21]]" {
+		t.Errorf("Unexpected merged intervals %s", got)
+	}
+
+	if !tree.Delete(14, 17) || tree.Delete(14, 17) || tree.Delete(1, 2) {
+		t.Error("Expected to delete [14, 17] once")
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(tree.Merged()); got != "[[9, 15] [20, 21]]" {
+		t.Errorf("Unexpected merged intervals %s", got)
+	}
+	if !strings.HasPrefix(tree.String(), "Interval Tree:\n└── [11, 12] max 21: lunch\n") {
+		t.Errorf("Unexpected tree:\n%s", tree)
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Size() != 0 || tree.Merged() != nil {
+		t.Error("Expected tree to be empty after clear")
+	}
+}
+
+func TestIntervalTreeMergedAtIntLimits(t *testing.T) {
+	tree := NewIntervalTree[int]()
+	tree.Insert(0, math.MaxInt, 1)
+	tree.Insert(5, 10, 2)
+	tree.Insert(math.MinInt, -1, 3)
+	if got := fmt.Sprint(tree.Merged()); got != fmt.Sprintf("[[%d, %d]]", math.MinInt, math.MaxInt) {
+		t.Errorf("Expected one interval covering every int, got %s", got)
+	}
+
+	tree.Clear()
+	tree.Insert(math.MinInt, math.MinInt, 1)
+	tree.Insert(math.MaxInt, math.MaxInt, 2)
+	if got := tree.Merged(); len(got) != 2 {
+		t.Errorf("Expected the extremes to stay apart, got %v", got)
+	}
+}
+
+func TestIntervalTreeBalanced(t *testing.T) {
+	// Sorted input would make an unbalanced tree a list
+	tree := NewIntervalTree[int]()
+	for i := 0; i < 1<<12; i++ {
+		tree.Insert(i, i+3, i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if tree.Height() > 14 {
+		t.Errorf("Expected height at most 14 for 4096 intervals, got %d", tree.Height())
+	}
+	if got := fmt.Sprint(entriesOf(tree.Stabbing(100))); got != "[[97, 100] [98, 101] [99, 102] [100, 103]]" {
+		t.Errorf("Unexpected stabbing result %s", got)
+	}
+}
+
+func TestIntervalTreeAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int]()
+	reference := make(map[Interval]int)
+
+	for i := 0; i < 3000; i++ {
+		lo := rng.Intn(200)
+		iv := Interval{lo, lo + rng.Intn(20)}
+		if rng.Intn(3) == 0 {
+			_, want := reference[iv]
+			if got := tree.Delete(iv.Lo, iv.Hi); got != want {
+				t.Fatalf("Delete(%s) = %v, want %v", iv, got, want)
+			}
+			delete(reference, iv)
+		} else {
+			tree.Insert(iv.Lo, iv.Hi, i)
+			reference[iv] = i
+		}
+		if i%100 == 0 {
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("After %d operations: %v", i, err)
+			}
+		}
+	}
+
+	all := make([]Interval, 0, len(reference))
+	for iv := range reference {
+		all = append(all, iv)
+	}
+	slices.SortFunc(all, compareIntervals)
+	if got := entriesOf(tree.All()); !slices.Equal(got, all) {
+		t.Fatalf("Expected %d intervals, got %d", len(all), len(got))
+	}
+
+	for i := 0; i < 300; i++ {
+		lo := rng.Intn(230) - 10
+		query := Interval{lo, lo + rng.Intn(10)}
+		var want []Interval
+		for _, iv := range all {
+			if iv.Overlaps(query) {
+				want = append(want, iv)
+			}
+		}
+		if got := entriesOf(tree.Overlapping(query.Lo, query.Hi)); !slices.Equal(got, want) {
+			t.Fatalf("Overlapping(%s) = %v, want %v", query, got, want)
+		}
+		if e, found := tree.AnyOverlap(query.Lo, query.Hi); found != (len(want) > 0) || (found && !e.Interval.Overlaps(query)) {
+			t.Fatalf("AnyOverlap(%s) = %v, %v with %d overlaps", query, e, found, len(want))
+		}
+	}
+
+	// Every point covered by some interval must lie in exactly one merged
+	// interval, and no uncovered point in any
+	merged := tree.Merged()
+	for point := -5; point < 230; point++ {
+		covered := false
+		for _, iv := range all {
+			covered = covered || iv.Contains(point)
+		}
+		in := 0
+		for _, m := range merged {
+			if m.Contains(point) {
+				in++
+			}
+		}
+		if (covered && in != 1) || (!covered && in != 0) {
+			t.Fatalf("Point %d covered %v but in %d merged intervals", point, covered, in)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMultisetBinaryTree(t *testing.T) {
+	bt := NewBinaryTreeWithMode(MultisetMode)
+	for _, v := range []int{5, 3, 8, 5, 3, 5, 9} {
+		bt.Insert(v)
+	}
+	if bt.Size() != 7 || bt.DistinctCount() != 4 || bt.Count(5) != 3 || bt.Count(4) != 0 {
+		t.Errorf("Expected 7 values, 4 distinct and three 5s, got %d, %d, %d",
+			bt.Size(), bt.DistinctCount(), bt.Count(5))
+	}
+	if got := fmt.Sprint(bt.InorderTraversal()); got != "[3 3 5 5 5 8 9]" {
+		t.Errorf("Expected repeats in inorder traversal, got %s", got)
+	}
+	if got := fmt.Sprint(bt.PreorderTraversal()); got != "[5 5 5 3 3 8 9]" {
+		t.Errorf("Expected repeats in preorder traversal, got %s", got)
+	}
+	if bt.String() != "Binary Tree:\n└── 5 (x3)\n    ├── 3 (x2)\n    └── 8\n        └── 9\n" {
+		t.Errorf("Unexpected drawing:\n%s", bt)
+	}
+
+	// Copies take their own positions in order statistics
+	if v, _ := bt.Select(4); v != 5 {
+		t.Errorf("Expected Select(4) = 5, got %d", v)
+	}
+	if bt.Rank(8) != 5 || bt.RangeCount(3, 5) != 5 || len(bt.Range(4, 9)) != 5 {
+		t.Errorf("Unexpected rank %d or range count %d", bt.Rank(8), bt.RangeCount(3, 5))
+	}
+
+	if !bt.DeleteOne(5) || bt.Count(5) != 2 || bt.Size() != 6 {
+		t.Errorf("Expected DeleteOne to leave two 5s, got %d", bt.Count(5))
+	}
+	if bt.DeleteOne(4) {
+		t.Error("Expected DeleteOne of an absent value to fail")
+	}
+
+	// Deleting the root moves every copy of its successor up
+	if n := bt.DeleteAll(5); n != 2 || bt.Root.Value != 8 {
+		t.Errorf("Expected to delete two 5s and promote 8, got %d\n%s", n, bt)
+	}
+	bt.Delete(3)
+	if got := fmt.Sprint(bt.InorderTraversal()); got != "[8 9]" || bt.Size() != 2 {
+		t.Errorf("Expected [8 9], got %s", got)
+	}
+}
+
+func TestSetModeIgnoresDuplicates(t *testing.T) {
+	bt := NewBinaryTree()
+	bt.Insert(1)
+	bt.Insert(1)
+	if bt.Count(1) != 1 || bt.Size() != 1 || bt.Mode() != SetMode {
+		t.Errorf("Expected a single 1 in set mode, got %d", bt.Count(1))
+	}
+	if bt.DeleteAll(1) != 1 || !bt.IsEmpty() {
+		t.Error("Expected DeleteAll to remove the only copy")
+	}
+}
+
+func TestMultisetAgainstSortedSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	bt := NewBinaryTreeWithMode(MultisetMode)
+	var reference []int
+
+	for i := 0; i < 3000; i++ {
+		v := rng.Intn(50)
+		idx := sort.SearchInts(reference, v)
+		present := idx < len(reference) && reference[idx] == v
+		switch rng.Intn(5) {
+		case 0:
+			if got := bt.DeleteOne(v); got != present {
+				t.Fatalf("DeleteOne(%d) = %v, want %v", v, got, present)
+			}
+			if present {
+				reference = append(reference[:idx], reference[idx+1:]...)
+			}
+		case 1:
+			end := sort.SearchInts(reference, v+1)
+			if got := bt.DeleteAll(v); got != end-idx {
+				t.Fatalf("DeleteAll(%d) = %d, want %d", v, got, end-idx)
+			}
+			reference = append(reference[:idx], reference[end:]...)
+		default:
+			bt.Insert(v)
+			reference = append(reference[:idx], append([]int{v}, reference[idx:]...)...)
+		}
+	}
+
+	if fmt.Sprint(bt.InorderTraversal()) != fmt.Sprint(reference) || bt.Size() != len(reference) {
+		t.Fatalf("Expected %v, got %v", reference, bt.InorderTraversal())
+	}
+	for k, want := range reference {
+		if got, _ := bt.Select(k); got != want {
+			t.Fatalf("Select(%d) = %d, want %d", k, got, want)
+		}
+	}
+}
+
+func TestHandBuiltBinaryTree(t *testing.T) {
+	// Nodes linked by hand have no counts or sizes yet
+	bt := &BinaryTree{Root: &TreeNode{Value: 2, Left: &TreeNode{Value: 1}, Right: &TreeNode{Value: 3}}}
+	if got := fmt.Sprint(bt.InorderTraversal()); got != "[1 2 3]" || bt.Size() != 3 {
+		t.Errorf("Expected [1 2 3] of size 3, got %s of size %d", got, bt.Size())
+	}
+	if bt.Count(3) != 1 || bt.LeetCodeString() != "[2,1,3]" {
+		t.Errorf("Expected one copy of 3 in [2,1,3], got %d in %s", bt.Count(3), bt.LeetCodeString())
+	}
+	if v, _ := bt.Select(1); v != 2 || bt.Rank(3) != 2 || bt.RangeCount(1, 3) != 3 {
+		t.Errorf("Expected order statistics over 3 values, got Select(1) = %d", v)
+	}
+	bt.Insert(4)
+	bt.Delete(1)
+	if got := fmt.Sprint(bt.InorderTraversal()); got != "[2 3 4]" || bt.Size() != 3 {
+		t.Errorf("Expected [2 3 4], got %s of size %d", got, bt.Size())
+	}
+
+	// Multiset inserts count the hand-built copy
+	multi := &BinaryTree{Root: &TreeNode{Value: 5}, mode: MultisetMode}
+	multi.Insert(5)
+	if multi.Count(5) != 2 || multi.Size() != 2 || !multi.DeleteOne(5) || multi.Count(5) != 1 {
+		t.Errorf("Expected two copies of 5 and then one, got\n%s", multi)
+	}
+
+	// Relinking nodes of a tree in use needs a Recount
+	bt.Root.Right.Right.Right = &TreeNode{Value: 6}
+	bt.Recount()
+	if bt.Size() != 4 || bt.Rank(6) != 3 {
+		t.Errorf("Expected 4 values after Recount, got %d", bt.Size())
+	}
+}
@@ -0,0 +1,141 @@
+package trees
+
+// Floor returns the largest value less than or equal to value
+func (bt *BinaryTree) Floor(value int) (int, bool) {
+	var floor *TreeNode
+	for node := bt.Root; node != nil; {
+		if value == node.Value {
+			return node.Value, true
+		}
+		if value < node.Value {
+			node = node.Left
+		} else {
+			floor = node
+			node = node.Right
+		}
+	}
+	return valueOf(floor)
+}
+
+// Ceiling returns the smallest value greater than or equal to value
+func (bt *BinaryTree) Ceiling(value int) (int, bool) {
+	var ceiling *TreeNode
+	for node := bt.Root; node != nil; {
+		if value == node.Value {
+			return node.Value, true
+		}
+		if value > node.Value {
+			node = node.Right
+		} else {
+			ceiling = node
+			node = node.Left
+		}
+	}
+	return valueOf(ceiling)
+}
+
+// Predecessor returns the largest value strictly less than value.
+// value itself need not be in the tree.
+func (bt *BinaryTree) Predecessor(value int) (int, bool) {
+	var predecessor *TreeNode
+	for node := bt.Root; node != nil; {
+		if node.Value < value {
+			predecessor = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return valueOf(predecessor)
+}
+
+// Successor returns the smallest value strictly greater than value.
+// value itself need not be in the tree.
+func (bt *BinaryTree) Successor(value int) (int, bool) {
+	var successor *TreeNode
+	for node := bt.Root; node != nil; {
+		if node.Value > value {
+			successor = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return valueOf(successor)
+}
+
+func valueOf(node *TreeNode) (int, bool) {
+	if node == nil {
+		return 0, false
+	}
+	return node.Value, true
+}
+
+// Select returns the k-th smallest value, counting from 0, so Select(0)
+// is the minimum and Select(Size()-1) the maximum. In multiset mode each
+// copy takes its own position.
+func (bt *BinaryTree) Select(k int) (int, bool) {
+	if k < 0 || k >= bt.Size() {
+		return 0, false
+	}
+	node := bt.Root
+	for {
+		left := nodeSize(node.Left)
+		switch {
+		case k < left:
+			node = node.Left
+		case k >= left+node.copies():
+			k -= left + node.copies()
+			node = node.Right
+		default:
+			return node.Value, true
+		}
+	}
+}
+
+// Rank returns the number of values strictly less than value. For a value
+// in the tree this is its index in sorted order, so Select(Rank(x)) == x.
+func (bt *BinaryTree) Rank(value int) int {
+	rank := 0
+	for node := bt.Root; node != nil; {
+		if value <= node.Value {
+			node = node.Left
+		} else {
+			rank += nodeSize(node.Left) + node.copies()
+			node = node.Right
+		}
+	}
+	return rank
+}
+
+// RangeCount returns the number of values in [lo, hi] in O(h), counting
+// every copy in multiset mode
+func (bt *BinaryTree) RangeCount(lo, hi int) int {
+	if lo > hi {
+		return 0
+	}
+	return bt.Rank(hi) - bt.Rank(lo) + bt.Count(hi)
+}
+
+// Range returns the values in [lo, hi] in ascending order, visiting only
+// the subtrees that can hold them
+func (bt *BinaryTree) Range(lo, hi int) []int {
+	var result []int
+	var walk func(node *TreeNode)
+	walk = func(node *TreeNode) {
+		if node == nil {
+			return
+		}
+		if lo < node.Value {
+			walk(node.Left)
+		}
+		if lo <= node.Value && node.Value <= hi {
+			result = appendCopies(result, node)
+		}
+		if node.Value < hi {
+			walk(node.Right)
+		}
+	}
+	walk(bt.Root)
+	return result
+}
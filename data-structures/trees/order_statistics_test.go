@@ -0,0 +1,119 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestOrderStatistics(t *testing.T) {
+	bt := NewBinaryTree()
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		bt.Insert(v)
+	}
+
+	tests := []struct {
+		name  string
+		query func(int) (int, bool)
+		arg   int
+		want  int
+		found bool
+	}{
+		{"Floor exact", bt.Floor, 40, 40, true},
+		{"Floor between", bt.Floor, 45, 40, true},
+		{"Floor below min", bt.Floor, 10, 0, false},
+		{"Ceiling between", bt.Ceiling, 45, 50, true},
+		{"Ceiling above max", bt.Ceiling, 81, 0, false},
+		{"Predecessor of member", bt.Predecessor, 50, 40, true},
+		{"Predecessor of absent", bt.Predecessor, 55, 50, true},
+		{"Predecessor of min", bt.Predecessor, 20, 0, false},
+		{"Successor of member", bt.Successor, 50, 60, true},
+		{"Successor of max", bt.Successor, 80, 0, false},
+		{"Select min", bt.Select, 0, 20, true},
+		{"Select median", bt.Select, 3, 50, true},
+		{"Select max", bt.Select, 6, 80, true},
+		{"Select out of range", bt.Select, 7, 0, false},
+	}
+	for _, tt := range tests {
+		if got, found := tt.query(tt.arg); got != tt.want || found != tt.found {
+			t.Errorf("%s(%d) = (%d, %v), want (%d, %v)", tt.name, tt.arg, got, found, tt.want, tt.found)
+		}
+	}
+
+	if bt.Rank(20) != 0 || bt.Rank(55) != 4 || bt.Rank(100) != 7 {
+		t.Errorf("Unexpected ranks %d %d %d", bt.Rank(20), bt.Rank(55), bt.Rank(100))
+	}
+	if got := fmt.Sprint(bt.Range(35, 70)); got != "[40 50 60 70]" {
+		t.Errorf("Expected [40 50 60 70], got %s", got)
+	}
+	if bt.RangeCount(35, 70) != 4 || bt.RangeCount(70, 35) != 0 {
+		t.Errorf("Expected 4 values in [35, 70], got %d", bt.RangeCount(35, 70))
+	}
+}
+
+func TestOrderStatisticsAgainstSortedSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bt := NewBinaryTree()
+	present := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		v := rng.Intn(1000)
+		if rng.Intn(4) == 0 {
+			bt.Delete(v)
+			delete(present, v)
+		} else {
+			bt.Insert(v)
+			present[v] = true
+		}
+	}
+
+	sorted := make([]int, 0, len(present))
+	for v := range present {
+		sorted = append(sorted, v)
+	}
+	sort.Ints(sorted)
+	if bt.Size() != len(sorted) {
+		t.Fatalf("Expected size %d, got %d", len(sorted), bt.Size())
+	}
+
+	for k, want := range sorted {
+		if got, _ := bt.Select(k); got != want {
+			t.Fatalf("Select(%d) = %d, want %d", k, got, want)
+		}
+	}
+	for x := -1; x <= 1001; x++ {
+		rank := sort.SearchInts(sorted, x)
+		if got := bt.Rank(x); got != rank {
+			t.Fatalf("Rank(%d) = %d, want %d", x, got, rank)
+		}
+		if succ, found := bt.Successor(x); found != (sort.SearchInts(sorted, x+1) < len(sorted)) ||
+			(found && succ != sorted[sort.SearchInts(sorted, x+1)]) {
+			t.Fatalf("Successor(%d) = (%d, %v)", x, succ, found)
+		}
+		if pred, found := bt.Predecessor(x); found != (rank > 0) || (found && pred != sorted[rank-1]) {
+			t.Fatalf("Predecessor(%d) = (%d, %v)", x, pred, found)
+		}
+	}
+
+	lo, hi := 250, 600
+	want := sorted[sort.SearchInts(sorted, lo):sort.SearchInts(sorted, hi+1)]
+	if got := bt.Range(lo, hi); fmt.Sprint(got) != fmt.Sprint(want) || bt.RangeCount(lo, hi) != len(want) {
+		t.Errorf("Range(%d, %d) = %v, want %v", lo, hi, got, want)
+	}
+}
+
+func TestLeaderboardPercentile(t *testing.T) {
+	scores := NewBinaryTree()
+	for score := 1; score <= 100; score++ {
+		scores.Insert(score * 10)
+	}
+
+	// A score of 905 beats 90 of the 100 players
+	if percentile := 100 * scores.Rank(905) / scores.Size(); percentile != 90 {
+		t.Errorf("Expected 90th percentile, got %d", percentile)
+	}
+	// The 95th percentile score is the 95th smallest
+	if score, _ := scores.Select(95*scores.Size()/100 - 1); score != 950 {
+		t.Errorf("Expected 950 at the 95th percentile, got %d", score)
+	}
+}
@@ -0,0 +1,348 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// RBNode represents a node in a red-black tree. A node is red when the
+// link from its parent is red.
+type RBNode struct {
+	Value int
+	Left  *RBNode
+	Right *RBNode
+	Red   bool
+}
+
+// RedBlackTree represents a left-leaning red-black tree of ints
+// (Sedgewick). Red links lean left and no node has two red links, so the
+// tree mirrors a 2-3 tree and every root-to-leaf path has the same number
+// of black links. The height is at most 2 log2(n+1).
+type RedBlackTree struct {
+	Root *RBNode
+	size int
+}
+
+// NewRedBlackTree creates a new empty red-black tree
+func NewRedBlackTree() *RedBlackTree {
+	return &RedBlackTree{}
+}
+
+func isRed(node *RBNode) bool {
+	return node != nil && node.Red
+}
+
+func (n *RBNode) rotateLeft() *RBNode {
+	right := n.Right
+	n.Right = right.Left
+	right.Left = n
+	right.Red = n.Red
+	n.Red = true
+	return right
+}
+
+func (n *RBNode) rotateRight() *RBNode {
+	left := n.Left
+	n.Left = left.Right
+	left.Right = n
+	left.Red = n.Red
+	n.Red = true
+	return left
+}
+
+// flipColors splits or merges a temporary 4-node
+func (n *RBNode) flipColors() {
+	n.Red = !n.Red
+	n.Left.Red = !n.Left.Red
+	n.Right.Red = !n.Right.Red
+}
+
+// balance restores the left-leaning invariants on the way up
+func (n *RBNode) balance() *RBNode {
+	if isRed(n.Right) && !isRed(n.Left) {
+		n = n.rotateLeft()
+	}
+	if isRed(n.Left) && isRed(n.Left.Left) {
+		n = n.rotateRight()
+	}
+	if isRed(n.Left) && isRed(n.Right) {
+		n.flipColors()
+	}
+	return n
+}
+
+// moveRedLeft makes n.Left or one of its children red, assuming n is red
+// and both n.Left and n.Left.Left are black
+func (n *RBNode) moveRedLeft() *RBNode {
+	n.flipColors()
+	if isRed(n.Right.Left) {
+		n.Right = n.Right.rotateRight()
+		n = n.rotateLeft()
+		n.flipColors()
+	}
+	return n
+}
+
+// moveRedRight makes n.Right or one of its children red, assuming n is red
+// and both n.Right and n.Right.Left are black
+func (n *RBNode) moveRedRight() *RBNode {
+	n.flipColors()
+	if isRed(n.Left.Left) {
+		n = n.rotateRight()
+		n.flipColors()
+	}
+	return n
+}
+
+// Insert inserts a value into the tree; duplicates are ignored
+func (t *RedBlackTree) Insert(value int) {
+	t.Root = t.insertRec(t.Root, value)
+	t.Root.Red = false
+}
+
+func (t *RedBlackTree) insertRec(node *RBNode, value int) *RBNode {
+	if node == nil {
+		t.size++
+		return &RBNode{Value: value, Red: true}
+	}
+
+	if value < node.Value {
+		node.Left = t.insertRec(node.Left, value)
+	} else if value > node.Value {
+		node.Right = t.insertRec(node.Right, value)
+	}
+	return node.balance()
+}
+
+// Search searches for a value in the tree
+func (t *RedBlackTree) Search(value int) bool {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			return true
+		}
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Delete removes a value from the tree
+func (t *RedBlackTree) Delete(value int) {
+	if !t.Search(value) {
+		return
+	}
+
+	// Make the root red so the descent can always borrow a red link
+	if !isRed(t.Root.Left) && !isRed(t.Root.Right) {
+		t.Root.Red = true
+	}
+	t.Root = deleteRB(t.Root, value)
+	if t.Root != nil {
+		t.Root.Red = false
+	}
+	t.size--
+}
+
+// deleteRB removes value, which must be present, keeping the current
+// node or one of its children red on the way down
+func deleteRB(node *RBNode, value int) *RBNode {
+	if value < node.Value {
+		if !isRed(node.Left) && !isRed(node.Left.Left) {
+			node = node.moveRedLeft()
+		}
+		node.Left = deleteRB(node.Left, value)
+	} else {
+		if isRed(node.Left) {
+			node = node.rotateRight()
+		}
+		if value == node.Value && node.Right == nil {
+			return nil
+		}
+		if !isRed(node.Right) && !isRed(node.Right.Left) {
+			node = node.moveRedRight()
+		}
+		if value == node.Value {
+			// Take over the inorder successor and delete it from the right
+			successor := node.Right
+			for successor.Left != nil {
+				successor = successor.Left
+			}
+			node.Value = successor.Value
+			node.Right = deleteMinRB(node.Right)
+		} else {
+			node.Right = deleteRB(node.Right, value)
+		}
+	}
+	return node.balance()
+}
+
+func deleteMinRB(node *RBNode) *RBNode {
+	if node.Left == nil {
+		return nil
+	}
+	if !isRed(node.Left) && !isRed(node.Left.Left) {
+		node = node.moveRedLeft()
+	}
+	node.Left = deleteMinRB(node.Left)
+	return node.balance()
+}
+
+// InorderTraversal returns values in inorder traversal (left, root, right)
+func (t *RedBlackTree) InorderTraversal() []int {
+	var result []int
+	var walk func(node *RBNode)
+	walk = func(node *RBNode) {
+		if node != nil {
+			walk(node.Left)
+			result = append(result, node.Value)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PreorderTraversal returns values in preorder traversal (root, left, right)
+func (t *RedBlackTree) PreorderTraversal() []int {
+	var result []int
+	var walk func(node *RBNode)
+	walk = func(node *RBNode) {
+		if node != nil {
+			result = append(result, node.Value)
+			walk(node.Left)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PostorderTraversal returns values in postorder traversal (left, right, root)
+func (t *RedBlackTree) PostorderTraversal() []int {
+	var result []int
+	var walk func(node *RBNode)
+	walk = func(node *RBNode) {
+		if node != nil {
+			walk(node.Left)
+			walk(node.Right)
+			result = append(result, node.Value)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// Height returns the height of the tree
+func (t *RedBlackTree) Height() int {
+	var height func(node *RBNode) int
+	height = func(node *RBNode) int {
+		if node == nil {
+			return -1
+		}
+		return max(height(node.Left), height(node.Right)) + 1
+	}
+	return height(t.Root)
+}
+
+// BlackHeight returns the number of black links on the path from the root
+// to the leftmost leaf. In a valid tree every path has this many.
+func (t *RedBlackTree) BlackHeight() int {
+	blacks := 0
+	for node := t.Root; node != nil; node = node.Left {
+		if !node.Red {
+			blacks++
+		}
+	}
+	return blacks
+}
+
+// IsEmpty checks if the tree is empty
+func (t *RedBlackTree) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Size returns the number of nodes in the tree
+func (t *RedBlackTree) Size() int {
+	return t.size
+}
+
+// Validate checks the search tree order, that the root is black, that red
+// links lean left and never come in pairs, that every path has the same
+// black height, and the node count. It returns the first violation found,
+// or nil.
+func (t *RedBlackTree) Validate() error {
+	if isRed(t.Root) {
+		return errors.New("root is red")
+	}
+
+	want := t.BlackHeight()
+	count := 0
+	var check func(node *RBNode, lo, hi *int, blacks int) error
+	check = func(node *RBNode, lo, hi *int, blacks int) error {
+		if node == nil {
+			if blacks != want {
+				return fmt.Errorf("path with black height %d, want %d", blacks, want)
+			}
+			return nil
+		}
+		count++
+		if (lo != nil && node.Value <= *lo) || (hi != nil && node.Value >= *hi) {
+			return fmt.Errorf("node %d is out of order", node.Value)
+		}
+		if isRed(node.Right) {
+			return fmt.Errorf("node %d has a red right link", node.Value)
+		}
+		if node.Red && isRed(node.Left) {
+			return fmt.Errorf("node %d and its left child are both red", node.Value)
+		}
+		if !node.Red {
+			blacks++
+		}
+		if err := check(node.Left, lo, &node.Value, blacks); err != nil {
+			return err
+		}
+		return check(node.Right, &node.Value, hi, blacks)
+	}
+
+	if err := check(t.Root, nil, nil, 0); err != nil {
+		return err
+	}
+	if count != t.size {
+		return errors.New("size does not match the number of nodes")
+	}
+	return nil
+}
+
+// String returns a string representation of the tree, marking red nodes
+func (t *RedBlackTree) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("Red-Black Tree:\n")
+	var printTree func(node *RBNode, prefix string, isLast bool)
+	printTree = func(node *RBNode, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		if node.Red {
+			result.WriteString(fmt.Sprintf("%d (red)\n", node.Value))
+		} else {
+			result.WriteString(fmt.Sprintf("%d\n", node.Value))
+		}
+		children := []*RBNode{node.Left, node.Right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.Root, "", true)
+	return result.String()
+}
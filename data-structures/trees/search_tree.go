@@ -0,0 +1,35 @@
+package trees
+
+// SearchTree is the int API shared by BinaryTree, AVLTree, RedBlackTree,
+// Treap and SplayTree
+type SearchTree interface {
+	// Insert inserts a value; duplicates are ignored unless the tree
+	// is a multiset
+	Insert(value int)
+	// Search checks if the tree contains value
+	Search(value int) bool
+	// Delete removes value if present
+	Delete(value int)
+	// InorderTraversal returns the values in sorted order
+	InorderTraversal() []int
+	// PreorderTraversal returns the values root first
+	PreorderTraversal() []int
+	// PostorderTraversal returns the values root last
+	PostorderTraversal() []int
+	// Height returns the number of edges on the longest root-to-leaf path
+	Height() int
+	// IsEmpty checks if the tree is empty
+	IsEmpty() bool
+	// Size returns the number of values
+	Size() int
+	// String returns a drawing of the tree
+	String() string
+}
+
+var (
+	_ SearchTree = (*BinaryTree)(nil)
+	_ SearchTree = (*AVLTree)(nil)
+	_ SearchTree = (*RedBlackTree)(nil)
+	_ SearchTree = (*Treap)(nil)
+	_ SearchTree = (*SplayTree)(nil)
+)
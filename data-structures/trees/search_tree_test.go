@@ -0,0 +1,179 @@
+package trees
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// validator is implemented by the self-balancing trees
+type validator interface {
+	Validate() error
+}
+
+var searchTreeVariants = []struct {
+	name string
+	new  func() SearchTree
+}{
+	{"binary", func() SearchTree { return NewBinaryTree() }},
+	{"avl", func() SearchTree { return NewAVLTree() }},
+	{"redblack", func() SearchTree { return NewRedBlackTree() }},
+	{"treap", func() SearchTree { return NewTreapWithSeed(1) }},
+	{"splay", func() SearchTree { return NewSplayTree() }},
+}
+
+// validate runs the tree's invariant checks, if it has any
+func validate(t *testing.T, tree SearchTree, step string) {
+	t.Helper()
+	if v, ok := tree.(validator); ok {
+		if err := v.Validate(); err != nil {
+			t.Fatalf("after %s: %v\n%s", step, err, tree)
+		}
+	}
+}
+
+func TestSearchTreeVariants(t *testing.T) {
+	for _, variant := range searchTreeVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			tree := variant.new()
+			if !tree.IsEmpty() || tree.Height() != -1 || tree.String() != "Empty tree" {
+				t.Error("Expected tree to be empty")
+			}
+
+			for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 30} {
+				tree.Insert(v)
+				validate(t, tree, fmt.Sprintf("Insert(%d)", v))
+			}
+			if tree.Size() != 7 || !tree.Search(40) || tree.Search(45) {
+				t.Errorf("Expected 7 values including 40, got %v", tree.InorderTraversal())
+			}
+			if got := fmt.Sprint(tree.InorderTraversal()); got != "[20 30 40 50 60 70 80]" {
+				t.Errorf("Expected sorted inorder traversal, got %s", got)
+			}
+			if len(tree.PreorderTraversal()) != 7 || len(tree.PostorderTraversal()) != 7 {
+				t.Error("Expected every traversal to visit 7 values")
+			}
+
+			for _, v := range []int{20, 50, 99, 70} {
+				tree.Delete(v)
+				validate(t, tree, fmt.Sprintf("Delete(%d)", v))
+			}
+			if got := fmt.Sprint(tree.InorderTraversal()); got != "[30 40 60 80]" || tree.Size() != 4 {
+				t.Errorf("Expected [30 40 60 80], got %s", got)
+			}
+		})
+	}
+}
+
+func TestBalancedTreesAgainstReference(t *testing.T) {
+	for _, variant := range searchTreeVariants[1:] {
+		t.Run(variant.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			tree := variant.new()
+			reference := make(map[int]bool)
+
+			for i := 0; i < 3000; i++ {
+				v := rng.Intn(500)
+				if rng.Intn(3) == 0 {
+					tree.Delete(v)
+					delete(reference, v)
+				} else {
+					tree.Insert(v)
+					reference[v] = true
+				}
+				validate(t, tree, fmt.Sprintf("step %d", i))
+			}
+
+			want := make([]int, 0, len(reference))
+			for v := range reference {
+				want = append(want, v)
+			}
+			sort.Ints(want)
+			if fmt.Sprint(tree.InorderTraversal()) != fmt.Sprint(want) || tree.Size() != len(want) {
+				t.Errorf("Expected %v, got %v", want, tree.InorderTraversal())
+			}
+		})
+	}
+}
+
+func TestBalancedTreesStayShallowOnSortedInput(t *testing.T) {
+	const n = 1 << 12
+	bounds := map[string]float64{
+		"avl":      1.45 * math.Log2(n+2), // AVL height < 1.44 log2(n+2)
+		"redblack": 2 * math.Log2(n+1),    // red-black height <= 2 log2(n+1)
+		"treap":    4 * math.Log2(n),      // expected height is about 3 ln n
+	}
+
+	for _, variant := range searchTreeVariants[1:] {
+		bound, bounded := bounds[variant.name]
+		if !bounded {
+			continue // a splay tree is only balanced amortized
+		}
+		t.Run(variant.name, func(t *testing.T) {
+			tree := variant.new()
+			for v := 0; v < n; v++ {
+				tree.Insert(v)
+			}
+			validate(t, tree, "sorted inserts")
+			if h := float64(tree.Height()); h > bound {
+				t.Errorf("Height %v exceeds bound %.1f", h, bound)
+			}
+
+			// Deleting the smaller half keeps the tree balanced
+			for v := 0; v < n/2; v++ {
+				tree.Delete(v)
+			}
+			validate(t, tree, "sorted deletes")
+			if tree.Size() != n/2 {
+				t.Errorf("Expected %d values, got %d", n/2, tree.Size())
+			}
+		})
+	}
+}
+
+func TestBalanceHelpers(t *testing.T) {
+	avl := NewAVLTree()
+	for v := 1; v <= 3; v++ {
+		avl.Insert(v)
+	}
+	// Sorted inserts trigger a left rotation around 1
+	if avl.Root.Value != 2 || avl.Root.BalanceFactor() != 0 {
+		t.Errorf("Expected balanced root 2, got\n%s", avl)
+	}
+	if avl.String() != "AVL Tree:\n└── 2 [+0]\n    ├── 1 [+0]\n    └── 3 [+0]\n" {
+		t.Errorf("Unexpected drawing:\n%s", avl)
+	}
+
+	rb := NewRedBlackTree()
+	for v := 1; v <= 7; v++ {
+		rb.Insert(v)
+	}
+	if rb.BlackHeight() != 3 || rb.Root.Value != 4 {
+		t.Errorf("Expected black height 3 under root 4, got %d\n%s", rb.BlackHeight(), rb)
+	}
+
+	// Corrupting a tree is reported by Validate
+	rb.Root.Right.Red = true
+	if rb.Validate() == nil {
+		t.Error("Expected a red right link to be rejected")
+	}
+	avl.Root.Left.height = 5
+	if avl.Validate() == nil {
+		t.Error("Expected a wrong stored height to be rejected")
+	}
+}
+
+func BenchmarkSearchTreeInsertSorted(b *testing.B) {
+	for _, variant := range searchTreeVariants {
+		b.Run(variant.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree := variant.new()
+				for v := 0; v < 1000; v++ {
+					tree.Insert(v)
+				}
+			}
+		})
+	}
+}
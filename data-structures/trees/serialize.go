@@ -0,0 +1,330 @@
+package trees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// nullMarker stands for a missing child in the preorder encoding
+const nullMarker = "#"
+
+// ErrInvalidTraversals is returned when traversal slices do not describe
+// a tree of distinct values
+var ErrInvalidTraversals = errors.New("traversals do not describe the same tree of distinct values")
+
+// treeFromRoot wraps hand-built nodes in a BinaryTree and recounts them.
+// The nodes keep their shape, so the result need not be a valid BST.
+func treeFromRoot(root *TreeNode) *BinaryTree {
+	bt := &BinaryTree{Root: root}
+	bt.Recount()
+	return bt
+}
+
+// LeetCodeString returns the tree in LeetCode's level-order format, such as
+// [4,2,7,1,3,null,9], with trailing nulls trimmed. Multiset copies are not
+// represented; each node appears once.
+func (bt *BinaryTree) LeetCodeString() string {
+	var tokens []string
+	queue := []*TreeNode{bt.Root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			tokens = append(tokens, "null")
+			continue
+		}
+		tokens = append(tokens, strconv.Itoa(node.Value))
+		queue = append(queue, node.Left, node.Right)
+	}
+
+	for len(tokens) > 0 && tokens[len(tokens)-1] == "null" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return "[" + strings.Join(tokens, ",") + "]"
+}
+
+// ParseLeetCode builds a tree from LeetCode's level-order format. The shape
+// is kept exactly as written, so the result is only a valid binary search
+// tree if the input describes one.
+func ParseLeetCode(s string) (*BinaryTree, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("expected a bracketed list, got %q", s)
+	}
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	if body == "" {
+		return NewBinaryTree(), nil
+	}
+
+	tokens := strings.Split(body, ",")
+	nodes := make([]*TreeNode, len(tokens))
+	for i, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "null" {
+			continue
+		}
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token %q at position %d", token, i)
+		}
+		nodes[i] = &TreeNode{Value: value}
+	}
+	if nodes[0] == nil {
+		return nil, errors.New("root cannot be null")
+	}
+
+	// Each non-null node takes the next two tokens as its children, so a
+	// node must have been claimed by a parent before its own turn comes
+	next := 1
+	for i := 0; i < len(nodes) && next < len(nodes); i++ {
+		if i >= next {
+			return nil, fmt.Errorf("token %d has no parent", i)
+		}
+		if nodes[i] == nil {
+			continue
+		}
+		nodes[i].Left = nodes[next]
+		next++
+		if next < len(nodes) {
+			nodes[i].Right = nodes[next]
+			next++
+		}
+	}
+	if next < len(nodes) {
+		return nil, fmt.Errorf("%d tokens have no parent", len(nodes)-next)
+	}
+	return treeFromRoot(nodes[0]), nil
+}
+
+// SerializePreorder encodes the tree in preorder with # for missing
+// children, such as 2,1,#,#,3,#,#
+func (bt *BinaryTree) SerializePreorder() string {
+	var tokens []string
+	stack := []*TreeNode{bt.Root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node == nil {
+			tokens = append(tokens, nullMarker)
+			continue
+		}
+		tokens = append(tokens, strconv.Itoa(node.Value))
+		stack = append(stack, node.Right, node.Left)
+	}
+	return strings.Join(tokens, ",")
+}
+
+// DeserializePreorder builds a tree from the output of SerializePreorder.
+// "null" is accepted as well as #.
+func DeserializePreorder(s string) (*BinaryTree, error) {
+	tokens := strings.Split(s, ",")
+	pos := 0
+	var build func() (*TreeNode, error)
+	build = func() (*TreeNode, error) {
+		if pos >= len(tokens) {
+			return nil, errors.New("unexpected end of input")
+		}
+		token := strings.TrimSpace(tokens[pos])
+		pos++
+		if token == nullMarker || token == "null" {
+			return nil, nil
+		}
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token %q at position %d", token, pos-1)
+		}
+
+		node := &TreeNode{Value: value}
+		if node.Left, err = build(); err != nil {
+			return nil, err
+		}
+		if node.Right, err = build(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	root, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(tokens) {
+		return nil, fmt.Errorf("%d extra tokens after the tree", len(tokens)-pos)
+	}
+	return treeFromRoot(root), nil
+}
+
+// jsonNode is the JSON shape of a TreeNode
+type jsonNode struct {
+	Value int       `json:"value"`
+	Count int       `json:"count,omitempty"` // only written for multiset copies
+	Left  *jsonNode `json:"left,omitempty"`
+	Right *jsonNode `json:"right,omitempty"`
+}
+
+// jsonTree is the JSON shape of a BinaryTree
+type jsonTree struct {
+	Mode string    `json:"mode"`
+	Root *jsonNode `json:"root"`
+}
+
+func toJSONNode(node *TreeNode) *jsonNode {
+	if node == nil {
+		return nil
+	}
+	jn := &jsonNode{Value: node.Value, Left: toJSONNode(node.Left), Right: toJSONNode(node.Right)}
+	if node.copies() > 1 {
+		jn.Count = node.copies()
+	}
+	return jn
+}
+
+// fromJSONNode rebuilds the nodes under jn, rejecting counts that mode
+// cannot hold
+func fromJSONNode(jn *jsonNode, mode Mode) (*TreeNode, error) {
+	if jn == nil {
+		return nil, nil
+	}
+	if jn.Count < 0 {
+		return nil, fmt.Errorf("node %d has negative count %d", jn.Value, jn.Count)
+	}
+	if mode == SetMode && jn.Count > 1 {
+		return nil, fmt.Errorf("node %d has count %d in set mode", jn.Value, jn.Count)
+	}
+	left, err := fromJSONNode(jn.Left, mode)
+	if err != nil {
+		return nil, err
+	}
+	right, err := fromJSONNode(jn.Right, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &TreeNode{Value: jn.Value, Left: left, Right: right, count: jn.Count}, nil
+}
+
+// MarshalJSON encodes the tree as nested objects, for example
+// {"mode":"set","root":{"value":2,"left":{"value":1}}}
+func (bt *BinaryTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTree{Mode: bt.mode.String(), Root: toJSONNode(bt.Root)})
+}
+
+// UnmarshalJSON decodes a tree produced by MarshalJSON
+func (bt *BinaryTree) UnmarshalJSON(data []byte) error {
+	var jt jsonTree
+	if err := json.Unmarshal(data, &jt); err != nil {
+		return err
+	}
+
+	var mode Mode
+	switch jt.Mode {
+	case SetMode.String(), "":
+		mode = SetMode
+	case MultisetMode.String():
+		mode = MultisetMode
+	default:
+		return fmt.Errorf("unknown mode %q", jt.Mode)
+	}
+
+	root, err := fromJSONNode(jt.Root, mode)
+	if err != nil {
+		return err
+	}
+	*bt = *treeFromRoot(root)
+	bt.mode = mode
+	return nil
+}
+
+// BuildFromPreorderInorder rebuilds the unique tree of distinct values with
+// the given preorder and inorder traversals
+func BuildFromPreorderInorder(preorder, inorder []int) (*BinaryTree, error) {
+	index, err := inorderIndex(preorder, inorder)
+	if err != nil {
+		return nil, err
+	}
+
+	// The next preorder value is always the root of the current range
+	next := 0
+	var build func(lo, hi int) (*TreeNode, error)
+	build = func(lo, hi int) (*TreeNode, error) {
+		if lo > hi {
+			return nil, nil
+		}
+		value := preorder[next]
+		mid, found := index[value]
+		if !found || mid < lo || mid > hi {
+			return nil, ErrInvalidTraversals
+		}
+		next++
+		node := &TreeNode{Value: value}
+		if node.Left, err = build(lo, mid-1); err != nil {
+			return nil, err
+		}
+		if node.Right, err = build(mid+1, hi); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	root, err := build(0, len(inorder)-1)
+	if err != nil {
+		return nil, err
+	}
+	return treeFromRoot(root), nil
+}
+
+// BuildFromPostorderInorder rebuilds the unique tree of distinct values
+// with the given postorder and inorder traversals
+func BuildFromPostorderInorder(postorder, inorder []int) (*BinaryTree, error) {
+	index, err := inorderIndex(postorder, inorder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walking postorder backwards gives root, right subtree, left subtree
+	next := len(postorder) - 1
+	var build func(lo, hi int) (*TreeNode, error)
+	build = func(lo, hi int) (*TreeNode, error) {
+		if lo > hi {
+			return nil, nil
+		}
+		value := postorder[next]
+		mid, found := index[value]
+		if !found || mid < lo || mid > hi {
+			return nil, ErrInvalidTraversals
+		}
+		next--
+		node := &TreeNode{Value: value}
+		if node.Right, err = build(mid+1, hi); err != nil {
+			return nil, err
+		}
+		if node.Left, err = build(lo, mid-1); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	root, err := build(0, len(inorder)-1)
+	if err != nil {
+		return nil, err
+	}
+	return treeFromRoot(root), nil
+}
+
+// inorderIndex maps each value to its inorder position, checking that both
+// traversals have the same length and inorder has no duplicates
+func inorderIndex(order, inorder []int) (map[int]int, error) {
+	if len(order) != len(inorder) {
+		return nil, ErrInvalidTraversals
+	}
+	index := make(map[int]int, len(inorder))
+	for i, value := range inorder {
+		if _, dup := index[value]; dup {
+			return nil, ErrInvalidTraversals
+		}
+		index[value] = i
+	}
+	return index, nil
+}
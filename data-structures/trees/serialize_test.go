@@ -0,0 +1,175 @@
+package trees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLeetCodeString(t *testing.T) {
+	bt := NewBinaryTree()
+	for _, v := range []int{4, 2, 7, 1, 3, 9} {
+		bt.Insert(v)
+	}
+	if got := bt.LeetCodeString(); got != "[4,2,7,1,3,null,9]" {
+		t.Errorf("Unexpected LeetCode string %s", got)
+	}
+	if got := NewBinaryTree().LeetCodeString(); got != "[]" {
+		t.Errorf("Expected [] for an empty tree, got %s", got)
+	}
+
+	// Round trip keeps the shape, sizes and BST queries working
+	parsed, err := ParseLeetCode(bt.LeetCodeString())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsed.LeetCodeString() != bt.LeetCodeString() || parsed.String() != bt.String() {
+		t.Errorf("Round trip changed the tree:\n%s", parsed)
+	}
+	if parsed.Size() != 6 || !parsed.Search(9) {
+		t.Errorf("Expected 6 values including 9, got %d", parsed.Size())
+	}
+	if v, _ := parsed.Select(3); v != 4 {
+		t.Errorf("Expected Select(3) = 4, got %d", v)
+	}
+
+	// Shapes that are not search trees are kept as written
+	skewed, err := ParseLeetCode("[1, null, 2, 3]")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(skewed.PreorderTraversal()); got != "[1 2 3]" {
+		t.Errorf("Unexpected preorder %s", got)
+	}
+	if skewed.Root.Right.Left.Value != 3 {
+		t.Error("Expected 3 to be the left child of 2")
+	}
+
+	for _, input := range []string{"4,2,7", "[null,1]", "[1,x]", "[1,null,null,2]"} {
+		if _, err := ParseLeetCode(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestPreorderSerialization(t *testing.T) {
+	bt := sampleTree()
+	encoded := bt.SerializePreorder()
+	if encoded != "50,30,20,#,#,40,#,45,#,#,70,60,#,#,80,#,#" {
+		t.Errorf("Unexpected encoding %s", encoded)
+	}
+
+	decoded, err := DeserializePreorder(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.String() != bt.String() || decoded.Size() != bt.Size() {
+		t.Errorf("Round trip changed the tree:\n%s", decoded)
+	}
+
+	empty, err := DeserializePreorder(NewBinaryTree().SerializePreorder())
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected an empty tree, got %v, %v", empty, err)
+	}
+	if _, err := DeserializePreorder("1,null,null"); err != nil {
+		t.Errorf("Expected null markers to be accepted, got %v", err)
+	}
+	for _, input := range []string{"1,#", "1,#,#,#", "1,a,#"} {
+		if _, err := DeserializePreorder(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestJSONSerialization(t *testing.T) {
+	bt := NewBinaryTreeWithMode(MultisetMode)
+	for _, v := range []int{2, 1, 3, 3} {
+		bt.Insert(v)
+	}
+	data, err := json.Marshal(bt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"mode":"multiset","root":{"value":2,"left":{"value":1},"right":{"value":3,"count":2}}}`
+	if string(data) != want {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	var decoded BinaryTree
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Mode() != MultisetMode || decoded.Size() != 4 || decoded.Count(3) != 2 {
+		t.Errorf("Expected a multiset of 4 values with two 3s, got %s", &decoded)
+	}
+	decoded.Insert(3)
+	if decoded.Count(3) != 3 {
+		t.Errorf("Expected the decoded tree to keep counting, got %d", decoded.Count(3))
+	}
+
+	if err := json.Unmarshal([]byte(`{"mode":"bag"}`), &decoded); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+	if err := json.Unmarshal([]byte(`{"mode":"set","root":null}`), &decoded); err != nil || !decoded.IsEmpty() {
+		t.Errorf("Expected an empty tree, got %v", err)
+	}
+
+	// A set holds each value once, so repeated counts are rejected
+	for _, data := range []string{
+		`{"mode":"set","root":{"value":2,"count":3}}`,
+		`{"root":{"value":2,"left":{"value":1,"count":2}}}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &decoded); err == nil {
+			t.Errorf("Expected an error for %s", data)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"mode":"set","root":{"value":2,"count":1}}`), &decoded); err != nil || decoded.Size() != 1 {
+		t.Errorf("Expected a count of 1 to be accepted in set mode, got %v", err)
+	}
+}
+
+func TestBuildFromTraversals(t *testing.T) {
+	bt := sampleTree()
+	inorder := bt.InorderTraversal()
+
+	fromPre, err := BuildFromPreorderInorder(bt.PreorderTraversal(), inorder)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fromPost, err := BuildFromPostorderInorder(bt.PostorderTraversal(), inorder)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, rebuilt := range []*BinaryTree{fromPre, fromPost} {
+		if rebuilt.String() != bt.String() || rebuilt.Size() != bt.Size() {
+			t.Errorf("Rebuilt tree differs:\n%s", rebuilt)
+		}
+	}
+
+	// Works for shapes that are not search trees too
+	fromPre, err = BuildFromPreorderInorder([]int{3, 9, 20, 15, 7}, []int{9, 3, 15, 20, 7})
+	if err != nil || fromPre.LeetCodeString() != "[3,9,20,null,null,15,7]" {
+		t.Errorf("Unexpected tree %s, %v", fromPre.LeetCodeString(), err)
+	}
+
+	empty, err := BuildFromPostorderInorder(nil, nil)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected an empty tree, got %v", err)
+	}
+
+	invalid := [][2][]int{
+		{{1, 2}, {1}},
+		{{1, 2}, {1, 1}},
+		{{1, 2}, {1, 3}},
+		{{1, 2, 3}, {3, 1, 2}}, // 3 would have to be left of its ancestor 1
+	}
+	for _, pair := range invalid {
+		if _, err := BuildFromPreorderInorder(pair[0], pair[1]); !errors.Is(err, ErrInvalidTraversals) {
+			t.Errorf("Expected ErrInvalidTraversals for %v, got %v", pair, err)
+		}
+	}
+	if _, err := BuildFromPostorderInorder([]int{1, 2}, []int{2, 3}); !errors.Is(err, ErrInvalidTraversals) {
+		t.Errorf("Expected ErrInvalidTraversals, got %v", err)
+	}
+}
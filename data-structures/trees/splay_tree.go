@@ -0,0 +1,308 @@
+package trees
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// SplayNode represents a node in a splay tree
+type SplayNode struct {
+	Value int
+	Left  *SplayNode
+	Right *SplayNode
+	size  int // number of nodes in the subtree rooted here
+}
+
+func splaySize(node *SplayNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func (n *SplayNode) update() {
+	n.size = 1 + splaySize(n.Left) + splaySize(n.Right)
+}
+
+func (n *SplayNode) rotateLeft() *SplayNode {
+	right := n.Right
+	n.Right = right.Left
+	right.Left = n
+	n.update()
+	right.update()
+	return right
+}
+
+func (n *SplayNode) rotateRight() *SplayNode {
+	left := n.Left
+	n.Left = left.Right
+	left.Right = n
+	n.update()
+	left.update()
+	return left
+}
+
+// splay brings the node holding key to the root of the subtree, or the
+// last node visited while looking for it, using zig-zig and zig-zag steps
+func splay(node *SplayNode, key int) *SplayNode {
+	if node == nil || node.Value == key {
+		return node
+	}
+
+	if key < node.Value {
+		if node.Left == nil {
+			return node
+		}
+		if key < node.Left.Value {
+			node.Left.Left = splay(node.Left.Left, key)
+			node = node.rotateRight() // zig-zig
+		} else if key > node.Left.Value {
+			node.Left.Right = splay(node.Left.Right, key)
+			if node.Left.Right != nil {
+				node.Left = node.Left.rotateLeft() // zig-zag
+			}
+		}
+		if node.Left == nil {
+			return node
+		}
+		return node.rotateRight()
+	}
+
+	if node.Right == nil {
+		return node
+	}
+	if key > node.Right.Value {
+		node.Right.Right = splay(node.Right.Right, key)
+		node = node.rotateLeft() // zig-zig
+	} else if key < node.Right.Value {
+		node.Right.Left = splay(node.Right.Left, key)
+		if node.Right.Left != nil {
+			node.Right = node.Right.rotateRight() // zig-zag
+		}
+	}
+	if node.Right == nil {
+		return node
+	}
+	return node.rotateLeft()
+}
+
+// SplayTree represents a self-adjusting binary search tree of ints. Every
+// access moves the value it touched to the root, so recently and
+// frequently used values stay cheap to reach. Operations take O(log n)
+// amortized, though a single one can take O(n).
+type SplayTree struct {
+	Root *SplayNode
+}
+
+// NewSplayTree creates a new empty splay tree
+func NewSplayTree() *SplayTree {
+	return &SplayTree{}
+}
+
+// Insert inserts a value into the tree and splays it to the root;
+// duplicates are ignored
+func (t *SplayTree) Insert(value int) {
+	t.Root = splay(t.Root, value)
+	if t.Root != nil && t.Root.Value == value {
+		return
+	}
+
+	node := &SplayNode{Value: value}
+	if t.Root != nil {
+		if value < t.Root.Value {
+			node.Left, node.Right = t.Root.Left, t.Root
+			t.Root.Left = nil
+		} else {
+			node.Left, node.Right = t.Root, t.Root.Right
+			t.Root.Right = nil
+		}
+		t.Root.update()
+	}
+	node.update()
+	t.Root = node
+}
+
+// Search searches for a value and splays it, or the last node visited, to
+// the root
+func (t *SplayTree) Search(value int) bool {
+	t.Root = splay(t.Root, value)
+	return t.Root != nil && t.Root.Value == value
+}
+
+// Delete removes a value from the tree
+func (t *SplayTree) Delete(value int) {
+	if !t.Search(value) {
+		return
+	}
+	left, right := t.Root.Left, t.Root.Right
+	if left == nil {
+		t.Root = right
+		return
+	}
+	// The largest value on the left has no right child after splaying
+	left = splay(left, value)
+	left.Right = right
+	left.update()
+	t.Root = left
+}
+
+// Split moves every value >= key into a new splay tree, which it returns,
+// and keeps the values below key. It takes O(log n) amortized.
+func (t *SplayTree) Split(key int) *SplayTree {
+	t.Root = splay(t.Root, key)
+	if t.Root == nil {
+		return NewSplayTree()
+	}
+	if t.Root.Value < key {
+		right := t.Root.Right
+		t.Root.Right = nil
+		t.Root.update()
+		return &SplayTree{Root: right}
+	}
+	right := t.Root
+	t.Root = right.Left
+	right.Left = nil
+	right.update()
+	return &SplayTree{Root: right}
+}
+
+// Join moves every value of other into t in O(log n) amortized, leaving
+// other empty. Every value of other must be greater than every value of t.
+func (t *SplayTree) Join(other *SplayTree) error {
+	if t.Root == nil {
+		t.Root, other.Root = other.Root, nil
+		return nil
+	}
+	if other.Root == nil {
+		return nil
+	}
+
+	// Splaying past either end brings the maximum or minimum to the root
+	t.Root = splay(t.Root, math.MaxInt)
+	other.Root = splay(other.Root, math.MinInt)
+	if other.Root.Value <= t.Root.Value {
+		return fmt.Errorf("%w: %d is not above %d", ErrJoinOrder, other.Root.Value, t.Root.Value)
+	}
+	t.Root.Right = other.Root
+	t.Root.update()
+	other.Root = nil
+	return nil
+}
+
+// InorderTraversal returns values in inorder traversal (left, root, right)
+func (t *SplayTree) InorderTraversal() []int {
+	var result []int
+	var walk func(node *SplayNode)
+	walk = func(node *SplayNode) {
+		if node != nil {
+			walk(node.Left)
+			result = append(result, node.Value)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PreorderTraversal returns values in preorder traversal (root, left, right)
+func (t *SplayTree) PreorderTraversal() []int {
+	var result []int
+	var walk func(node *SplayNode)
+	walk = func(node *SplayNode) {
+		if node != nil {
+			result = append(result, node.Value)
+			walk(node.Left)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PostorderTraversal returns values in postorder traversal (left, right, root)
+func (t *SplayTree) PostorderTraversal() []int {
+	var result []int
+	var walk func(node *SplayNode)
+	walk = func(node *SplayNode) {
+		if node != nil {
+			walk(node.Left)
+			walk(node.Right)
+			result = append(result, node.Value)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// Height returns the current height of the tree
+func (t *SplayTree) Height() int {
+	var height func(node *SplayNode) int
+	height = func(node *SplayNode) int {
+		if node == nil {
+			return -1
+		}
+		return max(height(node.Left), height(node.Right)) + 1
+	}
+	return height(t.Root)
+}
+
+// IsEmpty checks if the tree is empty
+func (t *SplayTree) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Size returns the number of nodes in the tree
+func (t *SplayTree) Size() int {
+	return splaySize(t.Root)
+}
+
+// Validate checks the search tree order and the stored subtree sizes. It
+// returns the first violation found, or nil.
+func (t *SplayTree) Validate() error {
+	var check func(node *SplayNode, lo, hi *int) error
+	check = func(node *SplayNode, lo, hi *int) error {
+		if node == nil {
+			return nil
+		}
+		if (lo != nil && node.Value <= *lo) || (hi != nil && node.Value >= *hi) {
+			return fmt.Errorf("node %d is out of order", node.Value)
+		}
+		if err := check(node.Left, lo, &node.Value); err != nil {
+			return err
+		}
+		if err := check(node.Right, &node.Value, hi); err != nil {
+			return err
+		}
+		if want := 1 + splaySize(node.Left) + splaySize(node.Right); node.size != want {
+			return fmt.Errorf("node %d has size %d, want %d", node.Value, node.size, want)
+		}
+		return nil
+	}
+	return check(t.Root, nil, nil)
+}
+
+// String returns a string representation of the tree
+func (t *SplayTree) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("Splay Tree:\n")
+	var printTree func(node *SplayNode, prefix string, isLast bool)
+	printTree = func(node *SplayNode, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(fmt.Sprintf("%d\n", node.Value))
+		children := []*SplayNode{node.Left, node.Right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.Root, "", true)
+	return result.String()
+}
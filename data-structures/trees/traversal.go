@@ -0,0 +1,220 @@
+package trees
+
+import "iter"
+
+// The iterators below use an explicit stack or queue instead of recursion,
+// so degenerate trees cannot overflow the call stack, and they stop as soon
+// as the loop body breaks. Values repeat according to their counts in
+// multiset mode.
+
+// yieldCopies yields node.Value once per copy, reporting whether to continue
+func yieldCopies(node *TreeNode, yield func(int) bool) bool {
+	for i := 0; i < node.copies(); i++ {
+		if !yield(node.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Inorder returns an iterator over the values in ascending order
+func (bt *BinaryTree) Inorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var stack []*TreeNode
+		node := bt.Root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yieldCopies(node, yield) {
+				return
+			}
+			node = node.Right
+		}
+	}
+}
+
+// ReverseInorder returns an iterator over the values in descending order
+func (bt *BinaryTree) ReverseInorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var stack []*TreeNode
+		node := bt.Root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Right
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yieldCopies(node, yield) {
+				return
+			}
+			node = node.Left
+		}
+	}
+}
+
+// Preorder returns an iterator over the values root first (root, left, right)
+func (bt *BinaryTree) Preorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if bt.Root == nil {
+			return
+		}
+		stack := []*TreeNode{bt.Root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yieldCopies(node, yield) {
+				return
+			}
+			// Push right first so the left subtree is visited first
+			if node.Right != nil {
+				stack = append(stack, node.Right)
+			}
+			if node.Left != nil {
+				stack = append(stack, node.Left)
+			}
+		}
+	}
+}
+
+// Postorder returns an iterator over the values root last (left, right, root)
+func (bt *BinaryTree) Postorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var stack []*TreeNode
+		var last *TreeNode // most recently visited node
+		node := bt.Root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			top := stack[len(stack)-1]
+			if top.Right != nil && top.Right != last {
+				// Visit the right subtree before the node itself
+				node = top.Right
+				continue
+			}
+			stack = stack[:len(stack)-1]
+			if !yieldCopies(top, yield) {
+				return
+			}
+			last = top
+		}
+	}
+}
+
+// LevelOrder returns an iterator over the values breadth first, left to
+// right within each level
+func (bt *BinaryTree) LevelOrder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if bt.Root == nil {
+			return
+		}
+		queue := []*TreeNode{bt.Root}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if !yieldCopies(node, yield) {
+				return
+			}
+			if node.Left != nil {
+				queue = append(queue, node.Left)
+			}
+			if node.Right != nil {
+				queue = append(queue, node.Right)
+			}
+		}
+	}
+}
+
+// levels returns the nodes of each level, left to right
+func (bt *BinaryTree) levels() [][]*TreeNode {
+	var result [][]*TreeNode
+	level := []*TreeNode{}
+	if bt.Root != nil {
+		level = append(level, bt.Root)
+	}
+	for len(level) > 0 {
+		result = append(result, level)
+		var next []*TreeNode
+		for _, node := range level {
+			if node.Left != nil {
+				next = append(next, node.Left)
+			}
+			if node.Right != nil {
+				next = append(next, node.Right)
+			}
+		}
+		level = next
+	}
+	return result
+}
+
+// LevelOrderByDepth returns the values grouped by depth, root level first
+func (bt *BinaryTree) LevelOrderByDepth() [][]int {
+	levels := bt.levels()
+	result := make([][]int, len(levels))
+	for depth, level := range levels {
+		for _, node := range level {
+			result[depth] = appendCopies(result[depth], node)
+		}
+	}
+	return result
+}
+
+// ZigzagLevelOrder returns the values grouped by depth, alternating
+// left-to-right and right-to-left, starting left to right at the root
+func (bt *BinaryTree) ZigzagLevelOrder() [][]int {
+	result := bt.LevelOrderByDepth()
+	for depth := 1; depth < len(result); depth += 2 {
+		level := result[depth]
+		for i, j := 0, len(level)-1; i < j; i, j = i+1, j-1 {
+			level[i], level[j] = level[j], level[i]
+		}
+	}
+	return result
+}
+
+// MorrisInorder returns an iterator over the values in ascending order
+// that uses O(1) extra space. It temporarily threads each node's inorder
+// predecessor back to the node, so the tree must not be read or modified
+// by anyone else during iteration. Breaking out early still restores the
+// tree before returning.
+func (bt *BinaryTree) MorrisInorder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		stopped := false
+		visit := func(node *TreeNode) {
+			if !stopped && !yieldCopies(node, yield) {
+				stopped = true
+			}
+		}
+
+		node := bt.Root
+		for node != nil {
+			if node.Left == nil {
+				visit(node)
+				node = node.Right
+				continue
+			}
+
+			predecessor := node.Left
+			for predecessor.Right != nil && predecessor.Right != node {
+				predecessor = predecessor.Right
+			}
+			if predecessor.Right == nil {
+				// Thread the predecessor back to node and descend left
+				predecessor.Right = node
+				node = node.Left
+			} else {
+				// Left subtree is done: remove the thread and visit node
+				predecessor.Right = nil
+				visit(node)
+				node = node.Right
+			}
+		}
+	}
+}
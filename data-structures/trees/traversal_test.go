@@ -0,0 +1,145 @@
+package trees
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// sampleTree builds this tree:
+//
+//	      50
+//	    /    \
+//	  30      70
+//	 /  \    /  \
+//	20  40  60  80
+//	      \
+//	      45
+func sampleTree() *BinaryTree {
+	bt := NewBinaryTree()
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 45} {
+		bt.Insert(v)
+	}
+	return bt
+}
+
+func TestTraversalIterators(t *testing.T) {
+	bt := sampleTree()
+	tests := []struct {
+		name string
+		seq  func() []int
+		want string
+	}{
+		{"Inorder", func() []int { return slices.Collect(bt.Inorder()) }, "[20 30 40 45 50 60 70 80]"},
+		{"ReverseInorder", func() []int { return slices.Collect(bt.ReverseInorder()) }, "[80 70 60 50 45 40 30 20]"},
+		{"Preorder", func() []int { return slices.Collect(bt.Preorder()) }, "[50 30 20 40 45 70 60 80]"},
+		{"Postorder", func() []int { return slices.Collect(bt.Postorder()) }, "[20 45 40 30 60 80 70 50]"},
+		{"LevelOrder", func() []int { return slices.Collect(bt.LevelOrder()) }, "[50 30 70 20 40 60 80 45]"},
+		{"MorrisInorder", func() []int { return slices.Collect(bt.MorrisInorder()) }, "[20 30 40 45 50 60 70 80]"},
+		{"InorderTraversal", bt.InorderTraversal, "[20 30 40 45 50 60 70 80]"},
+		{"PreorderTraversal", bt.PreorderTraversal, "[50 30 20 40 45 70 60 80]"},
+		{"PostorderTraversal", bt.PostorderTraversal, "[20 45 40 30 60 80 70 50]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.seq()); got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if got := fmt.Sprint(bt.LevelOrderByDepth()); got != "[[50] [30 70] [20 40 60 80] [45]]" {
+		t.Errorf("Unexpected levels %s", got)
+	}
+	if got := fmt.Sprint(bt.ZigzagLevelOrder()); got != "[[50] [70 30] [20 40 60 80] [45]]" {
+		t.Errorf("Unexpected zigzag %s", got)
+	}
+	if bt.Height() != 3 || bt.DistinctCount() != 8 {
+		t.Errorf("Expected height 3 and 8 nodes, got %d and %d", bt.Height(), bt.DistinctCount())
+	}
+
+	empty := NewBinaryTree()
+	if empty.InorderTraversal() != nil || len(empty.LevelOrderByDepth()) != 0 || empty.Height() != -1 {
+		t.Error("Expected empty traversals for an empty tree")
+	}
+}
+
+func TestTraversalEarlyStop(t *testing.T) {
+	bt := sampleTree()
+	before := bt.PreorderTraversal()
+
+	seqs := map[string]func(func(int) bool){
+		"Inorder":       bt.Inorder(),
+		"Preorder":      bt.Preorder(),
+		"Postorder":     bt.Postorder(),
+		"LevelOrder":    bt.LevelOrder(),
+		"MorrisInorder": bt.MorrisInorder(),
+	}
+	for name, seq := range seqs {
+		visited := 0
+		for range seq {
+			visited++
+			if visited == 3 {
+				break
+			}
+		}
+		if visited != 3 {
+			t.Errorf("%s visited %d values after break", name, visited)
+		}
+	}
+
+	// Breaking out of a Morris traversal removes every thread it added
+	if after := bt.PreorderTraversal(); fmt.Sprint(after) != fmt.Sprint(before) {
+		t.Errorf("Expected tree to be restored, preorder %v became %v", before, after)
+	}
+}
+
+// degenerateTree builds a right-leaning chain 0 -> 1 -> ... -> n-1 directly,
+// since inserting sorted values one by one is quadratic
+func degenerateTree(n int) *BinaryTree {
+	var root *TreeNode
+	for v := n - 1; v >= 0; v-- {
+		root = &TreeNode{Value: v, Right: root, count: 1, size: n - v}
+	}
+	return &BinaryTree{Root: root}
+}
+
+func TestTraversalsOnDegenerateTree(t *testing.T) {
+	const n = 200000
+	bt := degenerateTree(n)
+
+	if bt.Height() != n-1 {
+		t.Errorf("Expected height %d, got %d", n-1, bt.Height())
+	}
+	for name, seq := range map[string]func(func(int) bool){
+		"Inorder":       bt.Inorder(),
+		"Preorder":      bt.Preorder(),
+		"Postorder":     bt.Postorder(),
+		"MorrisInorder": bt.MorrisInorder(),
+	} {
+		count := 0
+		for range seq {
+			count++
+		}
+		if count != n {
+			t.Errorf("%s visited %d values, want %d", name, count, n)
+		}
+	}
+	if v, _ := bt.Select(n - 1); v != n-1 {
+		t.Errorf("Expected Select(%d) = %d, got %d", n-1, n-1, v)
+	}
+}
+
+func TestTraversalsRepeatMultisetCopies(t *testing.T) {
+	bt := NewBinaryTreeWithMode(MultisetMode)
+	for _, v := range []int{2, 1, 2, 3, 1} {
+		bt.Insert(v)
+	}
+	if got := fmt.Sprint(slices.Collect(bt.ReverseInorder())); got != "[3 2 2 1 1]" {
+		t.Errorf("Expected [3 2 2 1 1], got %s", got)
+	}
+	if got := fmt.Sprint(bt.LevelOrderByDepth()); got != "[[2 2] [1 1 3]]" {
+		t.Errorf("Expected [[2 2] [1 1 3]], got %s", got)
+	}
+	if got := fmt.Sprint(slices.Collect(bt.MorrisInorder())); got != "[1 1 2 2 3]" {
+		t.Errorf("Expected [1 1 2 2 3], got %s", got)
+	}
+}
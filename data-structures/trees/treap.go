@@ -0,0 +1,282 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// ErrJoinOrder is returned by Join when the other tree has a value that is
+// not greater than every value in this one
+var ErrJoinOrder = errors.New("join needs every value of the other tree to be greater")
+
+// TreapNode represents a node in a treap
+type TreapNode struct {
+	Value    int
+	Priority uint64
+	Left     *TreapNode
+	Right    *TreapNode
+	size     int // number of nodes in the subtree rooted here
+}
+
+func treapSize(node *TreapNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func (n *TreapNode) update() {
+	n.size = 1 + treapSize(n.Left) + treapSize(n.Right)
+}
+
+// Treap represents a binary search tree of ints that is also a max-heap on
+// random priorities. The random priorities give it the shape of a BST
+// built from a random insertion order, so its expected height is O(log n)
+// whatever order values arrive in. All updates are built from split and
+// merge. The zero value is an empty treap with randomly seeded priorities.
+type Treap struct {
+	Root *TreapNode
+	rng  *rand.Rand // created on first use when nil
+}
+
+// NewTreap creates a new empty treap with randomly seeded priorities
+func NewTreap() *Treap {
+	return NewTreapWithSeed(rand.Uint64())
+}
+
+// NewTreapWithSeed creates a new empty treap whose priorities come from a
+// fixed seed, so the same operations always build the same shape
+func NewTreapWithSeed(seed uint64) *Treap {
+	return &Treap{rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// random returns the priority source, seeding one for a zero-value treap
+func (t *Treap) random() *rand.Rand {
+	if t.rng == nil {
+		t.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return t.rng
+}
+
+// treapSplit splits node into the values below key and the rest. With
+// orEqual, key itself goes to the left part instead.
+func treapSplit(node *TreapNode, key int, orEqual bool) (*TreapNode, *TreapNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Value < key || (orEqual && node.Value == key) {
+		left, right := treapSplit(node.Right, key, orEqual)
+		node.Right = left
+		node.update()
+		return node, right
+	}
+	left, right := treapSplit(node.Left, key, orEqual)
+	node.Left = right
+	node.update()
+	return left, node
+}
+
+// treapMerge joins two treaps where every value in left is below every
+// value in right
+func treapMerge(left, right *TreapNode) *TreapNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.Priority > right.Priority {
+		left.Right = treapMerge(left.Right, right)
+		left.update()
+		return left
+	}
+	right.Left = treapMerge(left, right.Left)
+	right.update()
+	return right
+}
+
+// Insert inserts a value into the treap; duplicates are ignored
+func (t *Treap) Insert(value int) {
+	if t.Search(value) {
+		return
+	}
+	left, right := treapSplit(t.Root, value, false)
+	node := &TreapNode{Value: value, Priority: t.random().Uint64(), size: 1}
+	t.Root = treapMerge(treapMerge(left, node), right)
+}
+
+// Search searches for a value in the treap
+func (t *Treap) Search(value int) bool {
+	node := t.Root
+	for node != nil {
+		if value == node.Value {
+			return true
+		}
+		if value < node.Value {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Delete removes a value from the treap
+func (t *Treap) Delete(value int) {
+	left, rest := treapSplit(t.Root, value, false)
+	_, right := treapSplit(rest, value, true)
+	t.Root = treapMerge(left, right)
+}
+
+// Split moves every value >= key into a new treap, which it returns, and
+// keeps the values below key. It takes expected O(log n).
+func (t *Treap) Split(key int) *Treap {
+	left, right := treapSplit(t.Root, key, false)
+	t.Root = left
+	return &Treap{Root: right, rng: rand.New(rand.NewPCG(t.random().Uint64(), t.random().Uint64()))}
+}
+
+// Join moves every value of other into t in expected O(log n), leaving
+// other empty. Every value of other must be greater than every value of t.
+func (t *Treap) Join(other *Treap) error {
+	if t.Root != nil && other.Root != nil {
+		highest, lowest := t.Root, other.Root
+		for highest.Right != nil {
+			highest = highest.Right
+		}
+		for lowest.Left != nil {
+			lowest = lowest.Left
+		}
+		if lowest.Value <= highest.Value {
+			return fmt.Errorf("%w: %d is not above %d", ErrJoinOrder, lowest.Value, highest.Value)
+		}
+	}
+	t.Root = treapMerge(t.Root, other.Root)
+	other.Root = nil
+	return nil
+}
+
+// InorderTraversal returns values in inorder traversal (left, root, right)
+func (t *Treap) InorderTraversal() []int {
+	var result []int
+	var walk func(node *TreapNode)
+	walk = func(node *TreapNode) {
+		if node != nil {
+			walk(node.Left)
+			result = append(result, node.Value)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PreorderTraversal returns values in preorder traversal (root, left, right)
+func (t *Treap) PreorderTraversal() []int {
+	var result []int
+	var walk func(node *TreapNode)
+	walk = func(node *TreapNode) {
+		if node != nil {
+			result = append(result, node.Value)
+			walk(node.Left)
+			walk(node.Right)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// PostorderTraversal returns values in postorder traversal (left, right, root)
+func (t *Treap) PostorderTraversal() []int {
+	var result []int
+	var walk func(node *TreapNode)
+	walk = func(node *TreapNode) {
+		if node != nil {
+			walk(node.Left)
+			walk(node.Right)
+			result = append(result, node.Value)
+		}
+	}
+	walk(t.Root)
+	return result
+}
+
+// Height returns the height of the treap
+func (t *Treap) Height() int {
+	var height func(node *TreapNode) int
+	height = func(node *TreapNode) int {
+		if node == nil {
+			return -1
+		}
+		return max(height(node.Left), height(node.Right)) + 1
+	}
+	return height(t.Root)
+}
+
+// IsEmpty checks if the treap is empty
+func (t *Treap) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Size returns the number of nodes in the treap
+func (t *Treap) Size() int {
+	return treapSize(t.Root)
+}
+
+// Validate checks the search tree order, the heap order of the priorities
+// and the stored subtree sizes. It returns the first violation found, or
+// nil.
+func (t *Treap) Validate() error {
+	var check func(node *TreapNode, lo, hi *int) error
+	check = func(node *TreapNode, lo, hi *int) error {
+		if node == nil {
+			return nil
+		}
+		if (lo != nil && node.Value <= *lo) || (hi != nil && node.Value >= *hi) {
+			return fmt.Errorf("node %d is out of order", node.Value)
+		}
+		for _, child := range []*TreapNode{node.Left, node.Right} {
+			if child != nil && child.Priority > node.Priority {
+				return fmt.Errorf("node %d has a lower priority than its child %d", node.Value, child.Value)
+			}
+		}
+		if err := check(node.Left, lo, &node.Value); err != nil {
+			return err
+		}
+		if err := check(node.Right, &node.Value, hi); err != nil {
+			return err
+		}
+		if want := 1 + treapSize(node.Left) + treapSize(node.Right); node.size != want {
+			return fmt.Errorf("node %d has size %d, want %d", node.Value, node.size, want)
+		}
+		return nil
+	}
+	return check(t.Root, nil, nil)
+}
+
+// String returns a string representation of the treap
+func (t *Treap) String() string {
+	if t.Root == nil {
+		return "Empty tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("Treap:\n")
+	var printTree func(node *TreapNode, prefix string, isLast bool)
+	printTree = func(node *TreapNode, prefix string, isLast bool) {
+		childPrefix := treeprint.Branch(&result, prefix, isLast)
+		result.WriteString(fmt.Sprintf("%d\n", node.Value))
+		children := []*TreapNode{node.Left, node.Right}
+		for i, child := range children {
+			if child != nil {
+				printTree(child, childPrefix, i == len(children)-1)
+			}
+		}
+	}
+	printTree(t.Root, "", true)
+	return result.String()
+}
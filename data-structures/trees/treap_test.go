@@ -0,0 +1,216 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// splitJoiner is the split and join API shared by Treap and SplayTree
+type splitJoiner[T any] interface {
+	SearchTree
+	Validate() error
+	Split(key int) T
+	Join(other T) error
+}
+
+func testSplitJoin[T splitJoiner[T]](t *testing.T, tree T) {
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 10} {
+		tree.Insert(v)
+	}
+
+	upper := tree.Split(45)
+	if got := fmt.Sprint(tree.InorderTraversal(), upper.InorderTraversal()); got != "[10 20 30 40] [50 60 70 80]" {
+		t.Errorf("Unexpected split %s", got)
+	}
+	if tree.Size() != 4 || upper.Size() != 4 {
+		t.Errorf("Expected 4 and 4 values, got %d and %d", tree.Size(), upper.Size())
+	}
+	for _, part := range []T{tree, upper} {
+		if err := part.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Splitting at a present key sends it right
+	top := upper.Split(70)
+	if got := fmt.Sprint(upper.InorderTraversal(), top.InorderTraversal()); got != "[50 60] [70 80]" {
+		t.Errorf("Unexpected split %s", got)
+	}
+
+	if err := top.Join(tree); !errors.Is(err, ErrJoinOrder) {
+		t.Errorf("Expected ErrJoinOrder, got %v", err)
+	}
+	if err := upper.Join(top); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Join(upper); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(tree.InorderTraversal()); got != "[10 20 30 40 50 60 70 80]" || !upper.IsEmpty() || !top.IsEmpty() {
+		t.Errorf("Expected every value back in one tree, got %s", got)
+	}
+	if err := tree.Validate(); err != nil || tree.Size() != 8 {
+		t.Errorf("Expected a valid tree of 8 values, got %v", err)
+	}
+
+	// Splitting beyond either end leaves one side empty
+	if rest := tree.Split(100); !rest.IsEmpty() || tree.Size() != 8 {
+		t.Error("Expected nothing above 100")
+	}
+	if rest := tree.Split(0); !tree.IsEmpty() || rest.Size() != 8 {
+		t.Error("Expected everything above 0")
+	}
+}
+
+func TestTreapSplitJoin(t *testing.T) {
+	testSplitJoin(t, NewTreap())
+}
+
+func TestSplayTreeSplitJoin(t *testing.T) {
+	testSplitJoin(t, NewSplayTree())
+}
+
+func TestTreapDeterministicSeed(t *testing.T) {
+	a, b := NewTreapWithSeed(7), NewTreapWithSeed(7)
+	for v := 0; v < 100; v++ {
+		a.Insert(v)
+		b.Insert(v)
+	}
+	if a.String() != b.String() {
+		t.Error("Expected the same seed to build the same shape")
+	}
+	if a.Root.Priority < a.Root.Left.Priority || a.Root.Priority < a.Root.Right.Priority {
+		t.Error("Expected the root to have the highest priority")
+	}
+}
+
+func TestTreapZeroValue(t *testing.T) {
+	var treap Treap
+	for _, v := range []int{5, 1, 9, 3} {
+		treap.Insert(v)
+	}
+	upper := treap.Split(4)
+	if got := fmt.Sprint(treap.InorderTraversal(), upper.InorderTraversal()); got != "[1 3] [5 9]" {
+		t.Errorf("Expected [1 3] [5 9], got %s", got)
+	}
+	if err := treap.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	upper.Insert(7)
+
+	var seq ImplicitTreap[string]
+	seq.Append("b")
+	if err := seq.Insert(0, "a"); err != nil || fmt.Sprint(seq.Values()) != "[a b]" {
+		t.Errorf("Expected [a b], got %v, %v", seq.Values(), err)
+	}
+}
+
+func TestSplayTreeMovesAccessedToRoot(t *testing.T) {
+	tree := NewSplayTree()
+	for v := 1; v <= 7; v++ {
+		tree.Insert(v)
+		if tree.Root.Value != v {
+			t.Fatalf("Expected %d at the root after inserting it", v)
+		}
+	}
+	// Sorted inserts leave a left path, which one search roughly halves
+	if tree.Height() != 6 {
+		t.Errorf("Expected a path of height 6, got %d", tree.Height())
+	}
+	tree.Search(1)
+	if tree.Root.Value != 1 || tree.Height() >= 6 {
+		t.Errorf("Expected 1 at the root of a shallower tree:\n%s", tree)
+	}
+
+	// A missed search splays the last node visited
+	tree.Search(100)
+	if tree.Root.Value != 7 {
+		t.Errorf("Expected 7 at the root, got %d", tree.Root.Value)
+	}
+	if !strings.HasPrefix(tree.String(), "Splay Tree:\n└── 7\n") {
+		t.Errorf("Unexpected drawing:\n%s", tree)
+	}
+}
+
+func TestImplicitTreap(t *testing.T) {
+	seq := NewImplicitTreap('a', 'b', 'c', 'd', 'e')
+	seq.Insert(2, 'x')
+	seq.Reverse(1, 5)
+	if got := string(seq.Values()); got != "adcxbe" {
+		t.Errorf("Expected adcxbe, got %s", got)
+	}
+	if r, err := seq.Delete(0); err != nil || r != 'a' {
+		t.Errorf("Expected (a, nil), got (%c, %v)", r, err)
+	}
+	seq.Set(0, 'D')
+	if r, _ := seq.Get(0); r != 'D' || seq.Len() != 5 {
+		t.Errorf("Expected D at 0 of 5, got %c of %d", r, seq.Len())
+	}
+
+	tail, _ := seq.Split(3)
+	tail.Reverse(0, tail.Len())
+	tail.Join(seq)
+	if got := string(tail.Values()); got != "ebDcx" || !seq.IsEmpty() {
+		t.Errorf("Expected ebDcx, got %s", got)
+	}
+	tail.Join(tail)
+	if got := string(tail.Values()); got != "ebDcx" {
+		t.Errorf("Expected a self-join to keep ebDcx, got %s", got)
+	}
+
+	for _, err := range []error{
+		seq.Insert(1, 'z'),
+		seq.Set(0, 'z'),
+		tail.Reverse(3, 2),
+	} {
+		if !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+		}
+	}
+	if _, err := tail.Get(5); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Expected ErrIndexOutOfRange, got %v", err)
+	}
+	if NewImplicitTreap[int]().String() != "ImplicitTreap: []" {
+		t.Error("Unexpected empty sequence")
+	}
+}
+
+func TestImplicitTreapAgainstSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	seq := NewImplicitTreap[int]()
+	var reference []int
+
+	for i := 0; i < 5000; i++ {
+		n := len(reference)
+		switch op := rng.Intn(6); {
+		case op < 2 || n == 0:
+			index := rng.Intn(n + 1)
+			seq.Insert(index, i)
+			reference = slices.Insert(reference, index, i)
+		case op == 2:
+			index := rng.Intn(n)
+			got, _ := seq.Delete(index)
+			if got != reference[index] {
+				t.Fatalf("Delete(%d) = %d, want %d", index, got, reference[index])
+			}
+			reference = slices.Delete(reference, index, index+1)
+		case op == 3:
+			lo := rng.Intn(n + 1)
+			hi := lo + rng.Intn(n-lo+1)
+			seq.Reverse(lo, hi)
+			slices.Reverse(reference[lo:hi])
+		default:
+			index := rng.Intn(n)
+			if got, _ := seq.Get(index); got != reference[index] {
+				t.Fatalf("Get(%d) = %d, want %d", index, got, reference[index])
+			}
+		}
+	}
+	if !slices.Equal(seq.Values(), reference) || seq.Len() != len(reference) {
+		t.Fatalf("Expected %d elements matching the slice", len(reference))
+	}
+}
@@ -0,0 +1,93 @@
+package tries
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is returned when inserting a key that is not valid UTF-8.
+// Both trees walk keys rune by rune, where every invalid byte would read
+// as U+FFFD and distinct keys would collide.
+var ErrInvalidUTF8 = errors.New("key is not valid UTF-8")
+
+// Wildcards understood by Match
+const (
+	// AnyRune matches exactly one rune
+	AnyRune = '?'
+	// AnyString matches any run of runes, including none
+	AnyString = '*'
+)
+
+// PrefixTree is the API shared by Trie and RadixTree
+type PrefixTree interface {
+	// Insert adds key with a weight used to rank autocomplete results, or
+	// updates the weight if key is present. Keys must be valid UTF-8;
+	// lookups of invalid UTF-8 find nothing.
+	Insert(key string, weight int) error
+	// Delete removes key and prunes nodes that no longer lead to a key
+	Delete(key string) bool
+	// Contains checks if key was inserted
+	Contains(key string) bool
+	// Weight returns the weight of key
+	Weight(key string) (int, bool)
+	// KeysWithPrefix returns the keys that start with prefix, sorted
+	KeysWithPrefix(prefix string) []string
+	// LongestPrefixOf returns the longest key that is a prefix of query
+	LongestPrefixOf(query string) (string, bool)
+	// TopK returns the k heaviest keys that start with prefix
+	TopK(prefix string, k int) []Suggestion
+	// Match returns the keys matching a pattern with ? and * wildcards, sorted
+	Match(pattern string) []string
+	// Keys returns all keys, sorted
+	Keys() []string
+	// Size returns the number of keys
+	Size() int
+	// NodeCount returns the number of nodes below the root
+	NodeCount() int
+	// IsEmpty checks if the tree holds no keys
+	IsEmpty() bool
+	// Clear removes all keys
+	Clear()
+	// String returns a drawing of the tree
+	String() string
+}
+
+var (
+	_ PrefixTree = (*Trie)(nil)
+	_ PrefixTree = (*RadixTree)(nil)
+)
+
+// Suggestion is an autocomplete result
+type Suggestion struct {
+	Key    string
+	Weight int
+}
+
+// topK sorts suggestions by descending weight, breaking ties by key, and
+// keeps the first k
+func topK(suggestions []Suggestion, k int) []Suggestion {
+	slices.SortFunc(suggestions, func(a, b Suggestion) int {
+		if a.Weight != b.Weight {
+			return cmp.Compare(b.Weight, a.Weight)
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return suggestions[:min(max(k, 0), len(suggestions))]
+}
+
+// firstRune splits the first rune off s
+func firstRune(s string) (rune, string) {
+	r, size := utf8.DecodeRuneInString(s)
+	return r, s[size:]
+}
+
+// trimStars collapses runs of * so matching does not revisit states
+func trimStars(pattern string) string {
+	for strings.Contains(pattern, "**") {
+		pattern = strings.ReplaceAll(pattern, "**", "*")
+	}
+	return pattern
+}
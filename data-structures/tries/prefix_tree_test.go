@@ -0,0 +1,287 @@
+package tries
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var prefixTreeVariants = []struct {
+	name string
+	new  func() PrefixTree
+}{
+	{"Trie", func() PrefixTree { return NewTrie() }},
+	{"RadixTree", func() PrefixTree { return NewRadixTree() }},
+}
+
+func TestPrefixTree(t *testing.T) {
+	words := map[string]int{
+		"tea": 5, "team": 9, "teammate": 2, "ten": 7, "to": 3, "tomato": 1, "inn": 4, "café": 6,
+	}
+	for _, variant := range prefixTreeVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			tree := variant.new()
+			if !tree.IsEmpty() || len(tree.Keys()) != 0 {
+				t.Error("Expected tree to be empty")
+			}
+			for word, weight := range words {
+				tree.Insert(word, weight)
+			}
+			tree.Insert("tea", 8)
+			if tree.Size() != len(words) {
+				t.Errorf("Expected %d keys, got %d", len(words), tree.Size())
+			}
+			if !tree.Contains("team") || tree.Contains("te") || tree.Contains("teams") {
+				t.Error("Unexpected membership")
+			}
+			if w, ok := tree.Weight("tea"); !ok || w != 8 {
+				t.Errorf("Expected weight 8, got %d, %v", w, ok)
+			}
+
+			if got := fmt.Sprint(tree.KeysWithPrefix("te")); got != "[tea team teammate ten]" {
+				t.Errorf("Unexpected keys with prefix te: %s", got)
+			}
+			if got := fmt.Sprint(tree.KeysWithPrefix("tom")); got != "[tomato]" {
+				t.Errorf("Unexpected keys with prefix tom: %s", got)
+			}
+			if got := tree.KeysWithPrefix("x"); len(got) != 0 {
+				t.Errorf("Expected no keys, got %v", got)
+			}
+			if got := fmt.Sprint(tree.Keys()); got != "[café inn tea team teammate ten to tomato]" {
+				t.Errorf("Unexpected keys %s", got)
+			}
+
+			longest := []struct{ query, want string }{
+				{"teammates", "teammate"},
+				{"teamwork", "team"},
+				{"teapot", "tea"},
+				{"cafés", "café"},
+			}
+			for _, tt := range longest {
+				if got, ok := tree.LongestPrefixOf(tt.query); !ok || got != tt.want {
+					t.Errorf("LongestPrefixOf(%q) = %q, want %q", tt.query, got, tt.want)
+				}
+			}
+			if _, ok := tree.LongestPrefixOf("te"); ok {
+				t.Error("Expected no key to be a prefix of te")
+			}
+
+			if got := fmt.Sprint(tree.TopK("te", 3)); got != "[{team 9} {tea 8} {ten 7}]" {
+				t.Errorf("Unexpected top 3 %s", got)
+			}
+			if got := tree.TopK("t", 100); len(got) != 6 {
+				t.Errorf("Expected all 6 keys under t, got %v", got)
+			}
+			if got := tree.TopK("te", 0); len(got) != 0 {
+				t.Errorf("Expected no suggestions, got %v", got)
+			}
+
+			patterns := []struct{ pattern, want string }{
+				{"te?", "[tea ten]"},
+				{"t*o", "[to tomato]"},
+				{"*a*", "[café tea team teammate tomato]"},
+				{"caf?", "[café]"},
+				{"t**m", "[team]"},
+				{"*", "[café inn tea team teammate ten to tomato]"},
+				{"?", "[]"},
+				{"team", "[team]"},
+			}
+			for _, tt := range patterns {
+				if got := fmt.Sprint(tree.Match(tt.pattern)); got != tt.want {
+					t.Errorf("Match(%q) = %s, want %s", tt.pattern, got, tt.want)
+				}
+			}
+
+			if tree.Delete("te") || tree.Delete("teams") {
+				t.Error("Expected deleting missing keys to fail")
+			}
+			for word := range words {
+				if !tree.Delete(word) {
+					t.Fatalf("Expected to delete %q", word)
+				}
+			}
+			if !tree.IsEmpty() || tree.NodeCount() != 0 {
+				t.Errorf("Expected no nodes after deleting every key, got %d", tree.NodeCount())
+			}
+		})
+	}
+}
+
+func TestRadixTreeCompression(t *testing.T) {
+	tree := NewRadixTree()
+	for i, word := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		tree.Insert(word, i)
+	}
+	want := "RadixTree:\n" +
+		"└── r\n" +
+		"    ├── om\n" +
+		"    │   ├── an\n" +
+		"    │   │   ├── e (0)\n" +
+		"    │   │   └── us (1)\n" +
+		"    │   └── ulus (2)\n" +
+		"    └── ub\n" +
+		"        ├── e\n" +
+		"        │   ├── ns (3)\n" +
+		"        │   └── r (4)\n" +
+		"        └── ic\n" +
+		"            ├── on (5)\n" +
+		"            └── undus (6)\n"
+	if tree.String() != want {
+		t.Errorf("Unexpected tree:\n%s", tree)
+	}
+	if tree.NodeCount() != 13 {
+		t.Errorf("Expected 13 nodes, got %d", tree.NodeCount())
+	}
+
+	// Deleting romulus leaves om with one child, which merges into it
+	tree.Delete("romulus")
+	if !strings.Contains(tree.String(), "├── oman\n") || tree.NodeCount() != 11 {
+		t.Errorf("Expected om and an to merge:\n%s", tree)
+	}
+
+	// Splitting inside a multi-byte rune would corrupt the labels
+	tree = NewRadixTree()
+	tree.Insert("é", 1)
+	tree.Insert("ê", 2)
+	if got := fmt.Sprint(tree.Keys()); got != "[é ê]" || tree.NodeCount() != 2 {
+		t.Errorf("Expected two separate edges, got %s", got)
+	}
+}
+
+func TestPrefixTreeInvalidUTF8(t *testing.T) {
+	for _, variant := range prefixTreeVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			tree := variant.new()
+			// Both bytes would decode to U+FFFD and collide
+			for _, key := range []string{"\xff", "\xfe", "ab\xff"} {
+				if err := tree.Insert(key, 1); !errors.Is(err, ErrInvalidUTF8) {
+					t.Errorf("Insert(%q): expected ErrInvalidUTF8, got %v", key, err)
+				}
+			}
+			if !tree.IsEmpty() || tree.NodeCount() != 0 {
+				t.Errorf("Expected rejected keys to leave the tree empty, got %v", tree.Keys())
+			}
+
+			// U+FFFD itself is a valid key, distinct from invalid bytes
+			for _, key := range []string{"\uFFFD", "ab"} {
+				if err := tree.Insert(key, 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tree.Contains("\xff") || tree.Delete("\xff") || tree.KeysWithPrefix("\xff") != nil || tree.Match("\xff") != nil {
+				t.Error("Expected invalid UTF-8 to find nothing")
+			}
+			if key, found := tree.LongestPrefixOf("\xff"); found || key != "" {
+				t.Errorf("Expected no prefix of \\xff, got %q", key)
+			}
+			if key, found := tree.LongestPrefixOf("ab\xffc"); !found || key != "ab" {
+				t.Errorf("Expected ab, got %q", key)
+			}
+			if !tree.Contains("\uFFFD") || tree.Size() != 2 {
+				t.Errorf("Expected U+FFFD and ab, got %q", tree.Keys())
+			}
+		})
+	}
+}
+
+func TestTrieString(t *testing.T) {
+	trie := NewTrie()
+	if trie.String() != "Empty trie" {
+		t.Errorf("Unexpected empty trie %q", trie)
+	}
+	trie.Insert("to", 1)
+	trie.Insert("tea", 2)
+	trie.Insert("a", 3)
+	want := "Trie:\n" +
+		"├── a (3)\n" +
+		"└── t\n" +
+		"    ├── e\n" +
+		"    │   └── a (2)\n" +
+		"    └── o (1)\n"
+	if trie.String() != want {
+		t.Errorf("Unexpected trie:\n%s", trie)
+	}
+}
+
+func TestPrefixTreeAgainstBruteForce(t *testing.T) {
+	for _, variant := range prefixTreeVariants {
+		t.Run(variant.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			randomWord := func() string {
+				var word strings.Builder
+				for n := rng.Intn(6); n >= 0; n-- {
+					word.WriteByte("abc"[rng.Intn(3)])
+				}
+				return word.String()
+			}
+
+			tree := variant.new()
+			reference := make(map[string]int)
+			for i := 0; i < 3000; i++ {
+				word := randomWord()
+				if rng.Intn(3) == 0 {
+					_, want := reference[word]
+					if got := tree.Delete(word); got != want {
+						t.Fatalf("Delete(%q) = %v, want %v", word, got, want)
+					}
+					delete(reference, word)
+				} else {
+					tree.Insert(word, i)
+					reference[word] = i
+				}
+			}
+
+			keys := make([]string, 0, len(reference))
+			for key := range reference {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			if !slices.Equal(tree.Keys(), keys) || tree.Size() != len(keys) {
+				t.Fatalf("Expected %d keys, got %d", len(keys), tree.Size())
+			}
+
+			// Deletes must leave the same shape as building from scratch
+			fresh := variant.new()
+			for _, key := range keys {
+				fresh.Insert(key, reference[key])
+			}
+			if tree.NodeCount() != fresh.NodeCount() || tree.String() != fresh.String() {
+				t.Fatalf("Expected %d nodes like a fresh tree, got %d", fresh.NodeCount(), tree.NodeCount())
+			}
+
+			for i := 0; i < 200; i++ {
+				word := randomWord()
+				prefix := word[:min(len(word), rng.Intn(3))]
+				var want []string
+				for _, key := range keys {
+					if strings.HasPrefix(key, prefix) {
+						want = append(want, key)
+					}
+				}
+				if got := tree.KeysWithPrefix(prefix); !slices.Equal(got, want) {
+					t.Fatalf("KeysWithPrefix(%q) = %v, want %v", prefix, got, want)
+				}
+
+				pattern := []byte(randomWord())
+				for j := range pattern {
+					if rng.Intn(3) == 0 {
+						pattern[j] = "?*"[rng.Intn(2)]
+					}
+				}
+				want = want[:0]
+				for _, key := range keys {
+					if ok, _ := path.Match(string(pattern), key); ok {
+						want = append(want, key)
+					}
+				}
+				if got := tree.Match(string(pattern)); !slices.Equal(got, want) {
+					t.Fatalf("Match(%q) = %v, want %v", pattern, got, want)
+				}
+			}
+		})
+	}
+}
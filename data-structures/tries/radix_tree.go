@@ -0,0 +1,359 @@
+package tries
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// radixNode is a node in a RadixTree. The label is the text on the edge
+// from its parent, and children are kept sorted by label.
+type radixNode struct {
+	label    string
+	children []*radixNode
+	terminal bool // a key ends here
+	weight   int
+}
+
+// child returns the index of the child whose label starts with r, and
+// whether there is one
+func (n *radixNode) child(r rune) (int, bool) {
+	return slices.BinarySearchFunc(n.children, r, func(c *radixNode, r rune) int {
+		first, _ := firstRune(c.label)
+		return int(first - r)
+	})
+}
+
+// RadixTree represents a compressed prefix tree. Chains of nodes with a
+// single child and no key are merged into one edge, so it needs far fewer
+// nodes than a Trie when keys share long prefixes or have long tails.
+type RadixTree struct {
+	root  *radixNode
+	size  int
+	nodes int
+}
+
+// NewRadixTree creates a new empty radix tree
+func NewRadixTree() *RadixTree {
+	return &RadixTree{root: &radixNode{}}
+}
+
+// commonPrefix returns the length in bytes of the longest common prefix of
+// a and b that ends on a rune boundary
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) {
+		_, size := utf8.DecodeRuneInString(a[i:])
+		if !strings.HasPrefix(b[i:], a[i:i+size]) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// Insert adds key with a weight, or updates the weight if key is present
+func (t *RadixTree) Insert(key string, weight int) error {
+	if !utf8.ValidString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidUTF8, key)
+	}
+	node, rest := t.root, key
+	for rest != "" {
+		r, _ := firstRune(rest)
+		i, found := node.child(r)
+		if !found {
+			leaf := &radixNode{label: rest, terminal: true, weight: weight}
+			node.children = slices.Insert(node.children, i, leaf)
+			t.nodes++
+			t.size++
+			return nil
+		}
+
+		child := node.children[i]
+		p := commonPrefix(child.label, rest)
+		if p < len(child.label) {
+			// Split the edge where key leaves it
+			mid := &radixNode{label: child.label[:p], children: []*radixNode{child}}
+			child.label = child.label[p:]
+			node.children[i] = mid
+			t.nodes++
+			child = mid
+		}
+		node, rest = child, rest[p:]
+	}
+
+	if !node.terminal {
+		node.terminal = true
+		t.size++
+	}
+	node.weight = weight
+	return nil
+}
+
+// find follows key and returns the node it reaches, along with the part
+// of that node's label beyond key when key ends inside an edge
+func (t *RadixTree) find(key string) (*radixNode, string) {
+	node, rest := t.root, key
+	for rest != "" {
+		r, _ := firstRune(rest)
+		i, found := node.child(r)
+		if !found {
+			return nil, ""
+		}
+		child := node.children[i]
+		switch {
+		case strings.HasPrefix(rest, child.label):
+			node, rest = child, rest[len(child.label):]
+		case strings.HasPrefix(child.label, rest):
+			return child, child.label[len(rest):]
+		default:
+			return nil, ""
+		}
+	}
+	return node, ""
+}
+
+// Contains checks if key was inserted
+func (t *RadixTree) Contains(key string) bool {
+	node, tail := t.find(key)
+	return node != nil && tail == "" && node.terminal
+}
+
+// Weight returns the weight of key
+func (t *RadixTree) Weight(key string) (int, bool) {
+	if node, tail := t.find(key); node != nil && tail == "" && node.terminal {
+		return node.weight, true
+	}
+	return 0, false
+}
+
+// Delete removes key. A node left without a key or children is removed,
+// and a node left with a single child and no key is merged into it, so the
+// tree stays as compact as if key had never been inserted.
+func (t *RadixTree) Delete(key string) bool {
+	path := []*radixNode{t.root}
+	indexes := []int{}
+	node, rest := t.root, key
+	for rest != "" {
+		r, _ := firstRune(rest)
+		i, found := node.child(r)
+		if !found || !strings.HasPrefix(rest, node.children[i].label) {
+			return false
+		}
+		node, rest = node.children[i], rest[len(node.children[i].label):]
+		path = append(path, node)
+		indexes = append(indexes, i)
+	}
+	if !node.terminal {
+		return false
+	}
+	node.terminal, node.weight = false, 0
+	t.size--
+
+	if node == t.root {
+		return true
+	}
+	parent := path[len(path)-2]
+	i := indexes[len(indexes)-1]
+	switch len(node.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, i, i+1)
+		t.nodes--
+		// The parent may now be a keyless node with one child
+		if len(path) > 2 {
+			t.compact(path[len(path)-3], indexes[len(indexes)-2])
+		}
+	case 1:
+		t.compact(parent, i)
+	}
+	return true
+}
+
+// compact merges child i of parent with its only child if it holds no key
+func (t *RadixTree) compact(parent *radixNode, i int) {
+	node := parent.children[i]
+	if node.terminal || len(node.children) != 1 {
+		return
+	}
+	only := node.children[0]
+	only.label = node.label + only.label
+	parent.children[i] = only
+	t.nodes--
+}
+
+// collect calls visit for every key below node, in sorted order
+func (t *RadixTree) collect(node *radixNode, prefix string, visit func(key string, weight int)) {
+	if node.terminal {
+		visit(prefix, node.weight)
+	}
+	for _, child := range node.children {
+		t.collect(child, prefix+child.label, visit)
+	}
+}
+
+// KeysWithPrefix returns the keys that start with prefix, sorted
+func (t *RadixTree) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	if node, tail := t.find(prefix); node != nil {
+		t.collect(node, prefix+tail, func(key string, _ int) {
+			keys = append(keys, key)
+		})
+	}
+	return keys
+}
+
+// LongestPrefixOf returns the longest key that is a prefix of query
+func (t *RadixTree) LongestPrefixOf(query string) (string, bool) {
+	node, rest := t.root, query
+	length, found := 0, node.terminal
+	for rest != "" {
+		r, _ := firstRune(rest)
+		i, ok := node.child(r)
+		if !ok || !strings.HasPrefix(rest, node.children[i].label) {
+			break
+		}
+		node, rest = node.children[i], rest[len(node.children[i].label):]
+		if node.terminal {
+			length, found = len(query)-len(rest), true
+		}
+	}
+	return query[:length], found
+}
+
+// TopK returns the k heaviest keys that start with prefix, heaviest first
+// and alphabetically among equal weights
+func (t *RadixTree) TopK(prefix string, k int) []Suggestion {
+	var suggestions []Suggestion
+	if node, tail := t.find(prefix); node != nil {
+		t.collect(node, prefix+tail, func(key string, weight int) {
+			suggestions = append(suggestions, Suggestion{key, weight})
+		})
+	}
+	return topK(suggestions, k)
+}
+
+// Match returns the keys matching pattern, sorted. In the pattern ?
+// matches any single rune and * matches any run of runes.
+func (t *RadixTree) Match(pattern string) []string {
+	if !utf8.ValidString(pattern) {
+		return nil
+	}
+	// A position is a node and the part of its edge label not yet
+	// consumed; an empty remainder means the node itself was reached
+	type state struct {
+		node      *radixNode
+		remainder string
+		pattern   string
+	}
+	visited := make(map[state]bool)
+	var keys []string
+
+	var match func(node *radixNode, remainder, pattern, path string)
+	match = func(node *radixNode, remainder, pattern, path string) {
+		if visited[state{node, remainder, pattern}] {
+			return
+		}
+		visited[state{node, remainder, pattern}] = true
+
+		if pattern == "" {
+			if remainder == "" && node.terminal {
+				keys = append(keys, path)
+			}
+			return
+		}
+
+		// step calls next with each rune that can come after the position
+		step := func(next func(r rune, node *radixNode, remainder, path string)) {
+			if remainder != "" {
+				r, rest := firstRune(remainder)
+				next(r, node, rest, path+string(r))
+				return
+			}
+			for _, child := range node.children {
+				r, rest := firstRune(child.label)
+				next(r, child, rest, path+string(r))
+			}
+		}
+
+		r, rest := firstRune(pattern)
+		switch r {
+		case AnyString:
+			match(node, remainder, rest, path)
+			step(func(_ rune, node *radixNode, remainder, path string) {
+				match(node, remainder, pattern, path)
+			})
+		case AnyRune:
+			step(func(_ rune, node *radixNode, remainder, path string) {
+				match(node, remainder, rest, path)
+			})
+		default:
+			step(func(next rune, node *radixNode, remainder, path string) {
+				if next == r {
+					match(node, remainder, rest, path)
+				}
+			})
+		}
+	}
+
+	match(t.root, "", trimStars(pattern), "")
+	slices.Sort(keys)
+	return keys
+}
+
+// Keys returns all keys, sorted
+func (t *RadixTree) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+// Size returns the number of keys
+func (t *RadixTree) Size() int {
+	return t.size
+}
+
+// NodeCount returns the number of nodes below the root, at most two per
+// key
+func (t *RadixTree) NodeCount() int {
+	return t.nodes
+}
+
+// IsEmpty checks if the tree holds no keys
+func (t *RadixTree) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all keys
+func (t *RadixTree) Clear() {
+	t.root = &radixNode{}
+	t.size, t.nodes = 0, 0
+}
+
+// String returns a drawing of the tree with one edge label per line. Nodes
+// that end a key show its weight.
+func (t *RadixTree) String() string {
+	if t.size == 0 {
+		return "Empty radix tree"
+	}
+
+	var result strings.Builder
+	result.WriteString("RadixTree:\n")
+	for i, child := range t.root.children {
+		t.printTree(child, "", i == len(t.root.children)-1, &result)
+	}
+	return result.String()
+}
+
+func (t *RadixTree) printTree(node *radixNode, prefix string, isLast bool, result *strings.Builder) {
+	childPrefix := treeprint.Branch(result, prefix, isLast)
+	result.WriteString(node.label)
+	if node.terminal {
+		result.WriteString(fmt.Sprintf(" (%d)", node.weight))
+	}
+	result.WriteString("\n")
+
+	for i, child := range node.children {
+		t.printTree(child, childPrefix, i == len(node.children)-1, result)
+	}
+}
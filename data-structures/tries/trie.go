@@ -0,0 +1,285 @@
+package tries
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go-programming/data-structures/internal/treeprint"
+)
+
+// trieNode is a node in a Trie; each edge is labelled with one rune
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal bool // a key ends here
+	weight   int
+}
+
+// sortedRunes returns the labels of the node's edges in order
+func (n *trieNode) sortedRunes() []rune {
+	runes := make([]rune, 0, len(n.children))
+	for r := range n.children {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	return runes
+}
+
+// Trie represents a prefix tree of strings with one node per rune
+type Trie struct {
+	root  *trieNode
+	size  int
+	nodes int
+}
+
+// NewTrie creates a new empty trie
+func NewTrie() *Trie {
+	return &Trie{root: &trieNode{}}
+}
+
+// Insert adds key with a weight, or updates the weight if key is present
+func (t *Trie) Insert(key string, weight int) error {
+	if !utf8.ValidString(key) {
+		return fmt.Errorf("%w: %q", ErrInvalidUTF8, key)
+	}
+	node := t.root
+	for _, r := range key {
+		child, found := node.children[r]
+		if !found {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[r] = child
+			t.nodes++
+		}
+		node = child
+	}
+	if !node.terminal {
+		node.terminal = true
+		t.size++
+	}
+	node.weight = weight
+	return nil
+}
+
+// find returns the node reached by following key, or nil
+func (t *Trie) find(key string) *trieNode {
+	if !utf8.ValidString(key) {
+		return nil
+	}
+	node := t.root
+	for _, r := range key {
+		if node = node.children[r]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Contains checks if key was inserted
+func (t *Trie) Contains(key string) bool {
+	node := t.find(key)
+	return node != nil && node.terminal
+}
+
+// Weight returns the weight of key
+func (t *Trie) Weight(key string) (int, bool) {
+	if node := t.find(key); node != nil && node.terminal {
+		return node.weight, true
+	}
+	return 0, false
+}
+
+// Delete removes key and prunes the nodes that led only to it
+func (t *Trie) Delete(key string) bool {
+	if !utf8.ValidString(key) {
+		return false
+	}
+	// Remember the path so empty nodes can be removed bottom-up
+	path := []*trieNode{t.root}
+	runes := []rune(key)
+	for _, r := range runes {
+		next := path[len(path)-1].children[r]
+		if next == nil {
+			return false
+		}
+		path = append(path, next)
+	}
+
+	node := path[len(path)-1]
+	if !node.terminal {
+		return false
+	}
+	node.terminal, node.weight = false, 0
+	t.size--
+
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i].terminal || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
+		t.nodes--
+	}
+	return true
+}
+
+// collect calls visit for every key below node, in sorted order
+func (t *Trie) collect(node *trieNode, prefix []rune, visit func(key string, weight int)) {
+	if node.terminal {
+		visit(string(prefix), node.weight)
+	}
+	for _, r := range node.sortedRunes() {
+		t.collect(node.children[r], append(prefix, r), visit)
+	}
+}
+
+// KeysWithPrefix returns the keys that start with prefix, sorted
+func (t *Trie) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	if node := t.find(prefix); node != nil {
+		t.collect(node, []rune(prefix), func(key string, _ int) {
+			keys = append(keys, key)
+		})
+	}
+	return keys
+}
+
+// LongestPrefixOf returns the longest key that is a prefix of query
+func (t *Trie) LongestPrefixOf(query string) (string, bool) {
+	node := t.root
+	length, found := 0, node.terminal
+	for i := 0; i < len(query); {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if r == utf8.RuneError && size == 1 {
+			break // no key holds invalid UTF-8
+		}
+		if node = node.children[r]; node == nil {
+			break
+		}
+		i += size
+		if node.terminal {
+			length, found = i, true
+		}
+	}
+	return query[:length], found
+}
+
+// TopK returns the k heaviest keys that start with prefix, heaviest first
+// and alphabetically among equal weights
+func (t *Trie) TopK(prefix string, k int) []Suggestion {
+	var suggestions []Suggestion
+	if node := t.find(prefix); node != nil {
+		t.collect(node, []rune(prefix), func(key string, weight int) {
+			suggestions = append(suggestions, Suggestion{key, weight})
+		})
+	}
+	return topK(suggestions, k)
+}
+
+// Match returns the keys matching pattern, sorted. In the pattern ?
+// matches any single rune and * matches any run of runes.
+func (t *Trie) Match(pattern string) []string {
+	if !utf8.ValidString(pattern) {
+		return nil
+	}
+	type state struct {
+		node    *trieNode
+		pattern string
+	}
+	visited := make(map[state]bool)
+	var keys []string
+
+	var match func(node *trieNode, pattern string, path []rune)
+	match = func(node *trieNode, pattern string, path []rune) {
+		if visited[state{node, pattern}] {
+			return
+		}
+		visited[state{node, pattern}] = true
+
+		if pattern == "" {
+			if node.terminal {
+				keys = append(keys, string(path))
+			}
+			return
+		}
+		r, rest := firstRune(pattern)
+		switch r {
+		case AnyString:
+			match(node, rest, path)
+			for _, next := range node.sortedRunes() {
+				match(node.children[next], pattern, append(path, next))
+			}
+		case AnyRune:
+			for _, next := range node.sortedRunes() {
+				match(node.children[next], rest, append(path, next))
+			}
+		default:
+			if child := node.children[r]; child != nil {
+				match(child, rest, append(path, r))
+			}
+		}
+	}
+
+	match(t.root, trimStars(pattern), nil)
+	slices.Sort(keys)
+	return keys
+}
+
+// Keys returns all keys, sorted
+func (t *Trie) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+// Size returns the number of keys
+func (t *Trie) Size() int {
+	return t.size
+}
+
+// NodeCount returns the number of nodes below the root, one per rune of
+// every distinct prefix
+func (t *Trie) NodeCount() int {
+	return t.nodes
+}
+
+// IsEmpty checks if the trie holds no keys
+func (t *Trie) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear removes all keys
+func (t *Trie) Clear() {
+	t.root = &trieNode{}
+	t.size, t.nodes = 0, 0
+}
+
+// String returns a drawing of the trie. Nodes that end a key show its
+// weight.
+func (t *Trie) String() string {
+	if t.size == 0 {
+		return "Empty trie"
+	}
+
+	var result strings.Builder
+	result.WriteString("Trie:\n")
+	runes := t.root.sortedRunes()
+	for i, r := range runes {
+		t.printTree(r, t.root.children[r], "", i == len(runes)-1, &result)
+	}
+	return result.String()
+}
+
+func (t *Trie) printTree(r rune, node *trieNode, prefix string, isLast bool, result *strings.Builder) {
+	childPrefix := treeprint.Branch(result, prefix, isLast)
+	result.WriteRune(r)
+	if node.terminal {
+		result.WriteString(fmt.Sprintf(" (%d)", node.weight))
+	}
+	result.WriteString("\n")
+
+	runes := node.sortedRunes()
+	for i, next := range runes {
+		t.printTree(next, node.children[next], childPrefix, i == len(runes)-1, result)
+	}
+}
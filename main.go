@@ -59,11 +59,18 @@ func printProjectStructure() {
 	structure := `
 data-structures/
 ├── arrays/          # Dynamic arrays with auto-resize
-├── linked-lists/    # Singly linked list implementation  
+├── linked-lists/    # Singly and doubly linked lists
 ├── stacks/          # LIFO stack operations
 ├── queues/          # FIFO queue (regular & circular)
-├── trees/           # Binary search trees
-└── hash-tables/     # Hash table with chaining
+├── trees/           # BST, AVL, red-black, treap, splay and B-trees
+├── tries/           # Trie and radix tree
+├── segment-trees/   # Segment and Fenwick trees
+├── spatial/         # k-d tree and quadtree
+├── heaps/           # Binary, d-ary and indexed heaps
+├── hash-tables/     # Hash table with chaining
+├── hash-ring/       # Consistent hashing
+├── caches/          # LRU and LFU caches
+└── probabilistic/   # Bloom filter, Count-Min Sketch, HyperLogLog
 
 algorithms/
 ├── sorting/         # Bubble, merge, quick, heap sort